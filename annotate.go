@@ -0,0 +1,92 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type annotation struct {
+	key   string
+	value any
+}
+
+// annotatedTB is a testing.TB that appends its annotations to every message it logs
+type annotatedTB struct {
+	testing.TB
+	annotations []annotation
+}
+
+var _ testing.TB = &annotatedTB{}
+
+// With returns a testing.TB that includes the given key value pairs in every failure message
+// logged through it, e.g. With(tb, "request_id", id). Calls may be nested to add further annotations.
+// A trailing key without a value is recorded with the value "(MISSING)"
+func With(tb testing.TB, keyvals ...any) testing.TB {
+	annotated := &annotatedTB{TB: tb}
+	if parent, ok := tb.(*annotatedTB); ok {
+		annotated.TB = parent.TB
+		annotated.annotations = append(annotated.annotations, parent.annotations...)
+	}
+
+	for i := 0; i < len(keyvals); i += 2 {
+		a := annotation{key: fmt.Sprint(keyvals[i]), value: "(MISSING)"}
+		if i+1 < len(keyvals) {
+			a.value = keyvals[i+1]
+		}
+		annotated.annotations = append(annotated.annotations, a)
+	}
+
+	return annotated
+}
+
+func (a *annotatedTB) annotate(msg string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(msg, "\n"))
+	b.WriteString("\n")
+	for _, an := range a.annotations {
+		fmt.Fprintf(&b, " @ %v: %v\n", an.key, formatValue(an.value))
+	}
+	return b.String()
+}
+
+// warnFunc implements warner, annotating warnings like any other failure.
+func (a *annotatedTB) warnFunc() func(message string) {
+	warn := warnerOf(a.TB)
+	if warn == nil {
+		return nil
+	}
+	return func(message string) {
+		warn(a.annotate(message))
+	}
+}
+
+// Error implements testing.TB.
+func (a *annotatedTB) Error(args ...any) {
+	a.TB.Error(a.annotate(fmt.Sprint(args...)))
+}
+
+// Errorf implements testing.TB.
+func (a *annotatedTB) Errorf(format string, args ...any) {
+	a.TB.Error(a.annotate(fmt.Sprintf(format, args...)))
+}
+
+// Fatal implements testing.TB.
+func (a *annotatedTB) Fatal(args ...any) {
+	a.TB.Fatal(a.annotate(fmt.Sprint(args...)))
+}
+
+// Fatalf implements testing.TB.
+func (a *annotatedTB) Fatalf(format string, args ...any) {
+	a.TB.Fatal(a.annotate(fmt.Sprintf(format, args...)))
+}
+
+// Log implements testing.TB.
+func (a *annotatedTB) Log(args ...any) {
+	a.TB.Log(a.annotate(fmt.Sprint(args...)))
+}
+
+// Logf implements testing.TB.
+func (a *annotatedTB) Logf(format string, args ...any) {
+	a.TB.Log(a.annotate(fmt.Sprintf(format, args...)))
+}
@@ -0,0 +1,65 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// recordingTB captures logged messages
+type recordingTB struct {
+	*TesterTB
+	logs []string
+}
+
+func (r *recordingTB) Log(args ...any) {
+	r.logs = append(r.logs, fmt.Sprint(args...))
+}
+
+func (r *recordingTB) Logf(format string, args ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func TestWith(t *testing.T) {
+	cases := []struct {
+		name     string
+		keyvals  [][]any
+		expected string
+	}{
+		{
+			name:     "no annotations",
+			keyvals:  [][]any{{}},
+			expected: "Unexpected error occurred\n > Error: error\n",
+		},
+		{
+			name:     "single annotation",
+			keyvals:  [][]any{{"request_id", 42}},
+			expected: "Unexpected error occurred\n > Error: error\n @ request_id: 42\n",
+		},
+		{
+			name:     "nested",
+			keyvals:  [][]any{{"scenario", "a"}, {"step", 2, "user", "x"}},
+			expected: "Unexpected error occurred\n > Error: error\n @ scenario: \"a\"\n @ step: 2\n @ user: \"x\"\n",
+		},
+		{
+			name:     "missing value",
+			keyvals:  [][]any{{"dangling"}},
+			expected: "Unexpected error occurred\n > Error: error\n @ dangling: \"(MISSING)\"\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := &recordingTB{TesterTB: NewTester(t, true)}
+
+			var tb testing.TB = rec
+			for _, kv := range tc.keyvals {
+				tb = With(tb, kv...)
+			}
+
+			NoError(tb, errors.New("error"))
+			rec.AssertExpectation()
+			Equal(t, []string{tc.expected}, rec.logs)
+		})
+	}
+}
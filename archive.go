@@ -0,0 +1,264 @@
+package assertions
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// ArchiveEntry is the expected state of a file in an archive.
+// A zero Mode is not compared
+type ArchiveEntry struct {
+	Mode    fs.FileMode
+	Content []byte
+}
+
+// readArchive reads the regular files of a zip, tar or gzip compressed tar archive, keyed by name.
+// Directories and links are skipped
+func readArchive(r io.Reader) (map[string]ArchiveEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")), bytes.HasPrefix(data, []byte("PK\x05\x06")):
+		return readZip(data)
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		return readTar(gz)
+	}
+
+	return readTar(bytes.NewReader(data))
+}
+
+func readZip(data []byte) (map[string]ArchiveEntry, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]ArchiveEntry)
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", f.Name, err)
+		}
+		entries[f.Name] = ArchiveEntry{Mode: f.Mode(), Content: content}
+	}
+	return entries, nil
+}
+
+// tarBlockSize is the size of the blocks a tar archive is made of
+const tarBlockSize = 512
+
+// readTar reads the regular files of a tar archive. tar.Reader treats input that ends before the first
+// header as an empty archive, so an archive without entries must start with the zero block that ends one
+func readTar(r io.Reader) (map[string]ArchiveEntry, error) {
+	br := bufio.NewReader(r)
+	first, _ := br.Peek(tarBlockSize)
+	tr := tar.NewReader(br)
+
+	entries := make(map[string]ArchiveEntry)
+	empty := true
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			if empty && !bytes.Equal(first, make([]byte, tarBlockSize)) {
+				return nil, fmt.Errorf("not a tar archive: %w", tar.ErrHeader)
+			}
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		empty = false
+		if !hdr.FileInfo().Mode().IsRegular() {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", hdr.Name, err)
+		}
+		entries[hdr.Name] = ArchiveEntry{Mode: hdr.FileInfo().Mode(), Content: content}
+	}
+}
+
+// describeBytesDifference summarizes how input differs from expected, by line for text and by offset otherwise.
+// It returns an empty string when they are equal
+func describeBytesDifference(expected, input []byte) string {
+	if bytes.Equal(expected, input) {
+		return ""
+	}
+
+	if utf8.Valid(expected) && utf8.Valid(input) {
+		el, il := strings.Split(string(expected), "\n"), strings.Split(string(input), "\n")
+		for i := range max(len(el), len(il)) {
+			switch {
+			case i >= len(el):
+				return fmt.Sprintf("unexpected line %v: %q", i+1, il[i])
+			case i >= len(il):
+				return fmt.Sprintf("missing line %v: %q", i+1, el[i])
+			case el[i] != il[i]:
+				return fmt.Sprintf("line %v: expected %q, got %q", i+1, el[i], il[i])
+			}
+		}
+	}
+
+	offset := 0
+	for offset < len(expected) && offset < len(input) && expected[offset] == input[offset] {
+		offset++
+	}
+	return fmt.Sprintf("expected %v bytes, got %v bytes, first difference at offset %v", len(expected), len(input), offset)
+}
+
+// archiveProblems compares the entries of an archive against expected. Entries not in expected
+// are only reported when exact is set
+func archiveProblems(expected, input map[string]ArchiveEntry, exact bool) string {
+	var b strings.Builder
+
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	sortPrinted(names)
+
+	for _, name := range names {
+		want := expected[name]
+		got, ok := input[name]
+		if !ok {
+			fmt.Fprintf(&b, " ~ %v: missing from archive\n", name)
+			continue
+		}
+		if want.Mode != 0 && want.Mode != got.Mode {
+			fmt.Fprintf(&b, " ~ %v: mode expected %v, got %v\n", name, want.Mode, got.Mode)
+		}
+		if msg := describeBytesDifference(want.Content, got.Content); msg != "" {
+			fmt.Fprintf(&b, " ~ %v: %v\n", name, msg)
+		}
+	}
+
+	if exact {
+		extra := make([]string, 0)
+		for name := range input {
+			if _, ok := expected[name]; !ok {
+				extra = append(extra, name)
+			}
+		}
+		sortPrinted(extra)
+		for _, name := range extra {
+			fmt.Fprintf(&b, " ~ %v: not expected in archive\n", name)
+		}
+	}
+
+	return b.String()
+}
+
+// ArchiveContains asserts that the zip, tar or gzip compressed tar archive read from archive contains each of entries,
+// keyed by name, with the given contents. Other entries in the archive are ignored
+func ArchiveContains(tb testing.TB, archive io.Reader, entries map[string][]byte) {
+	summary.recordAssertion()
+
+	expected := make(map[string]ArchiveEntry, len(entries))
+	for name, content := range entries {
+		expected[name] = ArchiveEntry{Content: content}
+	}
+	assertArchive(tb, archive, expected, false)
+}
+
+// ArchiveEntriesMatch asserts that the regular files in the zip, tar or gzip compressed tar archive read from archive
+// are exactly those in expected, with matching modes and contents
+func ArchiveEntriesMatch(tb testing.TB, archive io.Reader, expected map[string]ArchiveEntry) {
+	summary.recordAssertion()
+
+	assertArchive(tb, archive, expected, true)
+}
+
+func assertArchive(tb testing.TB, archive io.Reader, expected map[string]ArchiveEntry, exact bool) {
+	const invalidFormat = "Archive could not be read\n > error: %v\n"
+	const failureFormat = "Archive entries do not match\n%v"
+
+	input, err := readArchive(archive)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+
+	if problems := archiveProblems(expected, input, exact); problems != "" {
+		errorfNow(tb, failureFormat, problems)
+		return
+	}
+}
+
+// FSMatchesArchive asserts that the regular files in fsys, such as an embed.FS, are exactly those in the
+// zip, tar or gzip compressed tar archive at archivePath with the same contents. Modes are not compared
+func FSMatchesArchive(tb testing.TB, fsys fs.FS, archivePath string) {
+	const fsFormat = "File system could not be read\n > error: %v\n"
+	const openFormat = "Archive could not be opened\n > error: %v\n"
+	const invalidFormat = "Archive could not be read\n > error: %v\n"
+	const failureFormat = "Archive entries do not match\n%v"
+
+	summary.recordAssertion()
+
+	expected := make(map[string]ArchiveEntry)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		expected[name] = ArchiveEntry{Content: content}
+		return nil
+	})
+	if err != nil {
+		errorfNow(tb, fsFormat, err)
+		return
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		errorfNow(tb, openFormat, err)
+		return
+	}
+	defer f.Close()
+
+	entries, err := readArchive(f)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+
+	// Archives created from a directory, as with tar -C dir ., name their entries relative to it
+	input := make(map[string]ArchiveEntry, len(entries))
+	for name, entry := range entries {
+		input[strings.TrimPrefix(name, "./")] = entry
+	}
+
+	if problems := archiveProblems(expected, input, true); problems != "" {
+		errorfNow(tb, failureFormat, problems)
+		return
+	}
+}
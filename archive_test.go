@@ -0,0 +1,253 @@
+package assertions
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+type archiveFile struct {
+	name    string
+	mode    fs.FileMode
+	content string
+}
+
+func tarArchive(t *testing.T, files ...archiveFile) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	NoError(t, tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for _, f := range files {
+		NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Mode: int64(f.mode), Size: int64(len(f.content))}))
+		_, err := io.WriteString(tw, f.content)
+		NoError(t, err)
+	}
+	NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func zipArchive(t *testing.T, files ...archiveFile) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		hdr := &zip.FileHeader{Name: f.name, Method: zip.Deflate}
+		hdr.SetMode(f.mode)
+		w, err := zw.CreateHeader(hdr)
+		NoError(t, err)
+		_, err = io.WriteString(w, f.content)
+		NoError(t, err)
+	}
+	NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(data)
+	NoError(t, err)
+	NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestArchiveContains(t *testing.T) {
+	files := []archiveFile{
+		{name: "dir/a.txt", mode: 0o644, content: "a\nb\n"},
+		{name: "bin/run", mode: 0o755, content: "#!/bin/sh\n"},
+	}
+
+	formats := map[string][]byte{
+		"tar":    tarArchive(t, files...),
+		"tar.gz": gzipBytes(t, tarArchive(t, files...)),
+		"zip":    zipArchive(t, files...),
+	}
+
+	cases := []struct {
+		name     string
+		entries  map[string][]byte
+		mustFail bool
+	}{
+		{name: "subset", entries: map[string][]byte{"dir/a.txt": []byte("a\nb\n")}, mustFail: false},
+		{name: "content differs", entries: map[string][]byte{"dir/a.txt": []byte("a\nc\n")}, mustFail: true},
+		{name: "missing entry", entries: map[string][]byte{"dir/b.txt": nil}, mustFail: true},
+	}
+
+	for format, archive := range formats {
+		for _, tc := range cases {
+			t.Run(format+"/"+tc.name, func(t *testing.T) {
+				tb := NewTester(t, tc.mustFail)
+
+				ArchiveContains(tb, bytes.NewReader(archive), tc.entries)
+				tb.AssertExpectation()
+			})
+		}
+	}
+}
+
+func TestArchiveEntriesMatch(t *testing.T) {
+	archive := tarArchive(t,
+		archiveFile{name: "dir/a.txt", mode: 0o644, content: "a"},
+		archiveFile{name: "bin/run", mode: 0o755, content: "run"},
+	)
+
+	cases := []struct {
+		name     string
+		expected map[string]ArchiveEntry
+		mustFail bool
+	}{
+		{
+			name: "exact",
+			expected: map[string]ArchiveEntry{
+				"dir/a.txt": {Mode: 0o644, Content: []byte("a")},
+				"bin/run":   {Mode: 0o755, Content: []byte("run")},
+			},
+			mustFail: false,
+		},
+		{
+			name: "mode not compared",
+			expected: map[string]ArchiveEntry{
+				"dir/a.txt": {Content: []byte("a")},
+				"bin/run":   {Content: []byte("run")},
+			},
+			mustFail: false,
+		},
+		{
+			name: "wrong mode",
+			expected: map[string]ArchiveEntry{
+				"dir/a.txt": {Mode: 0o644, Content: []byte("a")},
+				"bin/run":   {Mode: 0o644, Content: []byte("run")},
+			},
+			mustFail: true,
+		},
+		{
+			name: "unexpected entry",
+			expected: map[string]ArchiveEntry{
+				"dir/a.txt": {Content: []byte("a")},
+			},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ArchiveEntriesMatch(tb, bytes.NewReader(archive), tc.expected)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestFSMatchesArchive(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "assets.tar.gz")
+	NoError(t, os.WriteFile(archivePath, gzipBytes(t, tarArchive(t,
+		archiveFile{name: "./index.html", mode: 0o644, content: "<html>\n"},
+		archiveFile{name: "./css/site.css", mode: 0o644, content: "body {}\n"},
+	)), 0o644))
+
+	cases := []struct {
+		name     string
+		fsys     fstest.MapFS
+		path     string
+		mustFail bool
+	}{
+		{
+			name: "in sync",
+			fsys: fstest.MapFS{
+				"index.html":   {Data: []byte("<html>\n"), Mode: 0o444},
+				"css/site.css": {Data: []byte("body {}\n"), Mode: 0o444},
+			},
+			path:     archivePath,
+			mustFail: false,
+		},
+		{
+			name: "changed content",
+			fsys: fstest.MapFS{
+				"index.html":   {Data: []byte("<html lang=en>\n")},
+				"css/site.css": {Data: []byte("body {}\n")},
+			},
+			path:     archivePath,
+			mustFail: true,
+		},
+		{
+			name: "file not packaged",
+			fsys: fstest.MapFS{
+				"index.html":   {Data: []byte("<html>\n")},
+				"css/site.css": {Data: []byte("body {}\n")},
+				"favicon.ico":  {Data: []byte{0}},
+			},
+			path:     archivePath,
+			mustFail: true,
+		},
+		{
+			name: "file not embedded",
+			fsys: fstest.MapFS{
+				"index.html": {Data: []byte("<html>\n")},
+			},
+			path:     archivePath,
+			mustFail: true,
+		},
+		{
+			name:     "missing archive",
+			fsys:     fstest.MapFS{},
+			path:     filepath.Join(dir, "missing.tar"),
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			FSMatchesArchive(tb, tc.fsys, tc.path)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestArchiveInvalid(t *testing.T) {
+	tb := NewTester(t, true)
+
+	ArchiveContains(tb, bytes.NewReader([]byte("PK\x03\x04 not really a zip")), nil)
+	tb.AssertExpectation()
+}
+
+func TestArchiveGarbage(t *testing.T) {
+	var empty bytes.Buffer
+	NoError(t, tar.NewWriter(&empty).Close())
+
+	cases := []struct {
+		name     string
+		input    []byte
+		mustFail bool
+	}{
+		{name: "empty tar", input: empty.Bytes(), mustFail: false},
+		{name: "empty tar.gz", input: gzipBytes(t, empty.Bytes()), mustFail: false},
+		{name: "no input", input: nil, mustFail: true},
+		{name: "garbage", input: []byte("not an archive"), mustFail: true},
+		{name: "gzip garbage", input: gzipBytes(t, []byte("not an archive")), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ArchiveContains(tb, bytes.NewReader(tc.input), nil)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestDescribeBytesDifference(t *testing.T) {
+	Equal(t, "", describeBytesDifference([]byte("a"), []byte("a")))
+	Equal(t, `line 2: expected "b", got "c"`, describeBytesDifference([]byte("a\nb"), []byte("a\nc")))
+	Equal(t, `missing line 2: "b"`, describeBytesDifference([]byte("a\nb"), []byte("a")))
+	Equal(t, "expected 2 bytes, got 2 bytes, first difference at offset 1", describeBytesDifference([]byte{0, 0xff}, []byte{0, 0xfe}))
+}
@@ -2,13 +2,27 @@ package assertions
 
 import (
 	"cmp"
+	"errors"
+	"fmt"
 	"reflect"
+	"runtime"
 	"runtime/debug"
+	"strings"
 	"testing"
 )
 
 func errorfNow(tb testing.TB, format string, args ...any) {
-	tb.Logf(format, args...)
+	if warn := warnerOf(tb); warn != nil {
+		warn(fmt.Sprintf(format, args...))
+		return
+	}
+
+	summary.recordFailure(tb, format, args)
+	if colorOutput.Load() {
+		tb.Log(colorize(fmt.Sprintf(format, args...)))
+	} else {
+		tb.Logf(format, args...)
+	}
 	tb.FailNow()
 }
 
@@ -16,6 +30,8 @@ func errorfNow(tb testing.TB, format string, args ...any) {
 func NoError(tb testing.TB, input error) {
 	const failureFormat = "Unexpected error occurred\n > Error: %v\n"
 
+	summary.recordAssertion()
+
 	if input != nil {
 		errorfNow(tb, failureFormat, input)
 		return
@@ -26,6 +42,8 @@ func NoError(tb testing.TB, input error) {
 func Error(tb testing.TB, input error) {
 	const failureFormat = "expected error did not occur\n"
 
+	summary.recordAssertion()
+
 	if input == nil {
 		errorfNow(tb, failureFormat)
 		return
@@ -37,6 +55,9 @@ func Error(tb testing.TB, input error) {
 // driven test with a single expected error field.
 func ErrorsMatch(tb testing.TB, expected, input error) {
 	const failureFormat = "Errors do not match\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
 	// If the errors are equal by direct comparison they must match, either both nil or equivalent errors
 	if expected != input {
 		// Don't call .Error() on a nil error
@@ -52,45 +73,183 @@ func ErrorsMatch(tb testing.TB, expected, input error) {
 	}
 }
 
-// Equal asserts that 2 values of the same type are equal using reflect.DeepEqual
-func Equal[T any](tb testing.TB, expected, input T) {
-	const failureFormat = "Values are not equal\n > expected: %v\n < input:    %v\n"
-	if !reflect.DeepEqual(expected, input) {
-		errorfNow(tb, failureFormat, expected, input)
+// EqualErr asserts that the result of a call returning a value and an error matches the expected pair,
+// reporting both mismatches in one failure. The errors are compared as by ErrorsMatch. The values are compared
+// as by Equal, but only when expectedErr is nil since the value returned with an error is usually meaningless
+func EqualErr[T any](tb testing.TB, expectedVal T, expectedErr error, gotVal T, gotErr error, opts ...CompareOption) {
+	const failureFormat = "Results are not equal\n%v"
+
+	summary.recordAssertion()
+
+	var diffs []difference
+	if expectedErr != gotErr && (expectedErr == nil || gotErr == nil || expectedErr.Error() != gotErr.Error()) {
+		diffs = append(diffs, difference{path: "error", expected: formatValue(expectedErr), input: formatValue(gotErr)})
+	}
+	if expectedErr == nil {
+		diffs = append(diffs, diffValues("value", expectedVal, gotVal, opts...)...)
+	}
+
+	if len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(diffs))
+		return
+	}
+}
+
+// ErrorAs asserts that input has an error of type E in its chain, as determined by errors.As, and returns it.
+// On failure the zero value of E is returned
+func ErrorAs[E error](tb testing.TB, input error) E {
+	const failureFormat = "Error is not of the expected type\n > expected type: %v\n < input:         %v\n"
+
+	summary.recordAssertion()
+
+	var target E
+	if !errors.As(input, &target) {
+		errorfNow(tb, failureFormat, reflect.TypeFor[E](), formatValue(input))
+		return target
+	}
+
+	return target
+}
+
+// ErrorFieldEqual asserts that input has an error of type E in its chain and that the value returned by
+// extractor for that error is equal to expected. Values are compared with the semantics of Equal
+func ErrorFieldEqual[E error](tb testing.TB, input error, extractor func(E) any, expected any) {
+	const typeFormat = "Error is not of the expected type\n > expected type: %v\n < input:         %v\n"
+	const failureFormat = "Error field does not match\n > error: %v\n%v"
+
+	summary.recordAssertion()
+
+	var target E
+	if !errors.As(input, &target) {
+		errorfNow(tb, typeFormat, reflect.TypeFor[E](), formatValue(input))
+		return
+	}
+
+	diffs := diffValues("", expected, extractor(target))
+	if len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatValue(target), formatDifferences(diffs))
+		return
+	}
+}
+
+// Equal asserts that 2 values of the same type are equal with the semantics of reflect.DeepEqual,
+// cyclic values are supported and matching cycles are equal.
+// When the values differ inside nested maps, slices or structs the path to each differing leaf is reported.
+// Options relax the comparison, see FollowPointers and WithWildcard, and StopAfter limits the differences reported.
+// Anything may be used in expected to ignore a position entirely.
+// Very large values can be written to files instead of the log, see SetDumpThreshold
+func Equal[T any](tb testing.TB, expected, input T, opts ...CompareOption) {
+	summary.recordAssertion()
+
+	equal(tb, "Values are not equal", expected, input, opts)
+}
+
+// EqualLoose is Equal treating nil and empty slices and maps as equal, as SlicesMatch does.
+// Failing results say that these semantics applied
+func EqualLoose[T any](tb testing.TB, expected, input T, opts ...CompareOption) {
+	summary.recordAssertion()
+
+	equal(tb, "Values are not equal (nil and empty slices and maps are equal)", expected, input, append(opts[:len(opts):len(opts)], NilEqualsEmpty()))
+}
+
+// EqualStrict is Equal, which distinguishes nil from empty slices and maps as reflect.DeepEqual does,
+// for code that wants to say so. Failing results say that these semantics applied
+func EqualStrict[T any](tb testing.TB, expected, input T, opts ...CompareOption) {
+	summary.recordAssertion()
+
+	equal(tb, "Values are not equal (nil and empty slices and maps differ)", expected, input, opts)
+}
+
+func equal(tb testing.TB, header string, expected, input any, opts []CompareOption) {
+	const failureFormat = "%v\n%v"
+
+	d := compareValues("", expected, input, opts...)
+	diffs := d.diffs
+	if len(diffs) == 0 {
+		return
 	}
+
+	if dumpEnabled() {
+		if expectedDump, inputDump := dumpContents(expected), dumpContents(input); shouldDump(expectedDump, inputDump) {
+			dumpFailure(tb, header, expectedDump, inputDump, diffs)
+			return
+		}
+	}
+
+	errorfNow(tb, failureFormat, header, describeDifferences(d))
 }
 
-func nonMatchingMaps[K comparable, E any, T ~map[K]E](a T, b T) (T, T) {
-	aout := make(T)
-	bout := make(T)
+type mapDiff[K comparable, E any] struct {
+	expectedOnly []K
+	inputOnly    []K
+	changed      []K
+}
+
+func (d mapDiff[K, E]) empty() bool {
+	return len(d.expectedOnly) == 0 && len(d.inputOnly) == 0 && len(d.changed) == 0
+}
+
+// diffMaps sorts the keys of a and b into those only in a, those only in b
+// and those in both with values that differ by reflect.DeepEqual
+func diffMaps[K comparable, E any, T ~map[K]E](a T, b T) mapDiff[K, E] {
+	var d mapDiff[K, E]
 
 	for ak, av := range a {
 		bv, ok := b[ak]
 		if !ok {
-			aout[ak] = av
+			d.expectedOnly = append(d.expectedOnly, ak)
+			continue
 		}
 		if !reflect.DeepEqual(av, bv) {
-			aout[ak] = av
-			bout[ak] = bv
+			d.changed = append(d.changed, ak)
 		}
 	}
 
-	for bk, bv := range b {
+	for bk := range b {
 		// We've already compared all elements that exist in both maps
-		_, ok := a[bk]
-		if !ok {
-			bout[bk] = bv
+		if _, ok := a[bk]; !ok {
+			d.inputOnly = append(d.inputOnly, bk)
+		}
+	}
+
+	sortPrinted(d.expectedOnly)
+	sortPrinted(d.inputOnly)
+	sortPrinted(d.changed)
+
+	return d
+}
+
+func formatMapDiff[K comparable, E any, T ~map[K]E](d mapDiff[K, E], expected, input T) string {
+	var b strings.Builder
+
+	if len(d.expectedOnly) > 0 {
+		b.WriteString(" keys only in expected:\n")
+		for _, k := range d.expectedOnly {
+			fmt.Fprintf(&b, "  > %v: %v\n", formatValue(k), formatValue(expected[k]))
+		}
+	}
+	if len(d.inputOnly) > 0 {
+		b.WriteString(" keys only in input:\n")
+		for _, k := range d.inputOnly {
+			fmt.Fprintf(&b, "  < %v: %v\n", formatValue(k), formatValue(input[k]))
+		}
+	}
+	if len(d.changed) > 0 {
+		b.WriteString(" keys with differing values:\n")
+		for _, k := range d.changed {
+			b.WriteString(formatDifferences(diffValues(fmt.Sprintf("[%v]", formatValue(k)), expected[k], input[k])))
 		}
 	}
 
-	return aout, bout
+	return b.String()
 }
 
 func nonMatchingSlices[E any, T ~[]E](a T, b T) (T, T) {
 	nonMatchedA := make(T, 0)
 	nonMatchedB := make(T, 0)
 
-	visited := make([]bool, len(a))
+	// Sized for either slice, the first pass marks elements of b and the second elements of a
+	visited := make([]bool, max(len(a), len(b)))
 
 	for _, elementA := range a {
 		found := false
@@ -127,42 +286,199 @@ func nonMatchingSlices[E any, T ~[]E](a T, b T) (T, T) {
 
 // SlicesMatch asserts that both expected and input have the same members regardless of order
 // elements in expected and input are compared using reflect.DeepEqual.
-// Failing results will only print the non-matching elements
+// Failing results will only print the non-matching elements.
+// The comparison takes quadratic time, prefer SlicesMatchC for comparable elements
 func SlicesMatch[E any, T ~[]E](tb testing.TB, expected, input T) {
+	summary.recordAssertion()
+
+	slicesMatch(tb, expected, input)
+}
+
+func slicesMatch[E any, T ~[]E](tb testing.TB, expected, input T) {
 	if len(expected) != len(input) {
 		errorfNow(tb, "Elements do not match, slices have different lengths\n > expected length: %v\n, < input length:    %v\n", len(expected), len(input))
 		return
 	}
 
-	const failureFormat = "Elements do not match\n > expected: %#v\n < input:    %#v\n"
+	const failureFormat = "Elements do not match\n%v"
 	// Slices will required a different approach
 	// since we're not requiring that elements be orderable we can't easily sort the elements
 	// we'll take the n^2 approach for simplicity
 	expectedNoMatch, inputNoMatch := nonMatchingSlices(expected, input)
 	if len(expectedNoMatch) > 0 || len(inputNoMatch) > 0 {
-		errorfNow(tb, failureFormat, expectedNoMatch, inputNoMatch)
+		errorfNow(tb, failureFormat, formatMultiplicities(multiplicities(expected, input, append(expectedNoMatch, inputNoMatch...))))
 		return
 	}
 }
 
+// SlicesMatchC asserts that both expected and input have the same members regardless of order, like SlicesMatch,
+// comparing elements with == in linear time. It is the preferred form for strings, numbers and other comparable
+// element types, SlicesMatch remains for elements that are not comparable.
+// Failing results print how many times each differing element occurs in expected and input
+func SlicesMatchC[E comparable, T ~[]E](tb testing.TB, expected, input T) {
+	const failureFormat = "Elements do not match\n%v"
+
+	summary.recordAssertion()
+
+	// Elements are kept in order of first appearance so failure output is stable
+	var order []E
+	counts := make(map[E]*multiplicity[E])
+	count := func(e E) *multiplicity[E] {
+		m, ok := counts[e]
+		if !ok {
+			m = &multiplicity[E]{element: e}
+			counts[e] = m
+			order = append(order, e)
+		}
+		return m
+	}
+	for _, e := range expected {
+		count(e).expected++
+	}
+	for _, e := range input {
+		count(e).input++
+	}
+
+	var differing []multiplicity[E]
+	for _, e := range order {
+		if m := counts[e]; m.expected != m.input {
+			differing = append(differing, *m)
+		}
+	}
+	if len(differing) > 0 {
+		errorfNow(tb, failureFormat, formatMultiplicities(differing))
+		return
+	}
+}
+
+// MostlyEqual asserts that expected and input have the same length and that at most maxMismatches of their
+// elements differ, comparing each pair as Equal does. It is meant for lossy transforms and probabilistic
+// pipelines where exact equality is the wrong bar. Failing results list the index of every differing element
+func MostlyEqual[E any, T ~[]E](tb testing.TB, expected, input T, maxMismatches int, opts ...CompareOption) {
+	const lengthFormat = "Slices have different lengths\n > expected: %v\n < input:    %v\n"
+	const failureFormat = "Too many elements differ\n ~ %v of %v elements differ, at most %v may\n ~ indices: %v\n%v"
+
+	summary.recordAssertion()
+
+	if len(expected) != len(input) {
+		errorfNow(tb, lengthFormat, len(expected), len(input))
+		return
+	}
+
+	var indices []int
+	var diffs []difference
+	for i := range expected {
+		if d := diffValues(fmt.Sprintf("[%v]", i), expected[i], input[i], opts...); len(d) > 0 {
+			indices = append(indices, i)
+			diffs = append(diffs, d...)
+		}
+	}
+	if len(indices) > maxMismatches {
+		errorfNow(tb, failureFormat, len(indices), len(expected), maxMismatches, indices, formatDifferences(diffs))
+		return
+	}
+}
+
+type multiplicity[E any] struct {
+	element  E
+	expected int
+	input    int
+}
+
+// multiplicities counts how many times each distinct element of candidates occurs in expected and input.
+// Distinct elements are determined using reflect.DeepEqual and are returned in order of first appearance
+func multiplicities[E any, T ~[]E](expected, input, candidates T) []multiplicity[E] {
+	counts := make([]multiplicity[E], 0)
+
+	count := func(s T, e E) int {
+		n := 0
+		for _, v := range s {
+			if reflect.DeepEqual(v, e) {
+				n++
+			}
+		}
+		return n
+	}
+
+outer:
+	for _, c := range candidates {
+		for _, m := range counts {
+			if reflect.DeepEqual(m.element, c) {
+				continue outer
+			}
+		}
+		counts = append(counts, multiplicity[E]{element: c, expected: count(expected, c), input: count(input, c)})
+	}
+
+	return counts
+}
+
+func formatMultiplicities[E any](counts []multiplicity[E]) string {
+	var b strings.Builder
+	for _, m := range counts {
+		fmt.Fprintf(&b, " ~ %v: expected %v×, got %v×\n", formatValue(m.element), m.expected, m.input)
+	}
+	return b.String()
+}
+
 // MapsMatch asserts that both expected and input have the same members regardless of order
 // elements in expected and input are compared using reflect.DeepEqual.
-// Failing results will only print the non-matching elements
+// Failing results will only print the non-matching elements, grouped into keys only in expected,
+// keys only in input and keys whose values differ
 func MapsMatch[K comparable, E any, T ~map[K]E](tb testing.TB, expected, input T) {
-	const failureFormat = "Elements do not match\n > expected: %#v\n < input:    %#v\n"
+	summary.recordAssertion()
 
-	expectedNoMatch, inputNoMatch := nonMatchingMaps(expected, input)
-	if len(expectedNoMatch) > 0 || len(inputNoMatch) > 0 {
-		errorfNow(tb, failureFormat, expectedNoMatch, inputNoMatch)
+	mapsMatch(tb, expected, input)
+}
+
+func mapsMatch[K comparable, E any, T ~map[K]E](tb testing.TB, expected, input T) {
+	const failureFormat = "Elements do not match\n%v"
+
+	d := diffMaps(expected, input)
+	if !d.empty() {
+		errorfNow(tb, failureFormat, formatMapDiff(d, expected, input))
 		return
 	}
 }
 
+// KeysEqual asserts that the keys of m are exactly expectedKeys, in any order, for when only the key set
+// of a map matters. Failing results print the keys only in one of them
+func KeysEqual[K comparable, V any, T ~map[K]V](tb testing.TB, expectedKeys []K, m T) {
+	const failureFormat = "Map keys are not equal\n > only in expected: %#v\n < only in input:    %#v\n"
+
+	summary.recordAssertion()
+
+	expected, input := NewSet(expectedKeys...), make(Set[K], len(m))
+	for k := range m {
+		input.Add(k)
+	}
+
+	expectedOnly, inputOnly := expected.difference(input), input.difference(expected)
+	if len(expectedOnly) > 0 || len(inputOnly) > 0 {
+		errorfNow(tb, failureFormat, expectedOnly, inputOnly)
+		return
+	}
+}
+
+// ValuesMatch asserts that the values of m are the elements of expectedValues regardless of order,
+// compared as by SlicesMatch, so a value appearing under several keys must appear as many times in expectedValues
+func ValuesMatch[K comparable, V any, T ~map[K]V](tb testing.TB, expectedValues []V, m T) {
+	summary.recordAssertion()
+
+	input := make([]V, 0, len(m))
+	for _, v := range m {
+		input = append(input, v)
+	}
+	slicesMatch(tb, expectedValues, input)
+}
+
 // Within asserts that input is within the range [minT, maxT]
 // The assertion will pass while input is >= minT and input is <= maxT
 func Within[T cmp.Ordered](tb testing.TB, minT, maxT, input T) {
 	const failureFormat = "value is not in the expected range\n > expected: [%v, %v]\n < input: %v\n"
 
+	summary.recordAssertion()
+
 	if input < minT || input > maxT {
 		errorfNow(tb, failureFormat, minT, maxT, input)
 		return
@@ -186,7 +502,9 @@ func panicHandler(fn func()) (panicked bool, msg any, stack string) {
 
 // Panics asserts that the provided function panics during execution
 func Panics(tb testing.TB, fn func()) {
-	const failureFormat = "function %#v did not panic\n > revcovered value: %#v\n"
+	const failureFormat = "function %p did not panic\n > revcovered value: %#v\n"
+
+	summary.recordAssertion()
 
 	panicked, recovered, _ := panicHandler(fn)
 	if !panicked {
@@ -195,9 +513,72 @@ func Panics(tb testing.TB, fn func()) {
 	}
 }
 
+// callArguments converts args to the values fn is called with, checking them against the parameters of fn
+func callArguments(fn reflect.Value, args []any) ([]reflect.Value, error) {
+	t := fn.Type()
+	if t.IsVariadic() && len(args) < t.NumIn()-1 || !t.IsVariadic() && len(args) != t.NumIn() {
+		return nil, fmt.Errorf("%v takes %v arguments, got %v", t, t.NumIn(), len(args))
+	}
+
+	values := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var param reflect.Type
+		if t.IsVariadic() && i >= t.NumIn()-1 {
+			param = t.In(t.NumIn() - 1).Elem()
+		} else {
+			param = t.In(i)
+		}
+
+		if arg == nil {
+			switch param.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+				values[i] = reflect.Zero(param)
+				continue
+			}
+			return nil, fmt.Errorf("argument %v is nil, which cannot be used as %v", i, param)
+		}
+
+		v := reflect.ValueOf(arg)
+		if !v.Type().AssignableTo(param) {
+			return nil, fmt.Errorf("argument %v is %v, which cannot be used as %v", i, v.Type(), param)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// PanicsCall asserts that calling fn, which may be any function, with args panics.
+// Arguments must be assignable to the parameters of fn, nil is the zero value of
+// pointer, interface, slice, map, channel and function parameters
+func PanicsCall(tb testing.TB, fn any, args ...any) {
+	const invalidFormat = "function could not be called\n > error: %v\n"
+	const failureFormat = "function %v did not panic\n > arguments: %v\n"
+
+	summary.recordAssertion()
+
+	rv := reflect.ValueOf(fn)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		errorfNow(tb, invalidFormat, fmt.Sprintf("%v is not a function", formatValue(fn)))
+		return
+	}
+	values, err := callArguments(rv, args)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+
+	panicked, _, _ := panicHandler(func() { rv.Call(values) })
+	if !panicked {
+		errorfNow(tb, failureFormat, runtime.FuncForPC(rv.Pointer()).Name(), formatValue(args))
+		return
+	}
+}
+
 // NotPanics asserts that the provided function does not panic durion execution
 func NotPanics(tb testing.TB, fn func()) {
-	const failureFormat = "function %#v panic\n > revcovered value: %#v\n > stack: %v\n"
+	const failureFormat = "function %p panic\n > revcovered value: %#v\n > stack: %v\n"
+
+	summary.recordAssertion()
 
 	panicked, recovered, stack := panicHandler(fn)
 	if panicked {
@@ -213,6 +213,36 @@ func TestMapsMatchAny(t *testing.T) {
 			expected: map[string]any{},
 			mustFail: false,
 		},
+		{
+			name:     "match",
+			input:    map[string]any{"a": 1, "b": []int{2}},
+			expected: map[string]any{"b": []int{2}, "a": 1},
+			mustFail: false,
+		},
+		{
+			name:     "missing key",
+			input:    map[string]any{"a": 1},
+			expected: map[string]any{"a": 1, "b": 2},
+			mustFail: true,
+		},
+		{
+			name:     "extra key",
+			input:    map[string]any{"a": 1, "b": 2},
+			expected: map[string]any{"a": 1},
+			mustFail: true,
+		},
+		{
+			name:     "differing value",
+			input:    map[string]any{"a": 1},
+			expected: map[string]any{"a": 2},
+			mustFail: true,
+		},
+		{
+			name:     "zero value is not missing",
+			input:    map[string]any{"a": nil},
+			expected: map[string]any{},
+			mustFail: true,
+		},
 	}
 
 	for _, tc := range cases {
@@ -224,3 +254,337 @@ func TestMapsMatchAny(t *testing.T) {
 		})
 	}
 }
+
+func TestKeysEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []string
+		input    map[string]int
+		mustFail bool
+	}{
+		{name: "equal", expected: []string{"b", "a"}, input: map[string]int{"a": 1, "b": 2}, mustFail: false},
+		{name: "both empty", expected: nil, input: map[string]int{}, mustFail: false},
+		{name: "missing key", expected: []string{"a", "b", "c"}, input: map[string]int{"a": 1, "b": 2}, mustFail: true},
+		{name: "extra key", expected: []string{"a"}, input: map[string]int{"a": 1, "b": 2}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			KeysEqual(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestValuesMatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []int
+		input    map[string]int
+		mustFail bool
+	}{
+		{name: "match", expected: []int{2, 1}, input: map[string]int{"a": 1, "b": 2}, mustFail: false},
+		{name: "repeated value", expected: []int{1, 1}, input: map[string]int{"a": 1, "b": 1}, mustFail: false},
+		{name: "repeated value counted once", expected: []int{1}, input: map[string]int{"a": 1, "b": 1}, mustFail: true},
+		{name: "different value", expected: []int{1, 3}, input: map[string]int{"a": 1, "b": 2}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ValuesMatch(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSlicesMatchMultiplicities(t *testing.T) {
+	expected := []string{"foo", "foo", "foo", "bar"}
+	input := []string{"foo", "bar", "bar", "baz"}
+
+	expectedNoMatch, inputNoMatch := nonMatchingSlices(expected, input)
+	counts := multiplicities(expected, input, append(expectedNoMatch, inputNoMatch...))
+
+	Equal(t, []multiplicity[string]{
+		{element: "foo", expected: 3, input: 1},
+		{element: "bar", expected: 1, input: 2},
+		{element: "baz", expected: 0, input: 1},
+	}, counts)
+	Equal(t, " ~ \"foo\": expected 3×, got 1×\n ~ \"bar\": expected 1×, got 2×\n ~ \"baz\": expected 0×, got 1×\n", formatMultiplicities(counts))
+}
+
+func TestSlicesMatchC(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []string
+		input    []string
+		mustFail bool
+	}{
+		{name: "same order", expected: []string{"a", "b"}, input: []string{"a", "b"}, mustFail: false},
+		{name: "any order", expected: []string{"a", "b", "b"}, input: []string{"b", "a", "b"}, mustFail: false},
+		{name: "both empty", expected: nil, input: []string{}, mustFail: false},
+		{name: "different multiplicity", expected: []string{"a", "b", "b"}, input: []string{"a", "a", "b"}, mustFail: true},
+		{name: "different length", expected: []string{"a"}, input: []string{"a", "b"}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SlicesMatchC(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSlicesMatchCMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	SlicesMatchC(tb, []string{"foo", "foo", "foo", "bar", "qux"}, []string{"qux", "foo", "bar", "bar", "baz"})
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Elements do not match\n ~ \"foo\": expected 3×, got 1×\n ~ \"bar\": expected 1×, got 2×\n ~ \"baz\": expected 0×, got 1×\n"}, tb.logs)
+}
+
+func TestMostlyEqual(t *testing.T) {
+	cases := []struct {
+		name          string
+		expected      []int
+		input         []int
+		maxMismatches int
+		mustFail      bool
+	}{
+		{name: "equal", expected: []int{1, 2, 3}, input: []int{1, 2, 3}, maxMismatches: 0, mustFail: false},
+		{name: "within budget", expected: []int{1, 2, 3}, input: []int{1, 5, 3}, maxMismatches: 1, mustFail: false},
+		{name: "over budget", expected: []int{1, 2, 3}, input: []int{0, 5, 3}, maxMismatches: 1, mustFail: true},
+		{name: "different lengths", expected: []int{1, 2, 3}, input: []int{1, 2}, maxMismatches: 3, mustFail: true},
+		{name: "both empty", expected: nil, input: []int{}, maxMismatches: 0, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			MostlyEqual(tb, tc.expected, tc.input, tc.maxMismatches)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestMostlyEqualMessage(t *testing.T) {
+	type point struct{ X, Y int }
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	MostlyEqual(tb, []point{{1, 1}, {2, 2}, {3, 3}}, []point{{1, 0}, {2, 2}, {0, 3}}, 1)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Too many elements differ\n ~ 2 of 3 elements differ, at most 1 may\n ~ indices: [0 2]\n" +
+		" ~ [0].Y:\n   > expected: 1\n   < input:    0\n" +
+		" ~ [2].X:\n   > expected: 3\n   < input:    0\n"}, tb.logs)
+}
+
+func TestMapsMatchDiff(t *testing.T) {
+	expected := map[string]int{"a": 1, "b": 2, "c": 3}
+	input := map[string]int{"b": 2, "c": 4, "d": 5}
+
+	d := diffMaps(expected, input)
+
+	Equal(t, []string{"a"}, d.expectedOnly)
+	Equal(t, []string{"d"}, d.inputOnly)
+	Equal(t, []string{"c"}, d.changed)
+	Equal(t, " keys only in expected:\n  > \"a\": 1\n keys only in input:\n  < \"d\": 5\n keys with differing values:\n ~ [\"c\"]:\n   > expected: 3\n   < input:    4\n", formatMapDiff(d, expected, input))
+}
+
+type validationError struct {
+	Field string
+}
+
+func (v *validationError) Error() string {
+	return "invalid " + v.Field
+}
+
+func TestEqualErr(t *testing.T) {
+	type result struct {
+		Name  string
+		Count int
+	}
+
+	cases := []struct {
+		name        string
+		expectedVal result
+		expectedErr error
+		gotVal      result
+		gotErr      error
+		mustFail    bool
+	}{
+		{name: "equal", expectedVal: result{"a", 1}, gotVal: result{"a", 1}, mustFail: false},
+		{name: "different value", expectedVal: result{"a", 1}, gotVal: result{"a", 2}, mustFail: true},
+		{name: "unexpected error", expectedVal: result{"a", 1}, gotVal: result{"a", 1}, gotErr: errors.New("error"), mustFail: true},
+		{name: "missing error", expectedErr: errors.New("error"), mustFail: true},
+		{name: "matching error ignores value", expectedErr: errors.New("error"), gotVal: result{"a", 1}, gotErr: errors.New("error"), mustFail: false},
+		{name: "different error", expectedErr: errors.New("error"), gotErr: errors.New("other"), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EqualErr(tb, tc.expectedVal, tc.expectedErr, tc.gotVal, tc.gotErr)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualErrMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	EqualErr(tb, []int{1, 2}, nil, []int{1, 3}, errors.New("boom"))
+	tb.AssertExpectation()
+
+	expected := "Results are not equal\n" +
+		" ~ error:\n   > expected: nil\n   < input:    *errors.errorString(boom)\n" +
+		" ~ value[1]:\n   > expected: 2\n   < input:    3\n"
+	Equal(t, []string{expected}, tb.logs)
+}
+
+func TestErrorAs(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    error
+		mustFail bool
+	}{
+		{name: "direct", input: &validationError{Field: "email"}, mustFail: false},
+		{name: "wrapped", input: fmt.Errorf("create user: %w", &validationError{Field: "email"}), mustFail: false},
+		{name: "nil", input: nil, mustFail: true},
+		{name: "other type", input: errors.New("error"), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			target := ErrorAs[*validationError](tb, tc.input)
+			tb.AssertExpectation()
+			if !tc.mustFail {
+				Equal(t, "email", target.Field)
+			}
+		})
+	}
+}
+
+func TestErrorFieldEqual(t *testing.T) {
+	field := func(v *validationError) any { return v.Field }
+
+	cases := []struct {
+		name     string
+		input    error
+		expected any
+		mustFail bool
+	}{
+		{name: "match", input: &validationError{Field: "email"}, expected: "email", mustFail: false},
+		{name: "wrapped match", input: fmt.Errorf("create user: %w", &validationError{Field: "email"}), expected: "email", mustFail: false},
+		{name: "different field", input: &validationError{Field: "name"}, expected: "email", mustFail: true},
+		{name: "other type", input: errors.New("invalid email"), expected: "email", mustFail: true},
+		{name: "nil", input: nil, expected: "email", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ErrorFieldEqual(tb, tc.input, field, tc.expected)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestPanicsCall(t *testing.T) {
+	divide := func(a, b int) int { return a / b }
+	deref := func(p *int) int { return *p }
+	sum := func(prefix string, values ...int) string {
+		if len(values) == 0 {
+			panic("no values")
+		}
+		return prefix
+	}
+
+	cases := []struct {
+		name     string
+		fn       any
+		args     []any
+		mustFail bool
+	}{
+		{name: "panics", fn: divide, args: []any{1, 0}, mustFail: false},
+		{name: "does not panic", fn: divide, args: []any{4, 2}, mustFail: true},
+		{name: "nil pointer argument", fn: deref, args: []any{nil}, mustFail: false},
+		{name: "variadic without values", fn: sum, args: []any{"total"}, mustFail: false},
+		{name: "variadic with values", fn: sum, args: []any{"total", 1, 2}, mustFail: true},
+		{name: "too few arguments", fn: divide, args: []any{1}, mustFail: true},
+		{name: "wrong argument type", fn: divide, args: []any{1, "0"}, mustFail: true},
+		{name: "nil for int", fn: divide, args: []any{1, nil}, mustFail: true},
+		{name: "not a function", fn: 42, args: nil, mustFail: true},
+		{name: "nil function", fn: (func())(nil), args: nil, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			PanicsCall(tb, tc.fn, tc.args...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestNonMatchingSlicesDifferentLengths(t *testing.T) {
+	a, b := nonMatchingSlices([]int{1, 2}, []int{2, 3, 4})
+	Equal(t, []int{1}, a)
+	Equal(t, []int{3, 4}, b)
+
+	a, b = nonMatchingSlices([]int{1, 2, 3}, []int{3})
+	Equal(t, []int{1, 2}, a)
+	Equal(t, []int{}, b)
+}
+
+func TestEqualLooseAndStrict(t *testing.T) {
+	type page struct {
+		Items  []string
+		Labels map[string]string
+	}
+
+	cases := []struct {
+		name     string
+		expected page
+		input    page
+		loose    bool
+		strict   bool
+	}{
+		{name: "nil and empty", expected: page{}, input: page{Items: []string{}, Labels: map[string]string{}}, loose: true, strict: false},
+		{name: "equal", expected: page{Items: []string{"a"}}, input: page{Items: []string{"a"}}, loose: true, strict: true},
+		{name: "nil and non-empty", expected: page{}, input: page{Items: []string{"a"}}, loose: false, strict: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, !tc.loose)
+			EqualLoose(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+
+			tb = NewTester(t, !tc.strict)
+			EqualStrict(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualLooseMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	EqualLoose(tb, []int(nil), []int{1})
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Values are not equal (nil and empty slices and maps are equal)\n > expected: []int(nil)\n < input:    []int{1}\n"}, tb.logs)
+}
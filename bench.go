@@ -0,0 +1,193 @@
+package assertions
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// BenchGuard is a testing.TB for making assertions inside the loop of a benchmark without skewing its
+// results. The timer is paused while failure messages are formatted and a failing assertion ends the
+// benchmark with b.Fatal, outside the timed section. Logged messages are held until a failure, when they
+// become its message, or until the benchmark function returns, when they are logged as usual:
+//
+//	func BenchmarkParse(b *testing.B) {
+//		g := GuardBenchmark(b)
+//		for range b.N {
+//			Equal(g, expected, Parse(input))
+//		}
+//	}
+//
+// The embedded *testing.B gives access to b.N and the timer. Guarded assertions should only be made while
+// the timer is running, as they restart it after pausing. A BenchGuard may also be used by the goroutines
+// of b.RunParallel, which are told apart from the benchmark goroutine. The timer is global to them so it
+// is left running, and each goroutine's messages are held separately. A failing assertion only ends the
+// goroutine that made it, as b.FailNow must be called from the benchmark goroutine, and RunParallel on
+// the BenchGuard ends the benchmark once every goroutine has returned
+type BenchGuard struct {
+	*testing.B
+	owner   int64
+	mu      sync.Mutex
+	pending map[int64][]string
+	stopped bool
+}
+
+var _ testing.TB = &BenchGuard{}
+
+// GuardBenchmark returns a BenchGuard for b
+func GuardBenchmark(b *testing.B) *BenchGuard {
+	g := &BenchGuard{B: b, owner: goroutineID(), pending: make(map[int64][]string)}
+	b.Cleanup(g.flush)
+	return g
+}
+
+// goroutineID returns the id of the calling goroutine from the header of its stack
+func goroutineID() int64 {
+	var buf [64]byte
+	header := strings.TrimPrefix(string(buf[:runtime.Stack(buf[:], false)]), "goroutine ")
+	header, _, _ = strings.Cut(header, " ")
+	id, _ := strconv.ParseInt(header, 10, 64)
+	return id
+}
+
+// pauseTimer stops the timer when called from the benchmark goroutine, returning a function that
+// restarts it. In the goroutines of b.RunParallel the timer is left alone
+func (g *BenchGuard) pauseTimer() func() {
+	if goroutineID() != g.owner {
+		return func() {}
+	}
+	g.B.StopTimer()
+	return g.B.StartTimer
+}
+
+// hold adds message to the pending messages of the calling goroutine with the timer paused
+func (g *BenchGuard) hold(format func() string) {
+	defer g.pauseTimer()()
+
+	id := goroutineID()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pending[id] = append(g.pending[id], format())
+}
+
+// failure returns the pending messages of the calling goroutine as one failure message, with any message
+// of the failure itself
+func (g *BenchGuard) failure(message string) string {
+	id := goroutineID()
+	g.mu.Lock()
+	pending := g.pending[id]
+	delete(g.pending, id)
+	g.mu.Unlock()
+
+	if message != "" {
+		pending = append(pending, message)
+	}
+	var b strings.Builder
+	for _, msg := range pending {
+		b.WriteString(strings.TrimSuffix(msg, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// fatal ends the benchmark with the pending messages and message, stopping the timer first.
+// Called from another goroutine it reports the failure and ends only that goroutine
+func (g *BenchGuard) fatal(message string) {
+	if goroutineID() != g.owner {
+		if message = g.failure(message); message != "" {
+			g.B.Error(message)
+		}
+		g.B.Fail()
+
+		g.mu.Lock()
+		g.stopped = true
+		g.mu.Unlock()
+		runtime.Goexit()
+	}
+
+	g.pauseTimer()
+	if message = g.failure(message); message == "" {
+		g.B.FailNow()
+	}
+	g.B.Fatal(message)
+}
+
+// fail marks the benchmark failed with the pending messages and message, keeping it running
+func (g *BenchGuard) fail(message string) {
+	defer g.pauseTimer()()
+
+	if message = g.failure(message); message != "" {
+		g.B.Error(message)
+	}
+	g.B.Fail()
+}
+
+// RunParallel is b.RunParallel, ending the benchmark with b.FailNow after the goroutines have returned
+// when a failing assertion ended one of them
+func (g *BenchGuard) RunParallel(body func(*testing.PB)) {
+	g.B.RunParallel(body)
+
+	g.mu.Lock()
+	stopped := g.stopped
+	g.mu.Unlock()
+	if stopped {
+		g.B.FailNow()
+	}
+}
+
+func (g *BenchGuard) flush() {
+	g.mu.Lock()
+	pending := g.pending
+	g.pending = make(map[int64][]string)
+	g.mu.Unlock()
+
+	for _, id := range slices.Sorted(maps.Keys(pending)) {
+		for _, msg := range pending[id] {
+			g.B.Log(msg)
+		}
+	}
+}
+
+// Error implements testing.TB.
+func (g *BenchGuard) Error(args ...any) {
+	g.fail(fmt.Sprint(args...))
+}
+
+// Errorf implements testing.TB.
+func (g *BenchGuard) Errorf(format string, args ...any) {
+	g.fail(fmt.Sprintf(format, args...))
+}
+
+// Fail implements testing.TB.
+func (g *BenchGuard) Fail() {
+	g.fail("")
+}
+
+// FailNow implements testing.TB.
+func (g *BenchGuard) FailNow() {
+	g.fatal("")
+}
+
+// Fatal implements testing.TB.
+func (g *BenchGuard) Fatal(args ...any) {
+	g.fatal(fmt.Sprint(args...))
+}
+
+// Fatalf implements testing.TB.
+func (g *BenchGuard) Fatalf(format string, args ...any) {
+	g.fatal(fmt.Sprintf(format, args...))
+}
+
+// Log implements testing.TB.
+func (g *BenchGuard) Log(args ...any) {
+	g.hold(func() string { return fmt.Sprint(args...) })
+}
+
+// Logf implements testing.TB.
+func (g *BenchGuard) Logf(format string, args ...any) {
+	g.hold(func() string { return fmt.Sprintf(format, args...) })
+}
@@ -0,0 +1,92 @@
+package assertions
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestBenchGuard(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    int
+		mustFail bool
+	}{
+		{name: "passing", input: 1, mustFail: false},
+		{name: "failing", input: 2, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			result := testing.Benchmark(func(b *testing.B) {
+				g := GuardBenchmark(b)
+				for range b.N {
+					calls++
+					Equal(g, 1, tc.input)
+				}
+			})
+
+			// testing.Benchmark reports a failed benchmark as a zero result
+			Equal(t, tc.mustFail, result.N == 0)
+			if tc.mustFail {
+				Equal(t, 1, calls)
+			}
+		})
+	}
+}
+
+func TestBenchGuardFailureMessage(t *testing.T) {
+	var message string
+	testing.Benchmark(func(b *testing.B) {
+		g := GuardBenchmark(b)
+		g.Logf("checking %v", "input")
+		g.Logf("Values are not equal\n > expected: %v\n < input:    %v\n", 1, 2)
+		message = g.failure("")
+	})
+
+	Equal(t, "checking input\nValues are not equal\n > expected: 1\n < input:    2\n", message)
+}
+
+func TestBenchGuardRunParallel(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    int
+		mustFail bool
+	}{
+		{name: "passing", input: 1, mustFail: false},
+		{name: "failing", input: 2, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var checked atomic.Int64
+			reached := false
+			result := testing.Benchmark(func(b *testing.B) {
+				g := GuardBenchmark(b)
+				g.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						Equal(g, 1, tc.input)
+						checked.Add(1)
+					}
+				})
+				reached = true
+			})
+
+			Equal(t, tc.mustFail, result.N == 0)
+			Equal(t, !tc.mustFail, reached)
+			if tc.mustFail {
+				Equal(t, int64(0), checked.Load())
+			}
+		})
+	}
+}
+
+func TestGoroutineID(t *testing.T) {
+	id := goroutineID()
+	Equal(t, true, id > 0)
+	Equal(t, id, goroutineID())
+
+	other := make(chan int64)
+	go func() { other <- goroutineID() }()
+	Equal(t, false, id == <-other)
+}
@@ -0,0 +1,27 @@
+package assertions
+
+import "testing"
+
+// Case is a case of a table test run by AssertCases
+type Case[I, O any] struct {
+	Name    string
+	Input   I
+	Want    O
+	WantErr error
+}
+
+// AssertCases runs each case as a subtest of t named after the case, calling fn with its input and
+// asserting the result as by EqualErr
+//
+//	AssertCases(t, []Case[string, int]{
+//		{Name: "number", Input: "42", Want: 42},
+//		{Name: "empty", Input: "", WantErr: errEmpty},
+//	}, parse)
+func AssertCases[I, O any](t *testing.T, cases []Case[I, O], fn func(I) (O, error), opts ...CompareOption) {
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := fn(tc.Input)
+			EqualErr(t, tc.Want, tc.WantErr, got, err, opts...)
+		})
+	}
+}
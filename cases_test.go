@@ -0,0 +1,37 @@
+package assertions
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestAssertCases(t *testing.T) {
+	parse := func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, errors.New("not a number")
+		}
+		return n, nil
+	}
+
+	AssertCases(t, []Case[string, int]{
+		{Name: "number", Input: "42", Want: 42},
+		{Name: "negative", Input: "-7", Want: -7},
+		{Name: "invalid", Input: "x", WantErr: errors.New("not a number")},
+	}, parse)
+}
+
+func TestAssertCasesRunsSubtests(t *testing.T) {
+	var ran []string
+	fn := func(s string) (string, error) {
+		ran = append(ran, s)
+		return s, nil
+	}
+
+	AssertCases(t, []Case[string, string]{
+		{Name: "first", Input: "a", Want: "a"},
+		{Name: "second", Input: "b", Want: "b"},
+	}, fn)
+	Equal(t, []string{"a", "b"}, ran)
+}
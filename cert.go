@@ -0,0 +1,72 @@
+package assertions
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// formatCert prints what identifies a certificate and when it is valid
+func formatCert(cert *x509.Certificate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "   subject:  %v\n   issuer:   %v\n", cert.Subject, cert.Issuer)
+	var sans []string
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	if len(sans) > 0 {
+		fmt.Fprintf(&b, "   SANs:     %v\n", strings.Join(sans, ", "))
+	}
+	fmt.Fprintf(&b, "   validity: %v to %v\n", cert.NotBefore.UTC().Format(time.RFC3339), cert.NotAfter.UTC().Format(time.RFC3339))
+	return b.String()
+}
+
+// CertValidFor asserts that cert is valid for the host name dnsName, which may also be an IP address,
+// matching its subject alternative names including wildcards. Failing results print the certificate
+func CertValidFor(tb testing.TB, cert *x509.Certificate, dnsName string) {
+	const failureFormat = "Certificate is not valid for %v\n < error: %v\n ~ certificate:\n%v"
+
+	summary.recordAssertion()
+
+	if err := cert.VerifyHostname(dnsName); err != nil {
+		errorfNow(tb, failureFormat, dnsName, err, formatCert(cert))
+		return
+	}
+}
+
+// CertExpiresAfter asserts that cert is still valid at t, such as time.Now().AddDate(0, 0, 30) to fail
+// a month before a certificate expires. Failing results print the certificate
+func CertExpiresAfter(tb testing.TB, cert *x509.Certificate, t time.Time) {
+	const failureFormat = "Certificate expires too soon\n > after:   %v\n < expires: %v\n ~ certificate:\n%v"
+
+	summary.recordAssertion()
+
+	if !cert.NotAfter.After(t) {
+		errorfNow(tb, failureFormat, t.UTC().Format(time.RFC3339), cert.NotAfter.UTC().Format(time.RFC3339), formatCert(cert))
+		return
+	}
+}
+
+// CertSignedBy asserts that cert chains to one of the roots in caPool, using any intermediates in
+// intermediates, at the current time and for any key usage. Failing results print the certificate
+func CertSignedBy(tb testing.TB, cert *x509.Certificate, caPool *x509.CertPool, intermediates ...*x509.Certificate) {
+	const failureFormat = "Certificate is not signed by the pool\n < error: %v\n ~ certificate:\n%v"
+
+	summary.recordAssertion()
+
+	opts := x509.VerifyOptions{Roots: caPool, Intermediates: x509.NewCertPool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}
+	for _, c := range intermediates {
+		opts.Intermediates.AddCert(c)
+	}
+	if _, err := cert.Verify(opts); err != nil {
+		errorfNow(tb, failureFormat, err, formatCert(cert))
+		return
+	}
+}
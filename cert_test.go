@@ -0,0 +1,134 @@
+package assertions
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+var certEpoch = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// issueCert creates a certificate from template signed by parent, or self-signed if parent is nil
+func issueCert(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	NoError(t, err)
+	return cert, key
+}
+
+func testCerts(t *testing.T) (leaf, ca, other *x509.Certificate) {
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              certEpoch,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	ca, caKey := issueCert(t, caTemplate, nil, nil)
+	other, _ = issueCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "Other CA"}, NotBefore: caTemplate.NotBefore, NotAfter: certEpoch,
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	leaf, _ = issueCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "api.example.com"},
+		DNSNames:     []string{"api.example.com", "*.internal.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    caTemplate.NotBefore,
+		NotAfter:     certEpoch.AddDate(0, -6, 0),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	return leaf, ca, other
+}
+
+func TestCertValidFor(t *testing.T) {
+	leaf, _, _ := testCerts(t)
+
+	cases := []struct {
+		name     string
+		host     string
+		mustFail bool
+	}{
+		{name: "name", host: "api.example.com", mustFail: false},
+		{name: "wildcard", host: "db.internal.example.com", mustFail: false},
+		{name: "ip", host: "10.0.0.1", mustFail: false},
+		{name: "other name", host: "www.example.com", mustFail: true},
+		{name: "wildcard depth", host: "a.db.internal.example.com", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			CertValidFor(tb, leaf, tc.host)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestCertExpiresAfter(t *testing.T) {
+	leaf, _, _ := testCerts(t)
+
+	tb := NewTester(t, false)
+	CertExpiresAfter(tb, leaf, certEpoch.AddDate(0, -7, 0))
+	tb.AssertExpectation()
+
+	tb = NewTester(t, true)
+	CertExpiresAfter(tb, leaf, certEpoch)
+	tb.AssertExpectation()
+}
+
+func TestCertExpiresAfterMessage(t *testing.T) {
+	leaf, _, _ := testCerts(t)
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	CertExpiresAfter(tb, leaf, certEpoch)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Certificate expires too soon\n > after:   2030-01-01T00:00:00Z\n < expires: 2029-07-01T00:00:00Z\n ~ certificate:\n" +
+		"   subject:  CN=api.example.com\n   issuer:   CN=Test CA\n   SANs:     api.example.com, *.internal.example.com, 10.0.0.1\n" +
+		"   validity: " + leaf.NotBefore.UTC().Format(time.RFC3339) + " to 2029-07-01T00:00:00Z\n"}, tb.logs)
+}
+
+func TestCertSignedBy(t *testing.T) {
+	leaf, ca, other := testCerts(t)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	otherPool := x509.NewCertPool()
+	otherPool.AddCert(other)
+
+	cases := []struct {
+		name     string
+		pool     *x509.CertPool
+		mustFail bool
+	}{
+		{name: "signed", pool: pool, mustFail: false},
+		{name: "other ca", pool: otherPool, mustFail: true},
+		{name: "empty pool", pool: x509.NewCertPool(), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			CertSignedBy(tb, leaf, tc.pool)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by waiting assertions such as Eventually.
+// Code under test can accept a Clock so that a FakeClock controls its timers deterministically
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RealClock returns a Clock backed by the time package
+func RealClock() Clock {
+	return realClock{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// FakeClock is a Clock whose time only moves when Advance is called.
+// It is safe for concurrent use
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+var _ Clock = &FakeClock{}
+
+// NewFakeClock returns a FakeClock set to start
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now implements Clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After implements Clock. The returned channel receives the fake time once the clock
+// has been advanced by at least d
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.timers = append(c.timers, &fakeTimer{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves the clock forward by d, firing every timer that expires on the way
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// BlockUntilTimers blocks until at least n timers are waiting for the clock to advance.
+// This lets a test advance the clock only once the code under test is waiting on it
+func (c *FakeClock) BlockUntilTimers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
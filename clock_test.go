@@ -0,0 +1,44 @@
+package assertions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+	immediate := clock.After(0)
+
+	Equal(t, start, <-immediate)
+	clock.BlockUntilTimers(2)
+
+	clock.Advance(30 * time.Second)
+	Equal(t, start.Add(30*time.Second), clock.Now())
+	Equal(t, start.Add(30*time.Second), <-short)
+
+	select {
+	case <-long:
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	Equal(t, start.Add(time.Minute), <-long)
+}
+
+func TestFakeClockBlockUntilTimers(t *testing.T) {
+	clock := NewFakeClock(time.Time{})
+
+	fired := make(chan time.Time)
+	go func() {
+		fired <- <-clock.After(time.Hour)
+	}()
+
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Hour)
+	Equal(t, time.Time{}.Add(time.Hour), <-fired)
+}
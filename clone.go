@@ -0,0 +1,171 @@
+package assertions
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// copyKey identifies a reference that has already been copied, so shared and cyclic references
+// in the original are shared and cyclic in the copy
+type copyKey struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+	len int
+}
+
+// cloneLeaves are the types copied by value rather than deeply. A *time.Location refers to a package level
+// singleton such as time.Local, copying it would make equal times differ by == and Location
+var cloneLeaves = map[reflect.Type]bool{
+	reflect.TypeFor[time.Time]():      true,
+	reflect.TypeFor[*time.Location](): true,
+}
+
+// copier deep-copies values, including the contents of unexported fields.
+// Functions, channels and unsafe pointers are not copied, the copy refers to the same ones as the original,
+// nor are the types in cloneLeaves
+type copier struct {
+	copied map[copyKey]reflect.Value
+}
+
+// usable returns v in a form that may be read with Interface and passed to Set
+func usable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		if !v.CanInterface() {
+			return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+		}
+		return v
+	}
+
+	// Values held by maps and interfaces are not addressable, copy them into one that is
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+	return out
+}
+
+// settable returns v in a form that may be passed to Set, v must be addressable
+func settable(v reflect.Value) reflect.Value {
+	if !v.CanSet() {
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+	}
+	return v
+}
+
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	v = usable(v)
+	if cloneLeaves[v.Type()] {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{ptr: v.UnsafePointer(), typ: v.Type()}
+		if out, ok := c.copied[key]; ok {
+			return out
+		}
+		out := reflect.New(v.Type().Elem())
+		c.copied[key] = out
+		out.Elem().Set(c.copy(v.Elem()))
+		return out
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{ptr: v.UnsafePointer(), typ: v.Type(), len: v.Len()}
+		if out, ok := c.copied[key]; ok {
+			return out
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		c.copied[key] = out
+		for i := range v.Len() {
+			out.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := copyKey{ptr: v.UnsafePointer(), typ: v.Type()}
+		if out, ok := c.copied[key]; ok {
+			return out
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copied[key] = out
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return out
+
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.NumField() {
+			settable(out.Field(i)).Set(c.copy(v.Field(i)))
+		}
+		return out
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(c.copy(v.Elem()))
+		return out
+	}
+
+	return v
+}
+
+// Clone returns a deep copy of v, sharing no pointers, slices or maps with it, including through
+// unexported fields. Shared and cyclic references within v are preserved in the copy.
+// Functions and channels are not copied, the copy refers to the same ones as v, and times are copied
+// by value so they keep their locations.
+// This allows cases of a table test to start from a common fixture without affecting each other
+func Clone[T any](v T) T {
+	// Copying from an addressable root makes every value reached from it addressable,
+	// which is what allows reading and writing unexported fields
+	var out T
+	c := copier{copied: make(map[copyKey]reflect.Value)}
+	reflect.ValueOf(&out).Elem().Set(c.copy(reflect.ValueOf(&v).Elem()))
+	return out
+}
+
+// Unchanged takes a deep copy of v and returns a function asserting that v is still equal to the copy,
+// to verify that code under test does not mutate its inputs. v should be a pointer, slice or map so that
+// changes are visible through it. The check runs when the returned function is called, or at cleanup
+// if it has not been called by then. Values are compared as by Equal with IgnoreFuncs, since the copy
+// shares the functions of v
+func Unchanged(tb testing.TB, v any) func() {
+	const failureFormat = "Value was modified\n%v"
+
+	summary.recordAssertion()
+
+	snapshot := Clone(v)
+
+	var once sync.Once
+	check := func() {
+		once.Do(func() {
+			if diffs := diffValues("", snapshot, v, IgnoreFuncs()); len(diffs) > 0 {
+				errorfNow(tb, failureFormat, formatDifferences(diffs))
+				return
+			}
+		})
+	}
+
+	tb.Cleanup(check)
+	return check
+}
@@ -0,0 +1,128 @@
+package assertions
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+type cloneFixture struct {
+	Name   string
+	Tags   []string
+	Counts map[string]int
+	Next   *cloneFixture
+	hidden []int
+	any    any
+}
+
+func TestClone(t *testing.T) {
+	original := &cloneFixture{
+		Name:   "a",
+		Tags:   []string{"x", "y"},
+		Counts: map[string]int{"x": 1},
+		hidden: []int{1, 2},
+		any:    []byte("bytes"),
+	}
+	original.Next = original
+
+	copied := Clone(original)
+	Equal(t, original, copied)
+
+	if copied == original || copied.Next != copied {
+		t.Fatalf("cycle was not copied: %p %p %p", original, copied, copied.Next)
+	}
+
+	copied.Tags[0] = "changed"
+	copied.Counts["x"] = 2
+	copied.hidden[0] = 3
+	copied.any.([]byte)[0] = 'B'
+
+	Equal(t, []string{"x", "y"}, original.Tags)
+	Equal(t, map[string]int{"x": 1}, original.Counts)
+	Equal(t, []int{1, 2}, original.hidden)
+	Equal(t, any([]byte("bytes")), original.any)
+}
+
+func TestCloneShared(t *testing.T) {
+	shared := []int{1, 2}
+	copied := Clone([2][]int{shared, shared})
+
+	copied[0][0] = 3
+	Equal(t, 3, copied[1][0])
+	Equal(t, 1, shared[0])
+}
+
+func TestCloneTime(t *testing.T) {
+	now := time.Now()
+	Equal(t, true, Clone(now) == now)
+	Equal(t, true, Clone(now).Location() == time.Local)
+
+	type event struct {
+		At   time.Time
+		Zone *time.Location
+	}
+	original := event{At: now.UTC(), Zone: time.UTC}
+	copied := Clone(original)
+	Equal(t, true, copied == original)
+	Equal(t, true, copied.Zone == time.UTC)
+}
+
+func TestCloneInterface(t *testing.T) {
+	Equal(t, nil, Clone[any](nil))
+
+	original := any(map[string][]int{"a": {1}})
+	copied := Clone(original)
+	copied.(map[string][]int)["a"][0] = 2
+	Equal(t, any(map[string][]int{"a": {1}}), original)
+}
+
+func TestUnchanged(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    any
+		fn       func(v any)
+		mustFail bool
+	}{
+		{
+			name:     "untouched slice",
+			input:    []int{3, 1, 2},
+			fn:       func(v any) { slices.Sort(slices.Clone(v.([]int))) },
+			mustFail: false,
+		},
+		{
+			name:     "sorted in place",
+			input:    []int{3, 1, 2},
+			fn:       func(v any) { slices.Sort(v.([]int)) },
+			mustFail: true,
+		},
+		{
+			name:     "map written",
+			input:    map[string]int{"a": 1},
+			fn:       func(v any) { v.(map[string]int)["b"] = 2 },
+			mustFail: true,
+		},
+		{
+			name:     "unexported field written",
+			input:    &cloneFixture{hidden: []int{1}},
+			fn:       func(v any) { v.(*cloneFixture).hidden[0] = 2 },
+			mustFail: true,
+		},
+		{
+			name:     "holding a function",
+			input:    &struct{ OnChange func() }{OnChange: func() {}},
+			fn:       func(v any) {},
+			mustFail: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			check := Unchanged(tb, tc.input)
+			tc.fn(tc.input)
+			check()
+			tb.AssertExpectation()
+		})
+	}
+}
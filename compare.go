@@ -0,0 +1,51 @@
+package assertions
+
+import (
+	"fmt"
+)
+
+// describeDifferences formats the differences found by d as Equal prints them, a difference between
+// the values themselves as the expected and input values and any others with their paths
+func describeDifferences(d *differ) string {
+	const valueFormat = " > expected: %v\n < input:    %v\n"
+
+	if len(d.diffs) == 1 && d.diffs[0].path == "" && d.diffs[0].note == "" {
+		return fmt.Sprintf(valueFormat, d.diffs[0].expected, d.diffs[0].input)
+	}
+	return formatDifferences(d.diffs) + d.stoppedNote()
+}
+
+// Diff compares expected and actual as Equal does, returning the differences as Equal would print them
+// and whether the values are equal, in which case the differences are empty. It needs no testing.TB,
+// so custom matchers, command line tools and snapshot updaters can reuse the comparison:
+//
+//	if diff, ok := assertions.Diff(want, got); !ok {
+//		fmt.Fprintf(os.Stderr, "snapshot %v is out of date\n%v", name, diff)
+//	}
+func Diff(expected, actual any, opts ...CompareOption) (string, bool) {
+	d := compareValues("", expected, actual, opts...)
+	if len(d.diffs) == 0 {
+		return "", true
+	}
+	return describeDifferences(d), false
+}
+
+// SlicesDiff compares expected and actual regardless of order as SlicesMatch does, returning how many times
+// each differing element occurs in them and whether they have the same members
+func SlicesDiff[E any, T ~[]E](expected, actual T) (string, bool) {
+	expectedNoMatch, actualNoMatch := nonMatchingSlices(expected, actual)
+	if len(expectedNoMatch) == 0 && len(actualNoMatch) == 0 {
+		return "", true
+	}
+	return formatMultiplicities(multiplicities(expected, actual, append(expectedNoMatch, actualNoMatch...))), false
+}
+
+// MapsDiff compares expected and actual as MapsMatch does, returning the keys only in one of them and the
+// differences of the values of shared keys, and whether the maps are equal
+func MapsDiff[K comparable, E any, T ~map[K]E](expected, actual T) (string, bool) {
+	d := diffMaps(expected, actual)
+	if d.empty() {
+		return "", true
+	}
+	return formatMapDiff(d, expected, actual), false
+}
@@ -0,0 +1,64 @@
+package assertions
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	cases := []struct {
+		name     string
+		expected any
+		actual   any
+		opts     []CompareOption
+		diff     string
+		equal    bool
+	}{
+		{name: "equal", expected: point{1, 2}, actual: point{1, 2}, diff: "", equal: true},
+		{name: "values", expected: 1, actual: 2, diff: " > expected: 1\n < input:    2\n", equal: false},
+		{name: "fields", expected: point{1, 2}, actual: point{1, 3}, diff: " ~ .Y:\n   > expected: 2\n   < input:    3\n", equal: false},
+		{name: "stopped", expected: []int{1, 2, 3}, actual: []int{4, 5, 6}, opts: []CompareOption{StopAfter(1)},
+			diff: " ~ [0]:\n   > expected: 1\n   < input:    4\n ~ stopped after 1 differences, about 3 in total\n", equal: false},
+		{name: "nil and empty", expected: []int(nil), actual: []int{}, opts: []CompareOption{NilEqualsEmpty()}, diff: "", equal: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, equal := Diff(tc.expected, tc.actual, tc.opts...)
+			Equal(t, tc.diff, diff)
+			Equal(t, tc.equal, equal)
+		})
+	}
+}
+
+func TestDiffMatchesEqual(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	Equal(tb, map[string]int{"a": 1}, map[string]int{"a": 2})
+	tb.AssertExpectation()
+
+	diff, _ := Diff(map[string]int{"a": 1}, map[string]int{"a": 2})
+	Equal(t, []string{"Values are not equal\n" + diff}, tb.logs)
+}
+
+func TestSlicesDiff(t *testing.T) {
+	diff, equal := SlicesDiff([]string{"a", "b", "b"}, []string{"b", "a", "b"})
+	Equal(t, "", diff)
+	Equal(t, true, equal)
+
+	diff, equal = SlicesDiff([]string{"a", "b", "b"}, []string{"b", "a", "c"})
+	Equal(t, " ~ \"b\": expected 2×, got 1×\n ~ \"c\": expected 0×, got 1×\n", diff)
+	Equal(t, false, equal)
+}
+
+func TestMapsDiff(t *testing.T) {
+	diff, equal := MapsDiff(map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "b": 2})
+	Equal(t, "", diff)
+	Equal(t, true, equal)
+
+	diff, equal = MapsDiff(map[string]int{"a": 1, "b": 2}, map[string]int{"b": 3, "c": 4})
+	Equal(t, " keys only in expected:\n  > \"a\": 1\n keys only in input:\n  < \"c\": 4\n keys with differing values:\n ~ [\"b\"]:\n   > expected: 2\n   < input:    3\n", diff)
+	Equal(t, false, equal)
+}
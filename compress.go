@@ -0,0 +1,56 @@
+package assertions
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"testing"
+)
+
+// Decompressor decodes a compression format for DecompressedEqual.
+// Formats with dependencies outside the standard library are provided by submodules, e.g. zstd
+type Decompressor struct {
+	Name      string
+	NewReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+// Gzip decodes data compressed with gzip
+var Gzip = Decompressor{
+	Name: "gzip",
+	NewReader: func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+}
+
+// Zlib decodes data compressed with zlib
+var Zlib = Decompressor{
+	Name:      "zlib",
+	NewReader: zlib.NewReader,
+}
+
+// DecompressedEqual asserts that compressed decodes with format to exactly expected.
+// Failing results describe the first difference rather than printing the full contents
+func DecompressedEqual(tb testing.TB, expected []byte, compressed io.Reader, format Decompressor) {
+	const invalidFormat = "Data could not be decompressed\n > format: %v\n > error:  %v\n"
+	const failureFormat = "Decompressed data does not match\n > format: %v\n > %v\n"
+
+	summary.recordAssertion()
+
+	r, err := format.NewReader(compressed)
+	if err != nil {
+		errorfNow(tb, invalidFormat, format.Name, err)
+		return
+	}
+	defer r.Close()
+
+	input, err := io.ReadAll(r)
+	if err != nil {
+		errorfNow(tb, invalidFormat, format.Name, err)
+		return
+	}
+
+	if msg := describeBytesDifference(expected, input); msg != "" {
+		errorfNow(tb, failureFormat, format.Name, msg)
+		return
+	}
+}
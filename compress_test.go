@@ -0,0 +1,44 @@
+package assertions
+
+import (
+	"bytes"
+	"compress/zlib"
+	"testing"
+)
+
+func zlibBytes(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, err := zw.Write(data)
+	NoError(t, err)
+	NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestDecompressedEqual(t *testing.T) {
+	payload := []byte("hello\ncompressed\nworld\n")
+
+	cases := []struct {
+		name       string
+		expected   []byte
+		compressed []byte
+		format     Decompressor
+		mustFail   bool
+	}{
+		{name: "gzip", expected: payload, compressed: gzipBytes(t, payload), format: Gzip, mustFail: false},
+		{name: "zlib", expected: payload, compressed: zlibBytes(t, payload), format: Zlib, mustFail: false},
+		{name: "gzip differs", expected: []byte("hello\n"), compressed: gzipBytes(t, payload), format: Gzip, mustFail: true},
+		{name: "wrong format", expected: payload, compressed: zlibBytes(t, payload), format: Gzip, mustFail: true},
+		{name: "not compressed", expected: payload, compressed: payload, format: Zlib, mustFail: true},
+		{name: "truncated", expected: payload, compressed: gzipBytes(t, payload)[:12], format: Gzip, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			DecompressedEqual(tb, tc.expected, bytes.NewReader(tc.compressed), tc.format)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package assertions
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ContextHasValue asserts that ctx carries a value of type T for key that is equal to expected,
+// so middleware tests may check what was attached to a request context directly
+func ContextHasValue[T any](tb testing.TB, ctx context.Context, key any, expected T) {
+	const missingFormat = "context has no value for key\n > key: %v\n"
+	const typeFormat = "context value has the wrong type\n > key:           %v\n > expected type: %v\n < input type:    %T\n"
+	const failureFormat = "context value is not equal\n > key: %v\n%v"
+
+	summary.recordAssertion()
+
+	value := ctx.Value(key)
+	if value == nil {
+		errorfNow(tb, missingFormat, formatValue(key))
+		return
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		errorfNow(tb, typeFormat, formatValue(key), reflect.TypeFor[T](), value)
+		return
+	}
+
+	if diffs := diffValues("", expected, typed); len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatValue(key), formatDifferences(diffs))
+		return
+	}
+}
+
+// ContextDeadlineWithin asserts that ctx has a deadline no more than tolerance before or after expected,
+// such as time.Now().Add(timeout) for a handler that applies a timeout
+func ContextDeadlineWithin(tb testing.TB, ctx context.Context, expected time.Time, tolerance time.Duration) {
+	const missingFormat = "context has no deadline\n > expected: %v\n"
+	const failureFormat = "context deadline is off by %v\n > expected: %v ± %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		errorfNow(tb, missingFormat, expected)
+		return
+	}
+
+	if off := deadline.Sub(expected).Abs(); off > tolerance {
+		errorfNow(tb, failureFormat, off, expected, tolerance, deadline)
+		return
+	}
+}
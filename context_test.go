@@ -0,0 +1,89 @@
+package assertions
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type contextKey string
+
+func TestContextHasValue(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("user"), "alice")
+	ctx = context.WithValue(ctx, contextKey("roles"), []string{"admin"})
+
+	cases := []struct {
+		name     string
+		check    func(tb testing.TB)
+		mustFail bool
+	}{
+		{
+			name:     "equal value",
+			check:    func(tb testing.TB) { ContextHasValue(tb, ctx, contextKey("user"), "alice") },
+			mustFail: false,
+		},
+		{
+			name:     "equal slice",
+			check:    func(tb testing.TB) { ContextHasValue(tb, ctx, contextKey("roles"), []string{"admin"}) },
+			mustFail: false,
+		},
+		{
+			name:     "different value",
+			check:    func(tb testing.TB) { ContextHasValue(tb, ctx, contextKey("user"), "bob") },
+			mustFail: true,
+		},
+		{
+			name:     "wrong type",
+			check:    func(tb testing.TB) { ContextHasValue(tb, ctx, contextKey("user"), 1) },
+			mustFail: true,
+		},
+		{
+			name:     "missing key",
+			check:    func(tb testing.TB) { ContextHasValue(tb, ctx, contextKey("org"), "acme") },
+			mustFail: true,
+		},
+		{
+			name:     "key of another type",
+			check:    func(tb testing.TB) { ContextHasValue(tb, ctx, "user", "alice") },
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.check(tb)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestContextDeadlineWithin(t *testing.T) {
+	deadline := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	cases := []struct {
+		name      string
+		ctx       context.Context
+		expected  time.Time
+		tolerance time.Duration
+		mustFail  bool
+	}{
+		{name: "exact", ctx: ctx, expected: deadline, tolerance: 0, mustFail: false},
+		{name: "within tolerance", ctx: ctx, expected: deadline.Add(-time.Second), tolerance: time.Second, mustFail: false},
+		{name: "too early", ctx: ctx, expected: deadline.Add(2 * time.Second), tolerance: time.Second, mustFail: true},
+		{name: "too late", ctx: ctx, expected: deadline.Add(-2 * time.Second), tolerance: time.Second, mustFail: true},
+		{name: "no deadline", ctx: context.Background(), expected: deadline, tolerance: time.Hour, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ContextDeadlineWithin(tb, tc.ctx, tc.expected, tc.tolerance)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package assertions
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// CookieSource is where cookie assertions find cookies: the Set-Cookie headers of an *http.Response,
+// or the Cookie header of an *http.Request, which carries names and values only
+type CookieSource interface {
+	Cookies() []*http.Cookie
+}
+
+// findCookie returns the last cookie named name in src, as a browser keeps the last one set,
+// and the names of all the cookies
+func findCookie(src CookieSource, name string) (*http.Cookie, []string) {
+	var found *http.Cookie
+	var names []string
+	for _, c := range src.Cookies() {
+		names = append(names, c.Name)
+		if c.Name == name {
+			found = c
+		}
+	}
+	return found, names
+}
+
+// formatCookie prints the attributes of c that matter to a browser
+func formatCookie(c *http.Cookie) string {
+	attrs := []string{fmt.Sprintf("%v=%q", c.Name, c.Value)}
+	if c.Path != "" {
+		attrs = append(attrs, "Path="+c.Path)
+	}
+	if c.Domain != "" {
+		attrs = append(attrs, "Domain="+c.Domain)
+	}
+	if !c.Expires.IsZero() {
+		attrs = append(attrs, "Expires="+c.Expires.UTC().Format(http.TimeFormat))
+	}
+	if c.MaxAge != 0 {
+		attrs = append(attrs, fmt.Sprintf("Max-Age=%v", c.MaxAge))
+	}
+	if c.Secure {
+		attrs = append(attrs, "Secure")
+	}
+	if c.HttpOnly {
+		attrs = append(attrs, "HttpOnly")
+	}
+	switch c.SameSite {
+	case http.SameSiteLaxMode:
+		attrs = append(attrs, "SameSite=Lax")
+	case http.SameSiteStrictMode:
+		attrs = append(attrs, "SameSite=Strict")
+	case http.SameSiteNoneMode:
+		attrs = append(attrs, "SameSite=None")
+	}
+	return strings.Join(attrs, "; ")
+}
+
+// CookieEqual asserts that src has a cookie named name with the value expected
+func CookieEqual(tb testing.TB, src CookieSource, name, expected string) {
+	const missingFormat = "Cookie is not set\n > name:    %v\n < cookies: %v\n"
+	const failureFormat = "Cookie value is not equal\n > expected: %q\n < input:    %q\n ~ cookie:   %v\n"
+
+	summary.recordAssertion()
+
+	c, names := findCookie(src, name)
+	if c == nil {
+		errorfNow(tb, missingFormat, name, names)
+		return
+	}
+	if c.Value != expected {
+		errorfNow(tb, failureFormat, expected, c.Value, formatCookie(c))
+		return
+	}
+}
+
+// CookieSecureHTTPOnly asserts that the response src sets a cookie named name with both the Secure
+// and HttpOnly attributes, as session cookies should be
+func CookieSecureHTTPOnly(tb testing.TB, src CookieSource, name string) {
+	const missingFormat = "Cookie is not set\n > name:    %v\n < cookies: %v\n"
+	const failureFormat = "Cookie is not Secure and HttpOnly\n < cookie: %v\n"
+
+	summary.recordAssertion()
+
+	c, names := findCookie(src, name)
+	if c == nil {
+		errorfNow(tb, missingFormat, name, names)
+		return
+	}
+	if !c.Secure || !c.HttpOnly {
+		errorfNow(tb, failureFormat, formatCookie(c))
+		return
+	}
+}
+
+// CookieExpiresWithin asserts that the response src sets a cookie named name expiring no more than tolerance
+// before or after expected. Max-Age takes precedence over Expires and counts from now, as it does for browsers
+func CookieExpiresWithin(tb testing.TB, src CookieSource, name string, expected time.Time, tolerance time.Duration) {
+	const missingFormat = "Cookie is not set\n > name:    %v\n < cookies: %v\n"
+	const sessionFormat = "Cookie has no expiry, it lasts for the browser session\n > expected: %v\n < cookie:   %v\n"
+	const failureFormat = "Cookie expiry is off by %v\n > expected: %v ± %v\n < input:    %v\n ~ cookie:   %v\n"
+
+	summary.recordAssertion()
+
+	c, names := findCookie(src, name)
+	if c == nil {
+		errorfNow(tb, missingFormat, name, names)
+		return
+	}
+
+	var expires time.Time
+	switch {
+	case c.MaxAge < 0:
+		expires = time.Now()
+	case c.MaxAge > 0:
+		expires = time.Now().Add(time.Duration(c.MaxAge) * time.Second)
+	case !c.Expires.IsZero():
+		expires = c.Expires
+	default:
+		errorfNow(tb, sessionFormat, expected, formatCookie(c))
+		return
+	}
+
+	if off := expires.Sub(expected).Abs(); off > tolerance {
+		errorfNow(tb, failureFormat, off, expected, tolerance, expires, formatCookie(c))
+		return
+	}
+}
@@ -0,0 +1,100 @@
+package assertions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func cookieResponse(cookies ...*http.Cookie) *http.Response {
+	rec := httptest.NewRecorder()
+	for _, c := range cookies {
+		http.SetCookie(rec, c)
+	}
+	return rec.Result()
+}
+
+func TestCookieEqual(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+
+	cases := []struct {
+		name     string
+		src      CookieSource
+		cookie   string
+		expected string
+		mustFail bool
+	}{
+		{name: "response", src: cookieResponse(&http.Cookie{Name: "session", Value: "abc"}), cookie: "session", expected: "abc", mustFail: false},
+		{name: "last one set", src: cookieResponse(&http.Cookie{Name: "session", Value: "old"}, &http.Cookie{Name: "session", Value: "new"}), cookie: "session", expected: "new", mustFail: false},
+		{name: "request", src: req, cookie: "theme", expected: "dark", mustFail: false},
+		{name: "different value", src: cookieResponse(&http.Cookie{Name: "session", Value: "abc"}), cookie: "session", expected: "xyz", mustFail: true},
+		{name: "missing", src: cookieResponse(&http.Cookie{Name: "theme", Value: "dark"}), cookie: "session", expected: "abc", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			CookieEqual(tb, tc.src, tc.cookie, tc.expected)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestCookieSecureHTTPOnly(t *testing.T) {
+	cases := []struct {
+		name     string
+		cookie   *http.Cookie
+		mustFail bool
+	}{
+		{name: "secure and http only", cookie: &http.Cookie{Name: "session", Value: "abc", Secure: true, HttpOnly: true}, mustFail: false},
+		{name: "not secure", cookie: &http.Cookie{Name: "session", Value: "abc", HttpOnly: true}, mustFail: true},
+		{name: "readable by scripts", cookie: &http.Cookie{Name: "session", Value: "abc", Secure: true}, mustFail: true},
+		{name: "missing", cookie: &http.Cookie{Name: "theme", Value: "dark", Secure: true, HttpOnly: true}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			CookieSecureHTTPOnly(tb, cookieResponse(tc.cookie), "session")
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestCookieSecureHTTPOnlyMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	CookieSecureHTTPOnly(tb, cookieResponse(&http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode}), "session")
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Cookie is not Secure and HttpOnly\n < cookie: session=\"abc\"; Path=/; HttpOnly; SameSite=Lax\n"}, tb.logs)
+}
+
+func TestCookieExpiresWithin(t *testing.T) {
+	in := time.Now().Add(time.Hour)
+
+	cases := []struct {
+		name     string
+		cookie   *http.Cookie
+		mustFail bool
+	}{
+		{name: "expires", cookie: &http.Cookie{Name: "session", Value: "abc", Expires: in}, mustFail: false},
+		{name: "max age", cookie: &http.Cookie{Name: "session", Value: "abc", MaxAge: 3600}, mustFail: false},
+		{name: "max age takes precedence", cookie: &http.Cookie{Name: "session", Value: "abc", MaxAge: 60, Expires: in}, mustFail: true},
+		{name: "too late", cookie: &http.Cookie{Name: "session", Value: "abc", Expires: in.Add(24 * time.Hour)}, mustFail: true},
+		{name: "deleted", cookie: &http.Cookie{Name: "session", Value: "", MaxAge: -1}, mustFail: true},
+		{name: "session cookie", cookie: &http.Cookie{Name: "session", Value: "abc"}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			CookieExpiresWithin(tb, cookieResponse(tc.cookie), "session", in, time.Minute)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package assertions
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Counter counts invocations of callbacks handed to the code under test and asserts on the count.
+// It is safe for concurrent use and the zero value is ready to use
+type Counter struct {
+	n atomic.Int64
+}
+
+// Inc adds one to the count
+func (c *Counter) Inc() {
+	c.n.Add(1)
+}
+
+// Add adds delta to the count
+func (c *Counter) Add(delta int) {
+	c.n.Add(int64(delta))
+}
+
+// Count returns the count
+func (c *Counter) Count() int {
+	return int(c.n.Load())
+}
+
+// EqualCount asserts that the count is expected
+func (c *Counter) EqualCount(tb testing.TB, expected int) {
+	const failureFormat = "Unexpected count\n > expected: %v\n < count:    %v\n"
+
+	summary.recordAssertion()
+
+	if n := c.Count(); n != expected {
+		errorfNow(tb, failureFormat, expected, n)
+		return
+	}
+}
+
+// AtLeast asserts that the count is at least minCount
+func (c *Counter) AtLeast(tb testing.TB, minCount int) {
+	const failureFormat = "Count is too low\n > at least: %v\n < count:    %v\n"
+
+	summary.recordAssertion()
+
+	if n := c.Count(); n < minCount {
+		errorfNow(tb, failureFormat, minCount, n)
+		return
+	}
+}
+
+// AtMost asserts that the count is at most maxCount
+func (c *Counter) AtMost(tb testing.TB, maxCount int) {
+	const failureFormat = "Count is too high\n > at most: %v\n < count:   %v\n"
+
+	summary.recordAssertion()
+
+	if n := c.Count(); n > maxCount {
+		errorfNow(tb, failureFormat, maxCount, n)
+		return
+	}
+}
+
+// EventualCount asserts that the count reaches expected within timeout, checking every interval.
+// A count that goes past expected fails at once
+func (c *Counter) EventualCount(tb testing.TB, expected int, timeout, interval time.Duration, opts ...WaitOption) {
+	const failureFormat = "count did not reach %v within %v\n > checked every %v\n < count: %v\n"
+	const exceededFormat = "count went past %v after %v\n < count: %v\n"
+	const canceledFormat = "count did not reach %v before waiting was stopped after %v\n > timeout: %v\n < error:   %v\n < count:   %v\n"
+
+	summary.recordAssertion()
+
+	var n int
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(timeout, interval, func() bool {
+		n = c.Count()
+		return n >= expected
+	})
+	summary.recordWait(tb, elapsed)
+	switch {
+	case err != nil:
+		errorfNow(tb, canceledFormat, expected, elapsed, timeout, err, n)
+		return
+	case !ok:
+		errorfNow(tb, failureFormat, expected, timeout, interval, n)
+		return
+	case n > expected:
+		errorfNow(tb, exceededFormat, expected, elapsed, n)
+		return
+	}
+}
@@ -0,0 +1,93 @@
+package assertions
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCounter(t *testing.T) {
+	var c Counter
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Inc()
+		}()
+	}
+	wg.Wait()
+	c.Add(2)
+
+	cases := []struct {
+		name     string
+		assert   func(tb testing.TB)
+		mustFail bool
+	}{
+		{name: "equal", assert: func(tb testing.TB) { c.EqualCount(tb, 12) }, mustFail: false},
+		{name: "not equal", assert: func(tb testing.TB) { c.EqualCount(tb, 10) }, mustFail: true},
+		{name: "at least", assert: func(tb testing.TB) { c.AtLeast(tb, 12) }, mustFail: false},
+		{name: "not at least", assert: func(tb testing.TB) { c.AtLeast(tb, 13) }, mustFail: true},
+		{name: "at most", assert: func(tb testing.TB) { c.AtMost(tb, 12) }, mustFail: false},
+		{name: "not at most", assert: func(tb testing.TB) { c.AtMost(tb, 11) }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.assert(tb)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestCounterEventualCount(t *testing.T) {
+	cases := []struct {
+		name     string
+		perCheck int
+		expected int
+		mustFail bool
+	}{
+		{name: "reached", perCheck: 1, expected: 5, mustFail: false},
+		{name: "never reached", perCheck: 0, expected: 5, mustFail: true},
+		{name: "went past", perCheck: 3, expected: 5, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Time{})
+			stop := make(chan struct{})
+			defer close(stop)
+
+			var c Counter
+			go func() {
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					clock.BlockUntilTimers(1)
+					c.Add(tc.perCheck)
+					clock.Advance(time.Second)
+				}
+			}()
+
+			tb := NewTester(t, tc.mustFail)
+			c.EventualCount(tb, tc.expected, 10*time.Second, time.Second, WithClock(clock))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestCounterMessage(t *testing.T) {
+	var c Counter
+	c.Inc()
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	c.EqualCount(tb, 2)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Unexpected count\n > expected: 2\n < count:    1\n"}, tb.logs)
+}
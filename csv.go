@@ -0,0 +1,287 @@
+package assertions
+
+import (
+	"encoding"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type csvConfig struct {
+	comma         rune
+	headerKeyed   bool
+	unorderedRows bool
+}
+
+// CSVOption configures CSVEqual and CSVReaderEqual
+type CSVOption func(*csvConfig)
+
+// CSVComma sets the field delimiter, the default is ','
+func CSVComma(comma rune) CSVOption {
+	return func(c *csvConfig) {
+		c.comma = comma
+	}
+}
+
+// CSVHeaderKeyed treats the first row as a header and compares columns by name, so columns may appear in any order
+func CSVHeaderKeyed() CSVOption {
+	return func(c *csvConfig) {
+		c.headerKeyed = true
+	}
+}
+
+// CSVUnorderedRows compares data rows regardless of order
+func CSVUnorderedRows() CSVOption {
+	return func(c *csvConfig) {
+		c.unorderedRows = true
+	}
+}
+
+type csvTable struct {
+	columns []string
+	rows    [][]string
+}
+
+func readCSV(r io.Reader, cfg csvConfig) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = cfg.comma
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+// canonicalCSV converts the records of expected and input to tables with the same column order.
+// Differences in the header are returned as problems
+func canonicalCSV(expected, input [][]string, cfg csvConfig) (csvTable, csvTable, []string) {
+	if !cfg.headerKeyed {
+		width := 0
+		for _, row := range append(append([][]string(nil), expected...), input...) {
+			width = max(width, len(row))
+		}
+		columns := make([]string, width)
+		for i := range columns {
+			columns[i] = fmt.Sprint(i)
+		}
+		return csvTable{columns: columns, rows: expected}, csvTable{columns: columns, rows: input}, nil
+	}
+
+	var problems []string
+	if len(expected) == 0 || len(input) == 0 {
+		if len(expected) != len(input) {
+			problems = append(problems, "header row is missing")
+		}
+		return csvTable{}, csvTable{}, problems
+	}
+
+	columns := expected[0]
+	index := make(map[string]int, len(input[0]))
+	for i, name := range input[0] {
+		index[name] = i
+	}
+
+	inputSet := NewSet(input[0]...)
+	expectedSet := NewSet(columns...)
+	for _, name := range expectedSet.difference(inputSet) {
+		problems = append(problems, fmt.Sprintf("column %q is missing from input", name))
+	}
+	for _, name := range inputSet.difference(expectedSet) {
+		problems = append(problems, fmt.Sprintf("column %q is not expected", name))
+	}
+
+	reordered := make([][]string, len(input)-1)
+	for r, row := range input[1:] {
+		reordered[r] = make([]string, len(columns))
+		for c, name := range columns {
+			if i, ok := index[name]; ok && i < len(row) {
+				reordered[r][c] = row[i]
+			}
+		}
+	}
+
+	return csvTable{columns: columns, rows: expected[1:]}, csvTable{columns: columns, rows: reordered}, problems
+}
+
+// csvDifferences compares the rows of two canonical tables
+func csvDifferences(expected, input csvTable, cfg csvConfig) []string {
+	var problems []string
+
+	if cfg.unorderedRows {
+		expectedOnly, inputOnly := nonMatchingSlices(expected.rows, input.rows)
+		for _, row := range expectedOnly {
+			problems = append(problems, fmt.Sprintf("row %q is missing from input", row))
+		}
+		for _, row := range inputOnly {
+			problems = append(problems, fmt.Sprintf("row %q is not expected", row))
+		}
+		return problems
+	}
+
+	if len(expected.rows) != len(input.rows) {
+		problems = append(problems, fmt.Sprintf("expected %v rows, got %v", len(expected.rows), len(input.rows)))
+	}
+
+	for r := range min(len(expected.rows), len(input.rows)) {
+		er, ir := expected.rows[r], input.rows[r]
+		if !cfg.headerKeyed && len(er) != len(ir) {
+			problems = append(problems, fmt.Sprintf("row %v: expected %v columns, got %v", r+1, len(er), len(ir)))
+		}
+		for c := range min(len(er), len(ir)) {
+			if er[c] != ir[c] {
+				problems = append(problems, fmt.Sprintf("row %v, column %v: expected %q, got %q", r+1, expected.columns[c], er[c], ir[c]))
+			}
+		}
+	}
+
+	return problems
+}
+
+func reportCSVProblems(tb testing.TB, problems []string) {
+	const failureFormat = "CSV does not match\n%v"
+
+	var b strings.Builder
+	for _, p := range problems {
+		fmt.Fprintf(&b, " ~ %v\n", p)
+	}
+	errorfNow(tb, failureFormat, b.String())
+}
+
+// CSVReaderEqual asserts that expected and input contain the same CSV data. Records are compared cell by cell,
+// see CSVHeaderKeyed and CSVUnorderedRows to relax the comparison. Rows are numbered from 1, excluding any header
+func CSVReaderEqual(tb testing.TB, expected, input io.Reader, opts ...CSVOption) {
+	const invalidFormat = "%v is not valid CSV\n > error: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := csvConfig{comma: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	expectedRecords, err := readCSV(expected, cfg)
+	if err != nil {
+		errorfNow(tb, invalidFormat, "expected", err)
+		return
+	}
+	inputRecords, err := readCSV(input, cfg)
+	if err != nil {
+		errorfNow(tb, invalidFormat, "input", err)
+		return
+	}
+
+	expectedTable, inputTable, problems := canonicalCSV(expectedRecords, inputRecords, cfg)
+	problems = append(problems, csvDifferences(expectedTable, inputTable, cfg)...)
+	if len(problems) > 0 {
+		reportCSVProblems(tb, problems)
+		return
+	}
+}
+
+// CSVEqual asserts that the strings expected and input contain the same CSV data, see CSVReaderEqual
+func CSVEqual(tb testing.TB, expected, input string, opts ...CSVOption) {
+	CSVReaderEqual(tb, strings.NewReader(expected), strings.NewReader(input), opts...)
+}
+
+// csvColumns maps column names to the exported fields of t, named by a csv struct tag or the field name.
+// Fields tagged csv:"-" are skipped
+func csvColumns(t reflect.Type) map[string]int {
+	columns := make(map[string]int)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		columns[name] = i
+	}
+	return columns
+}
+
+// csvCell prints a field value as it would appear in a CSV fixture. Nil pointers are empty cells,
+// encoding.TextMarshaler and fmt.Stringer implementations are used when present
+func csvCell(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch i := v.Interface().(type) {
+	case encoding.TextMarshaler:
+		if text, err := i.MarshalText(); err == nil {
+			return string(text)
+		}
+	case fmt.Stringer:
+		return i.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// StructSliceMatchesCSV asserts that input, a slice of structs, holds the rows of the CSV fixture read from expected.
+// The first row of the fixture is a header naming the field of each column, by csv struct tag or field name.
+// Fields without a column are not compared. Cells are compared with the fields printed by their MarshalText or
+// String method, or by fmt.Sprint. CSVComma reads TSV and other delimiters and CSVUnorderedRows ignores row order.
+// An empty fixture, without even a header, matches an empty input
+func StructSliceMatchesCSV[T any](tb testing.TB, expected io.Reader, input []T, opts ...CSVOption) {
+	const invalidFormat = "expected is not valid CSV\n > error: %v\n"
+	const typeFormat = "input elements must be structs\n < input type: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := csvConfig{comma: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.headerKeyed = true
+
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		errorfNow(tb, typeFormat, t)
+		return
+	}
+
+	expectedRecords, err := readCSV(expected, cfg)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	if len(expectedRecords) == 0 && len(input) == 0 {
+		return
+	}
+
+	// Only the columns of the fixture are rendered, so fields without a column are not reported as unexpected
+	fields := csvColumns(t)
+	var header []string
+	if len(expectedRecords) > 0 {
+		for _, name := range expectedRecords[0] {
+			if _, ok := fields[name]; ok {
+				header = append(header, name)
+			}
+		}
+	}
+
+	inputRecords := [][]string{header}
+	for _, element := range input {
+		v := reflect.ValueOf(element)
+		row := make([]string, len(header))
+		for c, name := range header {
+			row[c] = csvCell(v.Field(fields[name]))
+		}
+		inputRecords = append(inputRecords, row)
+	}
+
+	expectedTable, inputTable, problems := canonicalCSV(expectedRecords, inputRecords, cfg)
+	problems = append(problems, csvDifferences(expectedTable, inputTable, cfg)...)
+	if len(problems) > 0 {
+		reportCSVProblems(tb, problems)
+		return
+	}
+}
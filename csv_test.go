@@ -0,0 +1,157 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		opts     []CSVOption
+		mustFail bool
+	}{
+		{name: "equal", expected: "a,b\n1,2\n", input: "a,b\n1,2\n", mustFail: false},
+		{name: "quoting is insignificant", expected: "a,b\n1,2\n", input: "\"a\",b\n1,\"2\"", mustFail: false},
+		{name: "cell differs", expected: "a,b\n1,2\n", input: "a,b\n1,3\n", mustFail: true},
+		{name: "extra row", expected: "a,b\n1,2\n", input: "a,b\n1,2\n3,4\n", mustFail: true},
+		{name: "ragged row", expected: "a,b\n1,2\n", input: "a,b\n1\n", mustFail: true},
+		{name: "invalid", expected: "a,b\n", input: "\"a,b\n", mustFail: true},
+		{name: "semicolons", expected: "a;b\n1;2\n", input: "a;b\n1;2\n", opts: []CSVOption{CSVComma(';')}, mustFail: false},
+		{name: "reordered columns", expected: "a,b\n1,2\n", input: "b,a\n2,1\n", mustFail: true},
+		{name: "header keyed", expected: "a,b\n1,2\n", input: "b,a\n2,1\n", opts: []CSVOption{CSVHeaderKeyed()}, mustFail: false},
+		{name: "header keyed cell differs", expected: "a,b\n1,2\n", input: "b,a\n2,3\n", opts: []CSVOption{CSVHeaderKeyed()}, mustFail: true},
+		{name: "header keyed missing column", expected: "a,b\n1,2\n", input: "a\n1\n", opts: []CSVOption{CSVHeaderKeyed()}, mustFail: true},
+		{name: "header keyed extra column", expected: "a\n1\n", input: "a,b\n1,2\n", opts: []CSVOption{CSVHeaderKeyed()}, mustFail: true},
+		{name: "header keyed empty", expected: "", input: "", opts: []CSVOption{CSVHeaderKeyed()}, mustFail: false},
+		{name: "reordered rows", expected: "1,2\n3,4\n", input: "3,4\n1,2\n", mustFail: true},
+		{name: "unordered rows", expected: "1,2\n3,4\n", input: "3,4\n1,2\n", opts: []CSVOption{CSVUnorderedRows()}, mustFail: false},
+		{name: "unordered rows differ", expected: "1,2\n3,4\n", input: "3,4\n1,3\n", opts: []CSVOption{CSVUnorderedRows()}, mustFail: true},
+		{name: "unordered header keyed", expected: "a,b\n1,2\n3,4\n", input: "b,a\n4,3\n2,1\n", opts: []CSVOption{CSVUnorderedRows(), CSVHeaderKeyed()}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			CSVEqual(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestCSVDifferences(t *testing.T) {
+	cfg := csvConfig{comma: ',', headerKeyed: true}
+	expected, input, problems := canonicalCSV([][]string{{"id", "name"}, {"1", "x"}}, [][]string{{"name", "id"}, {"y", "1"}}, cfg)
+
+	Equal(t, 0, len(problems))
+	Equal(t, []string{`row 1, column name: expected "x", got "y"`}, csvDifferences(expected, input, cfg))
+}
+
+type csvAccount struct {
+	ID      int       `csv:"id"`
+	Name    string    `csv:"name"`
+	Balance *float64  `csv:"balance"`
+	Opened  time.Time `csv:"opened"`
+	Term    time.Duration
+	Secret  string `csv:"-"`
+	notes   string
+}
+
+func TestStructSliceMatchesCSV(t *testing.T) {
+	opened := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	accounts := []csvAccount{
+		{ID: 1, Name: "alice", Balance: Ptr(10.5), Opened: opened, Term: time.Second, Secret: "s", notes: "n"},
+		{ID: 2, Name: "bob", Opened: opened},
+	}
+
+	cases := []struct {
+		name     string
+		fixture  string
+		opts     []CSVOption
+		mustFail bool
+	}{
+		{
+			name:     "all columns",
+			fixture:  "id,name,balance,opened,Term\n1,alice,10.5,2024-03-01T00:00:00Z,1s\n2,bob,,2024-03-01T00:00:00Z,0s\n",
+			mustFail: false,
+		},
+		{
+			name:     "subset of columns in any order",
+			fixture:  "name,id\nalice,1\nbob,2\n",
+			mustFail: false,
+		},
+		{
+			name:     "tsv",
+			fixture:  "id\tname\n1\talice\n2\tbob\n",
+			opts:     []CSVOption{CSVComma('\t')},
+			mustFail: false,
+		},
+		{
+			name:     "unordered rows",
+			fixture:  "id,name\n2,bob\n1,alice\n",
+			opts:     []CSVOption{CSVUnorderedRows()},
+			mustFail: false,
+		},
+		{
+			name:     "different cell",
+			fixture:  "id,name\n1,alice\n2,carol\n",
+			mustFail: true,
+		},
+		{
+			name:     "missing row",
+			fixture:  "id,name\n1,alice\n",
+			mustFail: true,
+		},
+		{
+			name:     "column without field",
+			fixture:  "id,email\n1,a@example.test\n2,b@example.test\n",
+			mustFail: true,
+		},
+		{
+			name:     "skipped field",
+			fixture:  "id,Secret\n1,s\n2,\n",
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			StructSliceMatchesCSV(tb, strings.NewReader(tc.fixture), accounts, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestStructSliceMatchesCSVEmpty(t *testing.T) {
+	cases := []struct {
+		name     string
+		fixture  string
+		input    []csvAccount
+		mustFail bool
+	}{
+		{name: "empty fixture and input", fixture: "", input: nil, mustFail: false},
+		{name: "header only", fixture: "id,name\n", input: nil, mustFail: false},
+		{name: "empty fixture with rows", fixture: "", input: []csvAccount{{ID: 1}}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			StructSliceMatchesCSV(tb, strings.NewReader(tc.fixture), tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestStructSliceMatchesCSVNotStructs(t *testing.T) {
+	tb := NewTester(t, true)
+	StructSliceMatchesCSV(tb, strings.NewReader("a\n1\n"), []int{1})
+	tb.AssertExpectation()
+}
@@ -0,0 +1,123 @@
+package assertions
+
+import (
+	"database/sql"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type tableConfig struct {
+	columns []string
+}
+
+// TableOption configures DBTableEquals
+type TableOption func(*tableConfig)
+
+// TableColumns compares only the given columns, leaving out generated ids and timestamps.
+// Expected rows must then have exactly these keys
+func TableColumns(columns ...string) TableOption {
+	return func(c *tableConfig) {
+		c.columns = append(c.columns, columns...)
+	}
+}
+
+// normalizeSQLValue converts the types drivers return for a column, and the types of Go literals in expected
+// rows, to a common type: []byte to string, integers to int64, or uint64 if too large, and float32 to float64
+func normalizeSQLValue(v any) any {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := rv.Uint(); u > math.MaxInt64 {
+			return u
+		}
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Slice:
+		if b, ok := v.([]byte); ok {
+			return string(b)
+		}
+	}
+	return v
+}
+
+func normalizeRows(rows []map[string]any) []map[string]any {
+	out := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		out[i] = make(map[string]any, len(row))
+		for k, v := range row {
+			out[i][k] = normalizeSQLValue(v)
+		}
+	}
+	return out
+}
+
+// queryRows returns every row of query as a map from column name to value
+func queryRows(db *sql.DB, query string) ([]map[string]any, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []map[string]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]any, len(columns))
+		for i, c := range columns {
+			row[c] = values[i]
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// DBTableEquals asserts that table holds exactly the rows of expected regardless of order, each row a map
+// from column name to value. Values are compared after converting the types drivers return to common ones,
+// so an expected int equals an INTEGER column and an expected string a TEXT column returned as []byte.
+// NULL is nil. table and the names given to TableColumns are inserted into the query as written, quote them
+// if needed. Failing results print how many times each differing row occurs in expected and the table
+func DBTableEquals(tb testing.TB, db *sql.DB, table string, expected []map[string]any, opts ...TableOption) {
+	const queryFormat = "Table could not be read\n ~ query: %v\n < error: %v\n"
+	const failureFormat = "Table rows do not match\n ~ table: %v\n%v"
+
+	summary.recordAssertion()
+
+	var cfg tableConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	columns := "*"
+	if len(cfg.columns) > 0 {
+		columns = strings.Join(cfg.columns, ", ")
+	}
+	query := "SELECT " + columns + " FROM " + table
+	rows, err := queryRows(db, query)
+	if err != nil {
+		errorfNow(tb, queryFormat, query, err)
+		return
+	}
+
+	e, i := normalizeRows(expected), normalizeRows(rows)
+	expectedNoMatch, inputNoMatch := nonMatchingSlices(e, i)
+	if len(expectedNoMatch) > 0 || len(inputNoMatch) > 0 {
+		errorfNow(tb, failureFormat, table, formatMultiplicities(multiplicities(e, i, append(expectedNoMatch, inputNoMatch...))))
+		return
+	}
+}
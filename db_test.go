@@ -0,0 +1,167 @@
+package assertions
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeTables are the tables served by the fake driver, each a list of columns followed by rows
+var fakeTables = map[string][][]driver.Value{
+	"users": {
+		{"id", "name", "age"},
+		{int64(1), []byte("ada"), int64(36)},
+		{int64(2), []byte("grace"), nil},
+	},
+}
+
+// fakeDriver answers queries of the form SELECT columns FROM table from fakeTables
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	query string
+}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return 0 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	columns, table, ok := strings.Cut(strings.TrimPrefix(s.query, "SELECT "), " FROM ")
+	data, found := fakeTables[table]
+	if !ok || !found {
+		return nil, errors.New("no such table: " + table)
+	}
+
+	names := make([]string, len(data[0]))
+	for i, c := range data[0] {
+		names[i] = c.(string)
+	}
+	selected := names
+	if columns != "*" {
+		selected = strings.Split(columns, ", ")
+	}
+
+	rows := &fakeRows{columns: selected}
+	for _, row := range data[1:] {
+		values := make([]driver.Value, len(selected))
+		for i, c := range selected {
+			j := slices.Index(names, c)
+			if j < 0 {
+				return nil, errors.New("no such column: " + c)
+			}
+			values[i] = row[j]
+		}
+		rows.rows = append(rows.rows, values)
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("assertions-fake", fakeDriver{})
+}
+
+func TestDBTableEquals(t *testing.T) {
+	db, err := sql.Open("assertions-fake", "")
+	NoError(t, err)
+	defer db.Close()
+
+	cases := []struct {
+		name     string
+		table    string
+		expected []map[string]any
+		opts     []TableOption
+		mustFail bool
+	}{
+		{
+			name:  "all columns in any order",
+			table: "users",
+			expected: []map[string]any{
+				{"id": 2, "name": "grace", "age": nil},
+				{"id": 1, "name": "ada", "age": 36},
+			},
+			mustFail: false,
+		},
+		{
+			name:     "column subset",
+			table:    "users",
+			expected: []map[string]any{{"name": "ada"}, {"name": "grace"}},
+			opts:     []TableOption{TableColumns("name")},
+			mustFail: false,
+		},
+		{
+			name:     "missing row",
+			table:    "users",
+			expected: []map[string]any{{"name": "ada"}},
+			opts:     []TableOption{TableColumns("name")},
+			mustFail: true,
+		},
+		{
+			name:     "different value",
+			table:    "users",
+			expected: []map[string]any{{"name": "ada"}, {"name": "linus"}},
+			opts:     []TableOption{TableColumns("name")},
+			mustFail: true,
+		},
+		{
+			name:     "unknown table",
+			table:    "orders",
+			expected: nil,
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			DBTableEquals(tb, db, tc.table, tc.expected, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestDBTableEqualsMessage(t *testing.T) {
+	db, err := sql.Open("assertions-fake", "")
+	NoError(t, err)
+	defer db.Close()
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	DBTableEquals(tb, db, "users", []map[string]any{{"id": 1}, {"id": 3}}, TableColumns("id"))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Table rows do not match\n ~ table: users\n" +
+		" ~ map[string]interface {}{\"id\":3}: expected 1×, got 0×\n" +
+		" ~ map[string]interface {}{\"id\":2}: expected 0×, got 1×\n"}, tb.logs)
+}
@@ -0,0 +1,22 @@
+// Package decimal registers a comparer and formatter for shopspring/decimal with the assertions package,
+// so decimals with the same value are equal regardless of their exponent, e.g. 1.50 and 1.5.
+// Import it for its side effects from a test file:
+//
+//	import _ "github.com/jcopi/assertions/decimal"
+//
+// It is a separate module so the assertions package stays free of dependencies
+package decimal
+
+import (
+	"github.com/jcopi/assertions"
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	assertions.RegisterComparer(func(expected, input decimal.Decimal) bool {
+		return expected.Equal(input)
+	})
+	assertions.RegisterFormatter(func(d decimal.Decimal) string {
+		return "decimal(" + d.String() + ")"
+	})
+}
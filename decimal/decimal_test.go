@@ -0,0 +1,64 @@
+package decimal
+
+import (
+	"testing"
+
+	"github.com/jcopi/assertions"
+	"github.com/shopspring/decimal"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+func TestDecimalEqual(t *testing.T) {
+	type invoice struct {
+		Total decimal.Decimal
+		Lines []decimal.Decimal
+	}
+
+	cases := []struct {
+		name     string
+		expected invoice
+		input    invoice
+		mustFail bool
+	}{
+		{
+			name:     "same representation",
+			expected: invoice{Total: decimal.RequireFromString("1.5")},
+			input:    invoice{Total: decimal.RequireFromString("1.5")},
+			mustFail: false,
+		},
+		{
+			name:     "different exponent",
+			expected: invoice{Total: decimal.RequireFromString("1.5"), Lines: []decimal.Decimal{decimal.NewFromInt(1)}},
+			input:    invoice{Total: decimal.RequireFromString("1.500"), Lines: []decimal.Decimal{decimal.RequireFromString("1.0")}},
+			mustFail: false,
+		},
+		{
+			name:     "different value",
+			expected: invoice{Total: decimal.RequireFromString("1.5")},
+			input:    invoice{Total: decimal.RequireFromString("1.51")},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			assertions.Equal(tb, tc.expected, tc.input)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/jcopi/assertions/decimal
+
+go 1.23.0
+
+require (
+	github.com/jcopi/assertions v0.1.0
+	github.com/shopspring/decimal v1.4.0
+)
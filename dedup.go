@@ -0,0 +1,178 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type groupedFailure struct {
+	message string
+	cases   []string
+}
+
+// FailureGroups collects the failures of subtests and reports each distinct failure message once,
+// with the number and names of the subtests that failed with it
+type FailureGroups struct {
+	tb       testing.TB
+	mu       sync.Mutex
+	failures []*groupedFailure
+}
+
+// GroupFailures returns FailureGroups reporting on tb, the parent of the subtests, once tb and all of its
+// subtests have completed. Subtests opt in by asserting through the testing.TB returned by TB:
+//
+//	groups := GroupFailures(t)
+//	for _, tc := range cases {
+//		t.Run(tc.name, func(t *testing.T) {
+//			tb := groups.TB(t)
+//			Equal(tb, tc.expected, fn(tc.input))
+//		})
+//	}
+func GroupFailures(tb testing.TB) *FailureGroups {
+	g := &FailureGroups{tb: tb}
+	tb.Cleanup(g.report)
+	return g
+}
+
+// TB returns a testing.TB for the subtest tb whose failure messages are reported by the parent instead of
+// being logged by tb. tb still fails as usual
+func (g *FailureGroups) TB(tb testing.TB) testing.TB {
+	d := &dedupTB{TB: tb, groups: g}
+	tb.Cleanup(d.flush)
+	return d
+}
+
+func (g *FailureGroups) add(name, message string) {
+	name = strings.TrimPrefix(name, g.tb.Name()+"/")
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, f := range g.failures {
+		if f.message == message {
+			f.cases = append(f.cases, name)
+			return
+		}
+	}
+	g.failures = append(g.failures, &groupedFailure{message: message, cases: []string{name}})
+}
+
+// report logs every distinct failure in the order they were first seen
+func (g *FailureGroups) report() {
+	const failureFormat = "%v failed with\n%v ~ cases: %v\n"
+
+	g.mu.Lock()
+	failures := g.failures
+	g.failures = nil
+	g.mu.Unlock()
+
+	for _, f := range failures {
+		count := "1 subtest"
+		if len(f.cases) > 1 {
+			count = fmt.Sprintf("%v subtests", len(f.cases))
+		}
+		g.tb.Logf(failureFormat, count, f.message, strings.Join(f.cases, ", "))
+	}
+}
+
+// dedupTB is a testing.TB that hands its failure messages to FailureGroups. Logged messages are held until
+// the test fails, when they become the failure message, or completes, when they are logged as usual
+type dedupTB struct {
+	testing.TB
+	groups  *FailureGroups
+	mu      sync.Mutex
+	pending []string
+}
+
+var _ testing.TB = &dedupTB{}
+
+// fail records message, together with any messages logged since the last failure, as one failure
+func (d *dedupTB) fail(message string) {
+	const groupedFormat = "failure reported by %v\n"
+
+	d.mu.Lock()
+	if message != "" {
+		d.pending = append(d.pending, message)
+	}
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, msg := range pending {
+		b.WriteString(strings.TrimSuffix(msg, "\n") + "\n")
+	}
+	d.groups.add(d.TB.Name(), b.String())
+	d.TB.Logf(groupedFormat, d.groups.tb.Name())
+}
+
+func (d *dedupTB) flush() {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	for _, msg := range pending {
+		d.TB.Log(msg)
+	}
+}
+
+// warnFunc implements warner. Warnings are not failures so they are logged rather than grouped
+func (d *dedupTB) warnFunc() func(message string) {
+	return warnerOf(d.TB)
+}
+
+// Error implements testing.TB.
+func (d *dedupTB) Error(args ...any) {
+	d.fail(fmt.Sprint(args...))
+	d.TB.Fail()
+}
+
+// Errorf implements testing.TB.
+func (d *dedupTB) Errorf(format string, args ...any) {
+	d.fail(fmt.Sprintf(format, args...))
+	d.TB.Fail()
+}
+
+// Fail implements testing.TB.
+func (d *dedupTB) Fail() {
+	d.fail("")
+	d.TB.Fail()
+}
+
+// FailNow implements testing.TB.
+func (d *dedupTB) FailNow() {
+	d.fail("")
+	d.TB.FailNow()
+}
+
+// Fatal implements testing.TB.
+func (d *dedupTB) Fatal(args ...any) {
+	d.fail(fmt.Sprint(args...))
+	d.TB.FailNow()
+}
+
+// Fatalf implements testing.TB.
+func (d *dedupTB) Fatalf(format string, args ...any) {
+	d.fail(fmt.Sprintf(format, args...))
+	d.TB.FailNow()
+}
+
+// Log implements testing.TB.
+func (d *dedupTB) Log(args ...any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = append(d.pending, fmt.Sprint(args...))
+}
+
+// Logf implements testing.TB.
+func (d *dedupTB) Logf(format string, args ...any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = append(d.pending, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,62 @@
+package assertions
+
+import (
+	"testing"
+)
+
+// namedTB is a recordingTB with its own test name
+type namedTB struct {
+	recordingTB
+	name string
+}
+
+func (n *namedTB) Name() string {
+	return n.name
+}
+
+func TestGroupFailures(t *testing.T) {
+	parent := &namedTB{recordingTB: recordingTB{TesterTB: NewTester(t, false)}, name: "TestTable"}
+	groups := GroupFailures(parent)
+
+	subtests := map[string]*namedTB{}
+	for _, tc := range []struct {
+		name  string
+		input int
+	}{
+		{name: "first", input: 2},
+		{name: "second", input: 1},
+		{name: "third", input: 2},
+		{name: "fourth", input: 3},
+	} {
+		sub := &namedTB{recordingTB: recordingTB{TesterTB: NewTester(t, tc.input != 1)}, name: "TestTable/" + tc.name}
+		subtests[tc.name] = sub
+		Equal(groups.TB(sub), 1, tc.input)
+		sub.AssertExpectation()
+	}
+
+	groups.report()
+
+	expected := []string{
+		"2 subtests failed with\nValues are not equal\n > expected: 1\n < input:    2\n ~ cases: first, third\n",
+		"1 subtest failed with\nValues are not equal\n > expected: 1\n < input:    3\n ~ cases: fourth\n",
+	}
+	Equal(t, expected, parent.logs)
+	Equal(t, []string{"failure reported by TestTable\n"}, subtests["first"].logs)
+	Equal(t, []string(nil), subtests["second"].logs)
+}
+
+func TestGroupFailuresPassingLogs(t *testing.T) {
+	parent := &namedTB{recordingTB: recordingTB{TesterTB: NewTester(t, false)}, name: "TestTable"}
+	groups := GroupFailures(parent)
+
+	sub := &namedTB{recordingTB: recordingTB{TesterTB: NewTester(t, false)}, name: "TestTable/case"}
+	tb := groups.TB(sub).(*dedupTB)
+	tb.Logf("progress %v", 1)
+	tb.flush()
+	sub.AssertExpectation()
+
+	groups.report()
+
+	Equal(t, []string{"progress 1"}, sub.logs)
+	Equal(t, []string(nil), parent.logs)
+}
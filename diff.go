@@ -0,0 +1,991 @@
+package assertions
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+const missingValue = "<missing>"
+
+// difference is a single leaf at which two values differ.
+// path is the Go access expression for the leaf relative to the compared values, empty for the values themselves.
+// note optionally explains the difference
+type difference struct {
+	path     string
+	expected string
+	input    string
+	note     string
+}
+
+type compareConfig struct {
+	maxDiffs       int
+	parallel       bool
+	unexported     UnexportedPolicy
+	ignoreFuncs    bool
+	chansByType    bool
+	nilEqualsEmpty bool
+	followPointers bool
+	wildcards      []reflect.Value
+	captures       []registeredCapture
+
+	// comparers is a snapshot of the registered comparers taken when a comparison starts,
+	// nil for walks that look them up as they go
+	comparers map[reflect.Type]func(expected, input any) bool
+}
+
+type registeredCapture struct {
+	placeholder reflect.Value
+	capture     capturer
+}
+
+// CompareOption adjusts how values are compared by Equal
+type CompareOption func(*compareConfig)
+
+// FollowPointers compares the values pointers refer to regardless of how many
+// pointers lead to them, so a *string matches a string inside an interface
+// and a nil pointer matches a nil interface
+func FollowPointers() CompareOption {
+	return func(c *compareConfig) {
+		c.followPointers = true
+	}
+}
+
+// StopAfter stops comparing once n differences have been found, so that comparing huge values that are
+// systematically different fails quickly. Failing results say that the comparison stopped and, when it
+// stopped part way through a slice or map, estimate the total number of differences from the part compared
+func StopAfter(n int) CompareOption {
+	return func(c *compareConfig) {
+		c.maxDiffs = n
+	}
+}
+
+// parallelMinElements is the number of elements each worker is given at least when comparing in parallel
+const parallelMinElements = 4096
+
+// Parallel compares the elements of large slices and maps using up to GOMAXPROCS goroutines, which
+// shortens the comparison of datasets with millions of elements. Collections with fewer than 8192 elements
+// are compared sequentially, as are values holding a Capture. Comparers registered with RegisterComparer
+// must be safe to call concurrently
+func Parallel() CompareOption {
+	return func(c *compareConfig) {
+		c.parallel = true
+	}
+}
+
+// UnexportedPolicy selects how Equal treats unexported struct fields
+type UnexportedPolicy int
+
+const (
+	// CompareUnexported compares unexported fields like exported ones, as reflect.DeepEqual does. This is the default
+	CompareUnexported UnexportedPolicy = iota
+	// IgnoreUnexported skips unexported fields, so only the exported API of a struct is compared
+	IgnoreUnexported
+	// RejectUnexported fails the comparison when it reaches a struct with unexported fields, even if the
+	// values are equal, so that types such as those embedding a sync.Mutex are not compared by accident.
+	// Types with a comparer registered by RegisterComparer are still compared by it
+	RejectUnexported
+)
+
+// WithUnexported sets the policy for unexported struct fields, see UnexportedPolicy
+func WithUnexported(policy UnexportedPolicy) CompareOption {
+	return func(c *compareConfig) {
+		c.unexported = policy
+	}
+}
+
+// NilEqualsEmpty treats a nil slice or map as equal to an empty one, see EqualLoose
+func NilEqualsEmpty() CompareOption {
+	return func(c *compareConfig) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// IgnoreFuncs skips values of function type, including struct fields holding callbacks.
+// By default functions are only equal when both are nil, as with reflect.DeepEqual
+func IgnoreFuncs() CompareOption {
+	return func(c *compareConfig) {
+		c.ignoreFuncs = true
+	}
+}
+
+// ChannelsByType compares channels by their capacity, and whether they are nil, rather than by identity,
+// so values holding channels made separately may be equal. The element types are equal as the types are
+func ChannelsByType() CompareOption {
+	return func(c *compareConfig) {
+		c.chansByType = true
+	}
+}
+
+// hasUnexportedFields reports whether the struct type t has unexported fields
+func hasUnexportedFields(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// Wildcard is the type of Anything
+type Wildcard struct{}
+
+// Anything may be placed in an expected value wherever the static type is an interface,
+// such as the values of a map[string]any or a field of type any, to match any input at that position
+var Anything any = Wildcard{}
+
+// WithWildcard registers placeholder as a wildcard, any expected value with the same type that is equal
+// to placeholder matches any input at that position. This supports positions that are not interfaces,
+// e.g. WithWildcard("<any>") lets "<any>" stand in for a generated ID in a string field
+func WithWildcard(placeholder any) CompareOption {
+	return func(c *compareConfig) {
+		c.wildcards = append(c.wildcards, reflect.ValueOf(placeholder))
+	}
+}
+
+// capturer is implemented by *Capture, capture reports whether input was accepted
+type capturer interface {
+	capture(input reflect.Value) bool
+}
+
+// Capture records the input value found at its position during a comparison, so that it may be
+// used by later assertions. A *Capture may be placed in an expected value wherever the static type
+// is an interface, or registered against a placeholder with WithCapture.
+// Once a value has been captured any later position using the same Capture must be equal to it,
+// which allows asserting that a value generated in one response is echoed in another
+type Capture[T any] struct {
+	value    T
+	captured bool
+}
+
+// NewCapture returns an empty Capture for values of type T
+func NewCapture[T any]() *Capture[T] {
+	return &Capture[T]{}
+}
+
+// Value returns the captured value, or the zero value if nothing has been captured
+func (c *Capture[T]) Value() T {
+	return c.value
+}
+
+// Captured reports whether a value has been captured
+func (c *Capture[T]) Captured() bool {
+	return c.captured
+}
+
+// GoString describes the capture in failure output
+func (c *Capture[T]) GoString() string {
+	if c.captured {
+		return fmt.Sprintf("Capture[%v](%#v)", reflect.TypeFor[T](), c.value)
+	}
+	return fmt.Sprintf("Capture[%v]", reflect.TypeFor[T]())
+}
+
+func (c *Capture[T]) capture(input reflect.Value) bool {
+	if input.IsValid() && input.Kind() == reflect.Interface && !input.IsNil() {
+		input = input.Elem()
+	}
+
+	var value T
+	switch {
+	case input.IsValid() && input.Type().AssignableTo(reflect.TypeFor[T]()):
+		reflect.ValueOf(&value).Elem().Set(input)
+	case isNil(input) && isNilable(reflect.TypeFor[T]()):
+		// nil inputs are captured as the zero value of a nilable T
+	default:
+		return false
+	}
+
+	if c.captured {
+		var d differ
+		d.walk(&diffPath{}, reflect.ValueOf(&c.value).Elem(), reflect.ValueOf(&value).Elem())
+		return len(d.diffs) == 0
+	}
+
+	c.value, c.captured = value, true
+	return true
+}
+
+func isNilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
+
+// WithCapture registers placeholder so that any expected value with the same type that is
+// equal to placeholder is captured into c, supporting positions that are not interfaces
+func WithCapture[T any](placeholder T, c *Capture[T]) CompareOption {
+	return func(cfg *compareConfig) {
+		cfg.captures = append(cfg.captures, registeredCapture{placeholder: reflect.ValueOf(&placeholder).Elem(), capture: c})
+	}
+}
+
+var (
+	comparersMu sync.RWMutex
+	comparers   = make(map[reflect.Type]func(expected, input any) bool)
+)
+
+// RegisterComparer sets the function used by Equal and the other comparing assertions to decide whether
+// two values of type T are equal, replacing the field by field comparison. This suits types whose internal
+// representation differs between equal values, such as arbitrary precision numbers.
+// Comparers are global and are typically registered from an init function or TestMain
+func RegisterComparer[T any](equal func(expected, input T) bool) {
+	comparersMu.Lock()
+	defer comparersMu.Unlock()
+
+	comparers[reflect.TypeFor[T]()] = func(expected, input any) bool {
+		return equal(expected.(T), input.(T))
+	}
+}
+
+func registeredComparer(t reflect.Type) (func(expected, input any) bool, bool) {
+	comparersMu.RLock()
+	defer comparersMu.RUnlock()
+
+	fn, ok := comparers[t]
+	return fn, ok
+}
+
+// snapshotComparers returns a copy of the registered comparers, so a comparison looks them up without locking
+func snapshotComparers() map[reflect.Type]func(expected, input any) bool {
+	comparersMu.RLock()
+	defer comparersMu.RUnlock()
+
+	return maps.Clone(comparers)
+}
+
+// comparer returns the comparer for t from the snapshot taken for the comparison, if there is one
+func (d *differ) comparer(t reflect.Type) (func(expected, input any) bool, bool) {
+	if d.cfg.comparers == nil {
+		return registeredComparer(t)
+	}
+	fn, ok := d.cfg.comparers[t]
+	return fn, ok
+}
+
+// interfaceOf returns the value held by v, including values reached through unexported fields when v is addressable
+func interfaceOf(v reflect.Value) (any, bool) {
+	switch {
+	case v.CanInterface():
+		return v.Interface(), true
+	case v.CanAddr():
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface(), true
+	}
+	return nil, false
+}
+
+// asType returns v as a value of type t when it can be assigned to one, so that it compares as Equal
+// would with both values of type t: nil is the nil value of a pointer, interface, slice or map type
+// and any value can be compared with an interface
+func asType(v any, t reflect.Type) reflect.Value {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		if isNilable(t) {
+			return reflect.Zero(t)
+		}
+		return rv
+	}
+	if rv.Type() == t || !rv.Type().AssignableTo(t) {
+		return rv
+	}
+	converted := reflect.New(t).Elem()
+	converted.Set(rv)
+	return converted
+}
+
+// Ptr returns a pointer to a copy of v, for building fixtures with pointer fields
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// diffPath is the position of a value within the compared values, a Go access expression that is only
+// formatted when a difference is reported there. The root holds a prefix, every other path is a field,
+// an element index or a map key of its parent
+type diffPath struct {
+	parent *diffPath
+	prefix string
+	field  string
+	index  int
+	key    reflect.Value
+}
+
+func (p *diffPath) fieldPath(name string) *diffPath {
+	return &diffPath{parent: p, field: name}
+}
+
+func (p *diffPath) indexPath(i int) *diffPath {
+	return &diffPath{parent: p, index: i}
+}
+
+func (p *diffPath) keyPath(k reflect.Value) *diffPath {
+	return &diffPath{parent: p, key: k}
+}
+
+func (p *diffPath) String() string {
+	var segments []*diffPath
+	for ; p.parent != nil; p = p.parent {
+		segments = append(segments, p)
+	}
+
+	var b strings.Builder
+	b.WriteString(p.prefix)
+	for _, s := range slices.Backward(segments) {
+		switch {
+		case s.field != "":
+			b.WriteString("." + s.field)
+		case s.key.IsValid():
+			b.WriteString("[" + formatKey(s.key) + "]")
+		default:
+			b.WriteString("[" + strconv.Itoa(s.index) + "]")
+		}
+	}
+	return b.String()
+}
+
+// visit is a pair of references that are being compared, used to detect cycles
+type visit struct {
+	expected unsafe.Pointer
+	input    unsafe.Pointer
+	typ      reflect.Type
+}
+
+// differ walks two values in parallel, recording every leaf at which they
+// differ. Without options leaves are compared with the same semantics as reflect.DeepEqual.
+// References already being compared are assumed equal, so cyclic values terminate and
+// matching cycles compare as equal
+type differ struct {
+	cfg      compareConfig
+	diffs    []difference
+	visited  map[visit]bool
+	rejected map[reflect.Type]bool
+
+	// estimate is the number of differences extrapolated from the elements compared
+	// when the outermost slice or map was cut short by StopAfter
+	estimate int
+}
+
+// stopped reports whether StopAfter differences have been found
+func (d *differ) stopped() bool {
+	return d.cfg.maxDiffs > 0 && len(d.diffs) >= d.cfg.maxDiffs
+}
+
+// stopEstimate records the estimated number of differences after the first compared of total elements
+func (d *differ) stopEstimate(compared, total int) {
+	d.estimate = len(d.diffs) * total / compared
+}
+
+// stoppedNote describes where the comparison stopped, or returns an empty string if it did not
+func (d *differ) stoppedNote() string {
+	if !d.stopped() {
+		return ""
+	}
+	if d.estimate > len(d.diffs) {
+		return fmt.Sprintf(" ~ stopped after %v differences, about %v in total\n", len(d.diffs), d.estimate)
+	}
+	return fmt.Sprintf(" ~ stopped after %v differences\n", len(d.diffs))
+}
+
+// enter records that the references held by expected and input are being compared,
+// returning false if they already were
+func (d *differ) enter(expected, input reflect.Value) bool {
+	if d.visited == nil {
+		d.visited = make(map[visit]bool)
+	}
+
+	v := visit{expected: expected.UnsafePointer(), input: input.UnsafePointer(), typ: expected.Type()}
+	if d.visited[v] {
+		return false
+	}
+	d.visited[v] = true
+	return true
+}
+
+// diffValues returns the differences between expected and input, paths are prefixed with path
+func diffValues(path string, expected, input any, opts ...CompareOption) []difference {
+	return compareValues(path, expected, input, opts...).diffs
+}
+
+// compareValues is diffValues returning the differ, which also knows whether the comparison stopped early
+func compareValues(path string, expected, input any, opts ...CompareOption) *differ {
+	d := &differ{}
+	for _, opt := range opts {
+		opt(&d.cfg)
+	}
+	d.cfg.comparers = snapshotComparers()
+
+	// Values equal by reflect.DeepEqual are equal however the options relax the comparison, unless captures
+	// have to be filled, comparers may disagree or RejectUnexported fails equal values. reflect.DeepEqual
+	// is much faster than walking, which only has to find where values differ
+	if len(d.cfg.captures) == 0 && len(d.cfg.comparers) == 0 && d.cfg.unexported != RejectUnexported && reflect.DeepEqual(expected, input) {
+		return d
+	}
+	d.walk(&diffPath{prefix: path}, reflect.ValueOf(expected), reflect.ValueOf(input))
+	return d
+}
+
+// indirect follows non-nil pointers and interfaces until reaching a value that is neither.
+// A chain of pointers that leads back to itself is returned at the point the cycle closes
+func indirect(v reflect.Value) reflect.Value {
+	var seen map[unsafe.Pointer]bool
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && !v.IsNil() {
+		if v.Kind() == reflect.Pointer {
+			if seen[v.UnsafePointer()] {
+				return v
+			}
+			if seen == nil {
+				seen = make(map[unsafe.Pointer]bool)
+			}
+			seen[v.UnsafePointer()] = true
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// isNil reports whether v is invalid or a nil pointer or interface
+func isNil(v reflect.Value) bool {
+	return !v.IsValid() || ((v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil())
+}
+
+// formatKey prints a map key in a path. Paths are access expressions, so keys are printed as
+// formatValue does rather than as mismatched values
+func formatKey(k reflect.Value) string {
+	if iv, ok := interfaceOf(k); ok {
+		return formatValue(iv)
+	}
+	return fmt.Sprintf("%#v", k)
+}
+
+func formatReflect(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	if iv, ok := interfaceOf(v); ok && !(v.Kind() == reflect.Interface && v.IsNil()) {
+		return formatMismatch(iv)
+	}
+	if out, ok := formatInteger(v); ok {
+		return out
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+func (d *differ) report(path *diffPath, expected, input reflect.Value) {
+	d.diffs = append(d.diffs, difference{path: path.String(), expected: formatReflect(expected), input: formatReflect(input)})
+}
+
+// reportNil reports a slice or map that is nil where the other is not, noting when the other is empty
+// as the two print the same
+func (d *differ) reportNil(path *diffPath, expected, input reflect.Value) {
+	if expected.Len() == 0 && input.Len() == 0 {
+		if !d.cfg.nilEqualsEmpty {
+			d.explain(path, expected, input, fmt.Sprintf("one %v is nil and the other is empty, see EqualLoose", expected.Kind()))
+		}
+		return
+	}
+	d.report(path, expected, input)
+}
+
+// explain reports a difference with a note saying why the values are not equal
+func (d *differ) explain(path *diffPath, expected, input reflect.Value, note string) {
+	d.diffs = append(d.diffs, difference{path: path.String(), expected: formatReflect(expected), input: formatReflect(input), note: note})
+}
+
+func (d *differ) missing(path *diffPath, expected, input reflect.Value) {
+	diff := difference{path: path.String(), expected: missingValue, input: missingValue}
+	if expected.IsValid() {
+		diff.expected = formatReflect(expected)
+	}
+	if input.IsValid() {
+		diff.input = formatReflect(input)
+	}
+	d.diffs = append(d.diffs, diff)
+}
+
+// isWildcard reports whether the expected value v matches any input
+func (d *differ) isWildcard(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Type() == reflect.TypeFor[Wildcard]() {
+		return true
+	}
+
+	for _, placeholder := range d.cfg.wildcards {
+		if placeholder.IsValid() && placeholder.Type() == v.Type() {
+			exact := differ{cfg: compareConfig{comparers: d.cfg.comparers}}
+			exact.walk(&diffPath{}, placeholder, v)
+			if len(exact.diffs) == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// capturerFor returns the Capture used at the position of the expected value v, if any
+func (d *differ) capturerFor(v reflect.Value) (capturer, bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() && v.CanInterface() {
+		if c, ok := v.Elem().Interface().(capturer); ok {
+			return c, true
+		}
+	}
+
+	for _, registered := range d.cfg.captures {
+		if registered.placeholder.Type() == v.Type() {
+			exact := differ{cfg: compareConfig{comparers: d.cfg.comparers}}
+			exact.walk(&diffPath{}, registered.placeholder, v)
+			if len(exact.diffs) == 0 {
+				return registered.capture, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (d *differ) walk(path *diffPath, expected, input reflect.Value) {
+	if d.stopped() {
+		return
+	}
+	if d.isWildcard(expected) {
+		return
+	}
+	if c, ok := d.capturerFor(expected); ok {
+		if !c.capture(input) {
+			d.report(path, expected, input)
+		}
+		return
+	}
+
+	if d.cfg.followPointers {
+		expected, input = indirect(expected), indirect(input)
+		if isNil(expected) || isNil(input) {
+			if isNil(expected) != isNil(input) {
+				d.report(path, expected, input)
+			}
+			return
+		}
+		// The pointers followed are no longer visible to the cycle check below,
+		// values reached through them are addressable so use those addresses instead
+		if expected.CanAddr() && input.CanAddr() && expected.Type() == input.Type() {
+			if !d.enter(expected.Addr(), input.Addr()) {
+				return
+			}
+		}
+	}
+
+	if !expected.IsValid() || !input.IsValid() {
+		if expected.IsValid() != input.IsValid() {
+			d.report(path, expected, input)
+		}
+		return
+	}
+
+	if expected.Type() != input.Type() {
+		d.explain(path, expected, input, fmt.Sprintf("different types %v and %v", expected.Type(), input.Type()))
+		return
+	}
+
+	if equal, ok := d.comparer(expected.Type()); ok {
+		e, eok := interfaceOf(expected)
+		i, iok := interfaceOf(input)
+		if eok && iok {
+			if !equal(e, i) {
+				d.report(path, expected, input)
+			}
+			return
+		}
+	}
+
+	switch expected.Kind() {
+	case reflect.Array:
+		leaf := d.plainLeaf(expected.Type().Elem())
+		for i := range expected.Len() {
+			d.walkElement(path, i, leaf, expected.Index(i), input.Index(i))
+		}
+
+	case reflect.Slice:
+		if expected.IsNil() != input.IsNil() {
+			d.reportNil(path, expected, input)
+			return
+		}
+		if expected.Len() == input.Len() && expected.UnsafePointer() == input.UnsafePointer() {
+			return
+		}
+		if !d.enter(expected, input) {
+			return
+		}
+		leaf := d.plainLeaf(expected.Type().Elem())
+		d.walkElements(max(expected.Len(), input.Len()), nil, func(d *differ, i int) {
+			switch {
+			case i >= expected.Len():
+				d.missing(path.indexPath(i), reflect.Value{}, input.Index(i))
+			case i >= input.Len():
+				d.missing(path.indexPath(i), expected.Index(i), reflect.Value{})
+			default:
+				d.walkElement(path, i, leaf, expected.Index(i), input.Index(i))
+			}
+		})
+
+	case reflect.Map:
+		if expected.IsNil() != input.IsNil() {
+			d.reportNil(path, expected, input)
+			return
+		}
+		if expected.UnsafePointer() == input.UnsafePointer() {
+			return
+		}
+		if !d.enter(expected, input) {
+			return
+		}
+		keys := expected.MapKeys()
+		for _, k := range input.MapKeys() {
+			if !expected.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+		// Differences are reported in the printed order of their keys. Sorting every key up front is only
+		// needed when the order decides which differences are found, otherwise the keys of the entries that
+		// differ are sorted once they are known
+		ordered := d.cfg.maxDiffs > 0 || len(d.cfg.captures) > 0
+		var counts []int
+		if ordered {
+			sortKeys(keys)
+		} else {
+			counts = make([]int, len(keys))
+		}
+		before := len(d.diffs)
+		d.walkElements(len(keys), counts, func(d *differ, n int) {
+			keyPath := path.keyPath(keys[n])
+			ev, iv := expected.MapIndex(keys[n]), input.MapIndex(keys[n])
+			if !ev.IsValid() || !iv.IsValid() {
+				d.missing(keyPath, ev, iv)
+				return
+			}
+			d.walk(keyPath, ev, iv)
+		})
+		if !ordered && len(d.diffs) > before {
+			d.orderByKey(before, keys, counts)
+		}
+
+	case reflect.Struct:
+		if d.cfg.unexported == RejectUnexported && hasUnexportedFields(expected.Type()) {
+			// Each type is reported once, at the first path it was found
+			if d.rejected[expected.Type()] {
+				return
+			}
+			if d.rejected == nil {
+				d.rejected = make(map[reflect.Type]bool)
+			}
+			d.rejected[expected.Type()] = true
+			d.diffs = append(d.diffs, difference{
+				path:     path.String(),
+				expected: formatReflect(expected),
+				input:    formatReflect(input),
+				note:     fmt.Sprintf("type %v has unexported fields, choose CompareUnexported or IgnoreUnexported", expected.Type()),
+			})
+			return
+		}
+		for i := range expected.NumField() {
+			field := expected.Type().Field(i)
+			if !field.IsExported() && d.cfg.unexported == IgnoreUnexported {
+				continue
+			}
+			d.walk(path.fieldPath(field.Name), expected.Field(i), input.Field(i))
+		}
+
+	case reflect.Pointer:
+		if expected.UnsafePointer() == input.UnsafePointer() {
+			return
+		}
+		if expected.IsNil() || input.IsNil() {
+			d.report(path, expected, input)
+			return
+		}
+		if !d.enter(expected, input) {
+			return
+		}
+		d.walk(path, expected.Elem(), input.Elem())
+
+	case reflect.Interface:
+		if expected.IsNil() || input.IsNil() {
+			if expected.IsNil() != input.IsNil() {
+				d.report(path, expected, input)
+			}
+			return
+		}
+		d.walk(path, expected.Elem(), input.Elem())
+
+	case reflect.Func:
+		// Functions are only equal when both are nil
+		if d.cfg.ignoreFuncs || expected.IsNil() && input.IsNil() {
+			return
+		}
+		if !expected.IsNil() && !input.IsNil() {
+			d.explain(path, expected, input, "functions are never equal unless both are nil, see IgnoreFuncs")
+			return
+		}
+		d.report(path, expected, input)
+
+	case reflect.Chan:
+		switch {
+		case !d.cfg.chansByType:
+			if expected.Pointer() != input.Pointer() {
+				if expected.IsNil() || input.IsNil() {
+					d.report(path, expected, input)
+					return
+				}
+				d.explain(path, expected, input, "channels are compared by identity, see ChannelsByType")
+			}
+		case expected.IsNil() != input.IsNil():
+			d.report(path, expected, input)
+		case expected.Cap() != input.Cap():
+			d.explain(path, expected, input, fmt.Sprintf("channel capacities differ, %v and %v", expected.Cap(), input.Cap()))
+		}
+
+	default:
+		if !leafEqual(expected, input) {
+			d.report(path, expected, input)
+		}
+	}
+}
+
+// plainLeaf reports whether values of type t are numbers, strings or booleans that can be compared directly,
+// with no comparer, wildcard or capture registered for the type
+func (d *differ) plainLeaf(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+	default:
+		return false
+	}
+	if _, ok := d.comparer(t); ok {
+		return false
+	}
+	for _, placeholder := range d.cfg.wildcards {
+		if placeholder.IsValid() && placeholder.Type() == t {
+			return false
+		}
+	}
+	for _, registered := range d.cfg.captures {
+		if registered.placeholder.Type() == t {
+			return false
+		}
+	}
+	return true
+}
+
+// walkElement compares the i-th elements of a slice or array, directly if they are plain leaves
+func (d *differ) walkElement(path *diffPath, i int, leaf bool, expected, input reflect.Value) {
+	if !leaf {
+		d.walk(path.indexPath(i), expected, input)
+		return
+	}
+	if !d.stopped() && !leafEqual(expected, input) {
+		d.report(path.indexPath(i), expected, input)
+	}
+}
+
+// walkElements calls element for each of the n elements of a slice or map in order, stopping early as
+// configured by StopAfter. With Parallel, large collections are split between workers that each compare
+// a contiguous range of elements with their own differ, and the differences are merged in order.
+// If counts is not nil it is set to the number of differences found in each element
+func (d *differ) walkElements(n int, counts []int, element func(d *differ, i int)) {
+	walk := func(d *differ, i int) {
+		before := len(d.diffs)
+		element(d, i)
+		if counts != nil {
+			counts[i] = len(d.diffs) - before
+		}
+	}
+
+	workers := min(runtime.GOMAXPROCS(0), n/parallelMinElements)
+	if !d.cfg.parallel || len(d.cfg.captures) > 0 || workers < 2 {
+		for i := range n {
+			if d.stopped() {
+				d.stopEstimate(i, n)
+				return
+			}
+			walk(d, i)
+		}
+		if d.stopped() {
+			// Every element was compared, only the last may have been cut short
+			d.estimate = len(d.diffs)
+		}
+		return
+	}
+
+	// Workers compare sequentially, nested collections are not split again.
+	// Each may find as many differences as remain before StopAfter is reached
+	cfg := d.cfg
+	cfg.parallel = false
+	if cfg.maxDiffs > 0 {
+		cfg.maxDiffs -= len(d.diffs)
+	}
+	before := len(d.diffs)
+
+	chunks := make([]*differ, workers)
+	compared := make([]int, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		chunks[w] = &differ{cfg: cfg}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := w * n / workers; i < (w+1)*n/workers && !chunks[w].stopped(); i++ {
+				walk(chunks[w], i)
+				compared[w]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	found, total := 0, 0
+	for w, chunk := range chunks {
+		d.diffs = append(d.diffs, chunk.diffs...)
+		found += len(chunk.diffs)
+		total += compared[w]
+	}
+	if d.stopped() {
+		d.diffs = d.diffs[:d.cfg.maxDiffs]
+		d.estimate = before + found*n/total
+	}
+}
+
+// mapKey is a map key prepared for sorting, see compare
+type mapKey struct {
+	value   reflect.Value
+	printed string
+}
+
+func newMapKey(k reflect.Value) mapKey {
+	if k.Kind() == reflect.Interface && !k.IsNil() {
+		k = k.Elem()
+	}
+	return mapKey{value: k, printed: fmt.Sprintf("%#v", k)}
+}
+
+// compare orders keys of different kinds by kind, then integers, floats and strings by value, so that
+// 2 comes before 10, and any other keys by their printed form
+func (a mapKey) compare(b mapKey) int {
+	if c := cmp.Compare(a.value.Kind(), b.value.Kind()); c != 0 {
+		return c
+	}
+
+	c := 0
+	switch a.value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c = cmp.Compare(a.value.Int(), b.value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c = cmp.Compare(a.value.Uint(), b.value.Uint())
+	case reflect.Float32, reflect.Float64:
+		c = cmp.Compare(a.value.Float(), b.value.Float())
+	case reflect.String:
+		c = strings.Compare(a.value.String(), b.value.String())
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.printed, b.printed)
+}
+
+// sortKeys sorts map keys in the order differences at them are reported, see mapKey.compare
+func sortKeys(keys []reflect.Value) {
+	type sortable struct {
+		key mapKey
+		k   reflect.Value
+	}
+
+	sorted := make([]sortable, len(keys))
+	for i, k := range keys {
+		sorted[i] = sortable{key: newMapKey(k), k: k}
+	}
+	slices.SortFunc(sorted, func(a, b sortable) int {
+		return a.key.compare(b.key)
+	})
+	for i, s := range sorted {
+		keys[i] = s.k
+	}
+}
+
+// orderByKey puts the differences found since before in the order of the map keys they were found at,
+// counts[n] of them at keys[n], see sortKeys. Only the keys of entries that differ are sorted
+func (d *differ) orderByKey(before int, keys []reflect.Value, counts []int) {
+	type entry struct {
+		key   mapKey
+		diffs []difference
+	}
+
+	var entries []entry
+	start := before
+	for n, count := range counts {
+		if count > 0 {
+			entries = append(entries, entry{key: newMapKey(keys[n]), diffs: slices.Clone(d.diffs[start : start+count])})
+			start += count
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return a.key.compare(b.key)
+	})
+
+	d.diffs = d.diffs[:before]
+	for _, e := range entries {
+		d.diffs = append(d.diffs, e.diffs...)
+	}
+}
+
+// leafEqual compares two values of the same non-composite kind.
+// Unlike Interface, this works on values obtained through unexported fields
+func leafEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+
+	panic("assertions: unexpected kind " + a.Kind().String())
+}
+
+func formatDifferences(diffs []difference) string {
+	var b strings.Builder
+	for _, diff := range diffs {
+		path := diff.path
+		if path == "" {
+			path = "(value)"
+		}
+		fmt.Fprintf(&b, " ~ %v:\n   > expected: %v\n   < input:    %v\n", path, diff.expected, diff.input)
+		if diff.note != "" {
+			fmt.Fprintf(&b, "   ~ %v\n", diff.note)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,641 @@
+package assertions
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	type address struct {
+		Street string
+		Zip    string
+	}
+	type user struct {
+		Name    string
+		Address *address
+		tags    []string
+	}
+
+	cases := []struct {
+		name     string
+		expected any
+		input    any
+		diffs    []difference
+	}{
+		{
+			name:     "equal scalars",
+			expected: 1,
+			input:    1,
+			diffs:    nil,
+		},
+		{
+			name:     "scalar",
+			expected: 1,
+			input:    2,
+			diffs:    []difference{{path: "", expected: "1", input: "2"}},
+		},
+		{
+			name:     "mismatched types",
+			expected: 1,
+			input:    "1",
+			diffs:    []difference{{path: "", expected: "1", input: `"1"`, note: "different types int and string"}},
+		},
+		{
+			name:     "nan",
+			expected: math.NaN(),
+			input:    math.NaN(),
+			diffs:    []difference{{path: "", expected: "NaN", input: "NaN"}},
+		},
+		{
+			name: "nested leaf",
+			expected: map[string][]user{"users": {
+				{Name: "a", Address: &address{Street: "main", Zip: "12345"}},
+			}},
+			input: map[string][]user{"users": {
+				{Name: "a", Address: &address{Street: "main", Zip: "54321"}},
+			}},
+			diffs: []difference{{path: `["users"][0].Address.Zip`, expected: `"12345"`, input: `"54321"`}},
+		},
+		{
+			name:     "unexported field",
+			expected: user{tags: []string{"a"}},
+			input:    user{tags: []string{"b"}},
+			diffs:    []difference{{path: ".tags[0]", expected: `"a"`, input: `"b"`}},
+		},
+		{
+			name:     "slice lengths",
+			expected: []int{1, 2},
+			input:    []int{1, 2, 3},
+			diffs:    []difference{{path: "[2]", expected: missingValue, input: "3"}},
+		},
+		{
+			name:     "nil and empty slice",
+			expected: []int(nil),
+			input:    []int{},
+			diffs:    []difference{{path: "", expected: "[]int(nil)", input: "[]int{}", note: "one slice is nil and the other is empty, see EqualLoose"}},
+		},
+		{
+			name:     "map keys",
+			expected: map[string]int{"a": 1, "b": 2},
+			input:    map[string]int{"b": 2, "c": 3},
+			diffs: []difference{
+				{path: `["a"]`, expected: "1", input: missingValue},
+				{path: `["c"]`, expected: missingValue, input: "3"},
+			},
+		},
+		{
+			name:     "nil pointer",
+			expected: user{Address: &address{}},
+			input:    user{},
+			diffs:    []difference{{path: ".Address", expected: "&assertions.address{Street:\"\", Zip:\"\"}", input: "(*assertions.address)(nil)"}},
+		},
+		{
+			name:     "interface elements",
+			expected: []any{1, "a", nil},
+			input:    []any{1, "b", 2},
+			diffs: []difference{
+				{path: "[1]", expected: `"a"`, input: `"b"`},
+				{path: "[2]", expected: "interface {}(nil)", input: "2"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Equal(t, tc.diffs, diffValues("", tc.expected, tc.input))
+		})
+	}
+}
+
+func TestEqualNested(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected map[string][]int
+		input    map[string][]int
+		mustFail bool
+	}{
+		{name: "equal", expected: map[string][]int{"a": {1}}, input: map[string][]int{"a": {1}}, mustFail: false},
+		{name: "nested difference", expected: map[string][]int{"a": {1}}, input: map[string][]int{"a": {2}}, mustFail: true},
+		{name: "missing key", expected: map[string][]int{"a": {1}}, input: map[string][]int{}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualFollowPointers(t *testing.T) {
+	type fixture struct {
+		Name  *string
+		Value any
+	}
+
+	name := "x"
+	cases := []struct {
+		name     string
+		expected fixture
+		input    fixture
+		opts     []CompareOption
+		mustFail bool
+	}{
+		{
+			name:     "distinct pointers to equal values",
+			expected: fixture{Name: Ptr("x")},
+			input:    fixture{Name: &name},
+			mustFail: false,
+		},
+		{
+			name:     "pointer and value in interface",
+			expected: fixture{Value: "x"},
+			input:    fixture{Value: &name},
+			mustFail: true,
+		},
+		{
+			name:     "pointer and value in interface following pointers",
+			expected: fixture{Value: "x"},
+			input:    fixture{Value: &name},
+			opts:     []CompareOption{FollowPointers()},
+			mustFail: false,
+		},
+		{
+			name:     "double pointer following pointers",
+			expected: fixture{Value: Ptr(Ptr("x"))},
+			input:    fixture{Value: &name},
+			opts:     []CompareOption{FollowPointers()},
+			mustFail: false,
+		},
+		{
+			name:     "nil pointer and nil interface following pointers",
+			expected: fixture{Value: nil},
+			input:    fixture{Value: (*string)(nil)},
+			opts:     []CompareOption{FollowPointers()},
+			mustFail: false,
+		},
+		{
+			name:     "different values following pointers",
+			expected: fixture{Value: Ptr("y")},
+			input:    fixture{Value: &name},
+			opts:     []CompareOption{FollowPointers()},
+			mustFail: true,
+		},
+		{
+			name:     "nil and non-nil following pointers",
+			expected: fixture{Name: nil},
+			input:    fixture{Name: &name},
+			opts:     []CompareOption{FollowPointers()},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualCycles(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+
+	ring := func(values ...int) *node {
+		head := &node{Value: values[0]}
+		tail := head
+		for _, v := range values[1:] {
+			tail.Next = &node{Value: v}
+			tail = tail.Next
+		}
+		tail.Next = head
+		return head
+	}
+
+	selfMap := func(a int) map[string]any {
+		m := map[string]any{"a": a}
+		m["self"] = m
+		return m
+	}
+
+	cases := []struct {
+		name     string
+		expected any
+		input    any
+		opts     []CompareOption
+		mustFail bool
+	}{
+		{name: "self loop", expected: ring(1), input: ring(1), mustFail: false},
+		{name: "matching rings", expected: ring(1, 2, 3), input: ring(1, 2, 3), mustFail: false},
+		{name: "differing rings", expected: ring(1, 2, 3), input: ring(1, 2, 4), mustFail: true},
+		{name: "self referencing maps", expected: selfMap(1), input: selfMap(1), mustFail: false},
+		{name: "differing self referencing maps", expected: selfMap(1), input: selfMap(2), mustFail: true},
+		{name: "following pointers", expected: ring(1, 2), input: ring(1, 2), opts: []CompareOption{FollowPointers()}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualWildcards(t *testing.T) {
+	type response struct {
+		ID      string
+		Created int64
+		Name    string
+		Meta    map[string]any
+		Items   []any
+	}
+
+	input := response{
+		ID:      "9f86d081",
+		Created: 1700000000,
+		Name:    "x",
+		Meta:    map[string]any{"etag": "abc", "count": 2},
+		Items:   []any{1, "two", 3.0},
+	}
+
+	cases := []struct {
+		name     string
+		expected response
+		opts     []CompareOption
+		mustFail bool
+	}{
+		{
+			name:     "anything in interfaces",
+			expected: response{ID: "9f86d081", Created: 1700000000, Name: "x", Meta: map[string]any{"etag": Anything, "count": 2}, Items: []any{1, Anything, 3.0}},
+			mustFail: false,
+		},
+		{
+			name:     "anything does not match missing keys",
+			expected: response{ID: "9f86d081", Created: 1700000000, Name: "x", Meta: map[string]any{"etag": Anything, "count": 2, "next": Anything}, Items: []any{1, "two", 3.0}},
+			mustFail: true,
+		},
+		{
+			name:     "registered placeholders",
+			expected: response{ID: "<any>", Created: -1, Name: "x", Meta: map[string]any{"etag": "<any>", "count": 2}, Items: []any{1, "two", 3.0}},
+			opts:     []CompareOption{WithWildcard("<any>"), WithWildcard(int64(-1))},
+			mustFail: false,
+		},
+		{
+			name:     "placeholder must match type",
+			expected: response{ID: "<any>", Created: -1, Name: "x", Meta: map[string]any{"etag": "abc", "count": 2}, Items: []any{1, "two", 3.0}},
+			opts:     []CompareOption{WithWildcard("<any>"), WithWildcard(-1)},
+			mustFail: true,
+		},
+		{
+			name:     "unregistered placeholder",
+			expected: response{ID: "<any>", Created: 1700000000, Name: "x", Meta: map[string]any{"etag": "abc", "count": 2}, Items: []any{1, "two", 3.0}},
+			mustFail: true,
+		},
+		{
+			name:     "other fields still compared",
+			expected: response{ID: "<any>", Created: 1700000000, Name: "y", Meta: map[string]any{"etag": "abc", "count": 2}, Items: []any{1, "two", 3.0}},
+			opts:     []CompareOption{WithWildcard("<any>")},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualCapture(t *testing.T) {
+	type created struct {
+		ID   string
+		Name string
+	}
+	type fetched struct {
+		Item  created
+		Extra map[string]any
+	}
+
+	t.Run("capture and echo", func(t *testing.T) {
+		id := NewCapture[string]()
+
+		tb := NewTester(t, false)
+		Equal(tb, map[string]any{"id": id, "name": "x"}, map[string]any{"id": "9f86d081", "name": "x"})
+		tb.AssertExpectation()
+
+		Equal(t, true, id.Captured())
+		Equal(t, "9f86d081", id.Value())
+
+		tb = NewTester(t, false)
+		Equal(tb, []any{id, id}, []any{"9f86d081", "9f86d081"})
+		tb.AssertExpectation()
+
+		tb = NewTester(t, true)
+		Equal(tb, []any{id}, []any{"other"})
+		tb.AssertExpectation()
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		id := NewCapture[string]()
+
+		tb := NewTester(t, true)
+		Equal(tb, []any{id}, []any{42})
+		tb.AssertExpectation()
+		Equal(t, false, id.Captured())
+	})
+
+	t.Run("nil into nilable", func(t *testing.T) {
+		err := NewCapture[error]()
+
+		tb := NewTester(t, false)
+		Equal(tb, []any{err}, []any{nil})
+		tb.AssertExpectation()
+		Equal(t, true, err.Captured())
+	})
+
+	t.Run("registered placeholder", func(t *testing.T) {
+		id := NewCapture[string]()
+
+		tb := NewTester(t, false)
+		Equal(tb, fetched{Item: created{ID: "<id>", Name: "x"}}, fetched{Item: created{ID: "abc", Name: "x"}}, WithCapture("<id>", id))
+		tb.AssertExpectation()
+		Equal(t, "abc", id.Value())
+
+		tb = NewTester(t, false)
+		Equal(tb, fetched{Item: created{ID: "<id>"}, Extra: map[string]any{"parent": id}}, fetched{Item: created{ID: "abc"}, Extra: map[string]any{"parent": "abc"}}, WithCapture("<id>", id))
+		tb.AssertExpectation()
+	})
+}
+
+// foldedString compares equal to any string with the same case folding, once its comparer is registered
+type foldedString string
+
+func TestEqualRegisteredComparer(t *testing.T) {
+	RegisterComparer(func(expected, input foldedString) bool {
+		return strings.EqualFold(string(expected), string(input))
+	})
+
+	type user struct {
+		Email foldedString
+		name  foldedString
+	}
+
+	cases := []struct {
+		name     string
+		expected any
+		input    any
+		mustFail bool
+	}{
+		{name: "equal", expected: foldedString("a@example.com"), input: foldedString("A@Example.com"), mustFail: false},
+		{name: "different", expected: foldedString("a@example.com"), input: foldedString("b@example.com"), mustFail: true},
+		{name: "field", expected: user{Email: "a@example.com"}, input: user{Email: "A@EXAMPLE.COM"}, mustFail: false},
+		{name: "unexported field", expected: &user{name: "alice"}, input: &user{name: "ALICE"}, mustFail: false},
+		{name: "slice", expected: []foldedString{"a", "b"}, input: []foldedString{"A", "c"}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestStopAfter(t *testing.T) {
+	expected := make([]int, 1000)
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i % 2
+	}
+
+	d := compareValues("", expected, input, StopAfter(3))
+	Equal(t, 3, len(d.diffs))
+	Equal(t, " ~ stopped after 3 differences, about 500 in total\n", d.stoppedNote())
+
+	nested := [][]int{{0, 0, 0, 0}, {0, 0}}
+	d = compareValues("", nested, [][]int{{1, 1, 1, 1}, {1, 1}}, StopAfter(2))
+	Equal(t, []string{"[0][0]", "[0][1]"}, []string{d.diffs[0].path, d.diffs[1].path})
+	Equal(t, " ~ stopped after 2 differences, about 4 in total\n", d.stoppedNote())
+
+	d = compareValues("", expected, input)
+	Equal(t, 500, len(d.diffs))
+	Equal(t, "", d.stoppedNote())
+
+	d = compareValues("", map[string]int{"a": 1, "b": 2}, map[string]int{"a": 0, "b": 0}, StopAfter(2))
+	Equal(t, " ~ stopped after 2 differences\n", d.stoppedNote())
+}
+
+func TestEqualStopAfterMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	Equal(tb, []int{0, 0, 0, 0}, []int{1, 1, 1, 1}, StopAfter(1))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Values are not equal\n ~ [0]:\n   > expected: 0\n   < input:    1\n ~ stopped after 1 differences, about 4 in total\n"}, tb.logs)
+}
+
+func TestParallel(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	type row struct {
+		ID   int
+		Tags []string
+	}
+	expected := make([]row, 20000)
+	input := make([]row, 20000)
+	for i := range expected {
+		expected[i] = row{ID: i, Tags: []string{"a"}}
+		input[i] = row{ID: i, Tags: []string{"a"}}
+		if i%1000 == 7 {
+			input[i].Tags = []string{"b"}
+		}
+	}
+
+	sequential := compareValues("", expected, input)
+	parallel := compareValues("", expected, input, Parallel())
+	Equal(t, 20, len(parallel.diffs))
+	Equal(t, sequential.diffs, parallel.diffs)
+
+	stopped := compareValues("", expected, input, Parallel(), StopAfter(5))
+	Equal(t, sequential.diffs[:5], stopped.diffs)
+	Equal(t, true, strings.HasPrefix(stopped.stoppedNote(), " ~ stopped after 5 differences"))
+
+	m := make(map[int]int, 10000)
+	for i := range 10000 {
+		m[i] = i
+	}
+	other := maps.Clone(m)
+	other[1234] = 0
+	Equal(t, []difference{{path: "[1234]", expected: "1234 (0x4d2)", input: "0"}}, compareValues("", m, other, Parallel()).diffs)
+
+	tb := NewTester(t, false)
+	Equal(tb, expected, Clone(expected), Parallel())
+	tb.AssertExpectation()
+}
+
+func TestEqualUnexportedPolicy(t *testing.T) {
+	type counter struct {
+		Name string
+		mu   sync.Mutex
+		hits int
+	}
+
+	cases := []struct {
+		name     string
+		expected *counter
+		input    *counter
+		opts     []CompareOption
+		mustFail bool
+	}{
+		{name: "compare equal", expected: &counter{Name: "a", hits: 1}, input: &counter{Name: "a", hits: 1}, mustFail: false},
+		{name: "compare different", expected: &counter{Name: "a", hits: 1}, input: &counter{Name: "a", hits: 2}, mustFail: true},
+		{name: "ignore different", expected: &counter{Name: "a", hits: 1}, input: &counter{Name: "a", hits: 2}, opts: []CompareOption{WithUnexported(IgnoreUnexported)}, mustFail: false},
+		{name: "ignore exported difference", expected: &counter{Name: "a"}, input: &counter{Name: "b"}, opts: []CompareOption{WithUnexported(IgnoreUnexported)}, mustFail: true},
+		{name: "reject equal", expected: &counter{Name: "a"}, input: &counter{Name: "a"}, opts: []CompareOption{WithUnexported(RejectUnexported)}, mustFail: true},
+		{name: "reject exported only", expected: &counter{Name: "a"}, input: &counter{Name: "a"}, opts: []CompareOption{WithUnexported(IgnoreUnexported), WithUnexported(RejectUnexported), WithUnexported(CompareUnexported)}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualRejectUnexportedMessage(t *testing.T) {
+	type secret struct {
+		value int
+	}
+	type wrapper struct {
+		Items []secret
+	}
+
+	diffs := diffValues("", wrapper{Items: []secret{{1}, {2}}}, wrapper{Items: []secret{{1}, {2}}}, WithUnexported(RejectUnexported))
+	Equal(t, 1, len(diffs))
+	Equal(t, ".Items[0]", diffs[0].path)
+	Equal(t, " ~ .Items[0]:\n   > expected: assertions.secret{value:1}\n   < input:    assertions.secret{value:1}\n   ~ type assertions.secret has unexported fields, choose CompareUnexported or IgnoreUnexported\n", formatDifferences(diffs))
+}
+
+func TestEqualFuncsAndChannels(t *testing.T) {
+	type handler struct {
+		Name     string
+		OnClose  func()
+		Requests chan int
+	}
+	requests := make(chan int, 4)
+	onClose := func() {}
+
+	cases := []struct {
+		name     string
+		expected handler
+		input    handler
+		opts     []CompareOption
+		mustFail bool
+	}{
+		{name: "nil funcs", expected: handler{Name: "a"}, input: handler{Name: "a"}, mustFail: false},
+		{name: "same func", expected: handler{OnClose: onClose}, input: handler{OnClose: onClose}, mustFail: true},
+		{name: "ignored funcs", expected: handler{OnClose: onClose}, input: handler{OnClose: func() {}}, opts: []CompareOption{IgnoreFuncs()}, mustFail: false},
+		{name: "same channel", expected: handler{Requests: requests}, input: handler{Requests: requests}, mustFail: false},
+		{name: "different channels", expected: handler{Requests: requests}, input: handler{Requests: make(chan int, 4)}, mustFail: true},
+		{name: "channels by type", expected: handler{Requests: requests}, input: handler{Requests: make(chan int, 4)}, opts: []CompareOption{ChannelsByType()}, mustFail: false},
+		{name: "channels by type different capacity", expected: handler{Requests: requests}, input: handler{Requests: make(chan int)}, opts: []CompareOption{ChannelsByType()}, mustFail: true},
+		{name: "channels by type nil", expected: handler{Requests: requests}, input: handler{}, opts: []CompareOption{ChannelsByType()}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Equal(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualFuncsAndChannelsNotes(t *testing.T) {
+	type handler struct {
+		OnClose  func()
+		Requests chan int
+	}
+
+	diffs := diffValues("", handler{OnClose: func() {}, Requests: make(chan int)}, handler{OnClose: func() {}, Requests: make(chan int)})
+	Equal(t, []string{
+		"functions are never equal unless both are nil, see IgnoreFuncs",
+		"channels are compared by identity, see ChannelsByType",
+	}, []string{diffs[0].note, diffs[1].note})
+
+	diffs = diffValues("", handler{Requests: make(chan int, 1)}, handler{Requests: make(chan int, 2)}, ChannelsByType())
+	Equal(t, "channel capacities differ, 1 and 2", diffs[0].note)
+}
+
+func TestDiffMapKeyOrder(t *testing.T) {
+	expected, input := map[string]int{}, map[string]int{}
+	var paths []string
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		expected[k], input[k] = 1, 2
+		paths = append(paths, fmt.Sprintf("[%q]", k))
+	}
+	expected["same"], input["same"] = 1, 1
+
+	for range 10 {
+		var got []string
+		for _, diff := range diffValues("", expected, input) {
+			got = append(got, diff.path)
+		}
+		Equal(t, paths, got)
+	}
+}
+
+func TestDiffMapIntegerKeyOrder(t *testing.T) {
+	expected, input := map[int]int{}, map[int]int{}
+	for _, k := range []int{10, 2, -3, 1, 100} {
+		expected[k], input[k] = 1, 2
+	}
+	paths := []string{"[-3]", "[1]", "[2]", "[10]", "[100]"}
+
+	for _, opts := range [][]CompareOption{nil, {StopAfter(10)}} {
+		var got []string
+		for _, diff := range diffValues("", expected, input, opts...) {
+			got = append(got, diff.path)
+		}
+		Equal(t, paths, got)
+	}
+
+	keys := reflect.ValueOf(map[any]bool{"b": true, 10: true, 2: true, "a": true}).MapKeys()
+	sortKeys(keys)
+	var sorted []any
+	for _, k := range keys {
+		sorted = append(sorted, k.Interface())
+	}
+	Equal(t, []any{2, 10, "a", "b"}, sorted)
+}
+
+func TestDiffPath(t *testing.T) {
+	root := &diffPath{prefix: "value"}
+	Equal(t, "value", root.String())
+	Equal(t, `value.Items[2]["id"].Name`, root.fieldPath("Items").indexPath(2).keyPath(reflect.ValueOf("id")).fieldPath("Name").String())
+	Equal(t, "value[10][4096]", root.keyPath(reflect.ValueOf(10)).keyPath(reflect.ValueOf(4096)).String())
+}
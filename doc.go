@@ -0,0 +1,212 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Doc is a loosely typed document such as decoded JSON or YAML, with assertions on the values at paths.
+// Paths are object keys separated by dots with array indices in brackets, e.g. "items[2].name".
+// The empty path refers to the document itself
+type Doc map[string]any
+
+// JSONDoc decodes data as a JSON object, failing the test if it is not one
+func JSONDoc(tb testing.TB, data []byte) Doc {
+	const failureFormat = "Document is not a JSON object\n > error: %v\n"
+
+	var doc Doc
+	if err := decodeJSONFixture(data, &doc); err != nil {
+		errorfNow(tb, failureFormat, err)
+		return nil
+	}
+	return doc
+}
+
+// docSegment is an object key or, when key is empty, an array index
+type docSegment struct {
+	key   string
+	index int
+}
+
+func parseDocPath(path string) ([]docSegment, error) {
+	var segments []docSegment
+	for _, part := range strings.Split(path, ".") {
+		if part == "" {
+			if path == "" {
+				break
+			}
+			return nil, fmt.Errorf("empty key in path %q", path)
+		}
+
+		key, rest, _ := strings.Cut(part, "[")
+		if key != "" {
+			segments = append(segments, docSegment{key: key})
+		}
+		if rest == "" {
+			continue
+		}
+
+		for _, index := range strings.Split(strings.TrimSuffix("["+rest, "]"), "]") {
+			n, err := strconv.Atoi(strings.TrimPrefix(index, "["))
+			if !strings.HasPrefix(index, "[") || err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid index %q in path %q", index+"]", path)
+			}
+			segments = append(segments, docSegment{index: n})
+		}
+	}
+	return segments, nil
+}
+
+// lookup returns the value at path. When the value is absent, the returned string describes the deepest
+// part of the path that exists and why the rest could not be followed
+func (d Doc) lookup(path string) (any, bool, string, error) {
+	segments, err := parseDocPath(path)
+	if err != nil {
+		return nil, false, "", err
+	}
+
+	var current any = map[string]any(d)
+	var walked strings.Builder
+	for _, s := range segments {
+		at := walked.String()
+		if at == "" {
+			at = "(document)"
+		}
+
+		switch v := current.(type) {
+		case map[string]any:
+			if s.key == "" {
+				return nil, false, fmt.Sprintf("%v is an object, not an array", at), nil
+			}
+			next, ok := v[s.key]
+			if !ok {
+				return nil, false, fmt.Sprintf("%v has no key %q", at, s.key), nil
+			}
+			current = next
+		case []any:
+			if s.key != "" {
+				return nil, false, fmt.Sprintf("%v is an array, not an object", at), nil
+			}
+			if s.index >= len(v) {
+				return nil, false, fmt.Sprintf("%v has %v elements, no index %v", at, len(v), s.index), nil
+			}
+			current = v[s.index]
+		default:
+			return nil, false, fmt.Sprintf("%v is %v, not an object or array", at, formatValue(current)), nil
+		}
+
+		if s.key != "" {
+			if walked.Len() > 0 {
+				walked.WriteString(".")
+			}
+			walked.WriteString(s.key)
+		} else {
+			fmt.Fprintf(&walked, "[%v]", s.index)
+		}
+	}
+	return current, true, "", nil
+}
+
+// value returns the value at path, failing the test when the path is invalid or absent
+func (d Doc) value(tb testing.TB, path string) (any, bool) {
+	const invalidFormat = "Invalid document path\n > error: %v\n"
+	const missingFormat = "Document has no value at %v\n ~ %v\n"
+
+	v, ok, reason, err := d.lookup(path)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return nil, false
+	}
+	if !ok {
+		errorfNow(tb, missingFormat, path, reason)
+		return nil, false
+	}
+	return v, true
+}
+
+// AssertEqual asserts that the value at path equals expected, compared as by Equal.
+// JSON numbers decode as float64, so expected numbers must be float64 as well
+func (d Doc) AssertEqual(tb testing.TB, path string, expected any) {
+	const failureFormat = "Document value at %v is not equal\n%v"
+
+	summary.recordAssertion()
+
+	v, ok := d.value(tb, path)
+	if !ok {
+		return
+	}
+
+	if diffs := diffValues("", expected, v); len(diffs) > 0 {
+		errorfNow(tb, failureFormat, path, formatDifferences(diffs))
+		return
+	}
+}
+
+// AssertString asserts that the value at path is the string expected
+func (d Doc) AssertString(tb testing.TB, path string, expected string) {
+	const typeFormat = "Document value at %v is not a string\n < input: %v\n"
+	const failureFormat = "Document value at %v is not equal\n > expected: %q\n < input:    %q\n"
+
+	summary.recordAssertion()
+
+	v, ok := d.value(tb, path)
+	if !ok {
+		return
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		errorfNow(tb, typeFormat, path, formatValue(v))
+		return
+	}
+	if s != expected {
+		errorfNow(tb, failureFormat, path, expected, s)
+		return
+	}
+}
+
+// AssertLen asserts that the array, object or string at path has length expected
+func (d Doc) AssertLen(tb testing.TB, path string, expected int) {
+	const typeFormat = "Document value at %v has no length\n < input: %v\n"
+	const failureFormat = "Document value at %v has the wrong length\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	v, ok := d.value(tb, path)
+	if !ok {
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+	default:
+		errorfNow(tb, typeFormat, path, formatValue(v))
+		return
+	}
+	if rv.Len() != expected {
+		errorfNow(tb, failureFormat, path, expected, rv.Len())
+		return
+	}
+}
+
+// AssertAbsent asserts that there is no value at path. A key holding null is present
+func (d Doc) AssertAbsent(tb testing.TB, path string) {
+	const invalidFormat = "Invalid document path\n > error: %v\n"
+	const failureFormat = "Document has a value at %v\n < input: %v\n"
+
+	summary.recordAssertion()
+
+	v, ok, _, err := d.lookup(path)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	if ok {
+		errorfNow(tb, failureFormat, path, formatValue(v))
+		return
+	}
+}
@@ -0,0 +1,73 @@
+package assertions
+
+import "testing"
+
+const docPayload = `{
+	"id": "ord_1",
+	"total": 42.5,
+	"items": [
+		{"sku": "a", "tags": ["new"]},
+		{"sku": "b", "tags": []}
+	],
+	"customer": {"name": "alice", "email": null}
+}`
+
+func TestDoc(t *testing.T) {
+	doc := JSONDoc(t, []byte(docPayload))
+
+	cases := []struct {
+		name     string
+		check    func(tb testing.TB)
+		mustFail bool
+	}{
+		{name: "string", check: func(tb testing.TB) { doc.AssertString(tb, "customer.name", "alice") }, mustFail: false},
+		{name: "string in array", check: func(tb testing.TB) { doc.AssertString(tb, "items[1].sku", "b") }, mustFail: false},
+		{name: "nested index", check: func(tb testing.TB) { doc.AssertString(tb, "items[0].tags[0]", "new") }, mustFail: false},
+		{name: "different string", check: func(tb testing.TB) { doc.AssertString(tb, "id", "ord_2") }, mustFail: true},
+		{name: "not a string", check: func(tb testing.TB) { doc.AssertString(tb, "total", "42.5") }, mustFail: true},
+		{name: "missing key", check: func(tb testing.TB) { doc.AssertString(tb, "customer.phone", "") }, mustFail: true},
+		{name: "index out of range", check: func(tb testing.TB) { doc.AssertString(tb, "items[2].sku", "c") }, mustFail: true},
+		{name: "index into object", check: func(tb testing.TB) { doc.AssertString(tb, "customer[0]", "") }, mustFail: true},
+		{name: "invalid path", check: func(tb testing.TB) { doc.AssertString(tb, "items[x]", "") }, mustFail: true},
+		{name: "equal", check: func(tb testing.TB) { doc.AssertEqual(tb, "total", 42.5) }, mustFail: false},
+		{name: "equal object", check: func(tb testing.TB) { doc.AssertEqual(tb, "items[1]", map[string]any{"sku": "b", "tags": []any{}}) }, mustFail: false},
+		{name: "not equal", check: func(tb testing.TB) { doc.AssertEqual(tb, "total", 42) }, mustFail: true},
+		{name: "len array", check: func(tb testing.TB) { doc.AssertLen(tb, "items", 2) }, mustFail: false},
+		{name: "len object", check: func(tb testing.TB) { doc.AssertLen(tb, "customer", 2) }, mustFail: false},
+		{name: "len document", check: func(tb testing.TB) { doc.AssertLen(tb, "", 4) }, mustFail: false},
+		{name: "wrong len", check: func(tb testing.TB) { doc.AssertLen(tb, "items[1].tags", 1) }, mustFail: true},
+		{name: "len of number", check: func(tb testing.TB) { doc.AssertLen(tb, "total", 1) }, mustFail: true},
+		{name: "absent", check: func(tb testing.TB) { doc.AssertAbsent(tb, "customer.phone") }, mustFail: false},
+		{name: "absent below a leaf", check: func(tb testing.TB) { doc.AssertAbsent(tb, "id.value") }, mustFail: false},
+		{name: "null is present", check: func(tb testing.TB) { doc.AssertAbsent(tb, "customer.email") }, mustFail: true},
+		{name: "present", check: func(tb testing.TB) { doc.AssertAbsent(tb, "items[0]") }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.check(tb)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestJSONDocInvalid(t *testing.T) {
+	tb := NewTester(t, true)
+	JSONDoc(tb, []byte(`[1, 2]`))
+	tb.AssertExpectation()
+}
+
+func TestParseDocPath(t *testing.T) {
+	segments, err := parseDocPath("a.b[2][0].c")
+	NoError(t, err)
+	Equal(t, []docSegment{{key: "a"}, {key: "b"}, {index: 2}, {index: 0}, {key: "c"}}, segments)
+
+	segments, err = parseDocPath("")
+	NoError(t, err)
+	Equal(t, 0, len(segments))
+
+	_, err = parseDocPath("a..b")
+	Error(t, err)
+}
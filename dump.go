@@ -0,0 +1,113 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// DumpDirEnv names the environment variable selecting the directory large failing values are written to,
+// e.g. testdata/failures. When unset a directory under os.TempDir is used
+const DumpDirEnv = "ASSERTIONS_DUMP_DIR"
+
+const dumpSummaryLimit = 5
+const dumpLeafLimit = 80
+
+var dumpThreshold atomic.Int64
+
+// SetDumpThreshold makes failing assertions write expected and input to files instead of the test log
+// when either printed value is larger than n bytes. The log then contains the file paths and a short
+// summary of the differences. A threshold of 0, the default, disables dumping
+func SetDumpThreshold(n int) {
+	dumpThreshold.Store(int64(n))
+}
+
+// dumpContents renders v for a dump file, strings and byte slices are written verbatim
+func dumpContents(v any) []byte {
+	switch b := v.(type) {
+	case []byte:
+		return b
+	case string:
+		return []byte(b)
+	}
+	return []byte(formatValue(v))
+}
+
+// dumpEnabled reports whether a dump threshold is set, values should only be rendered for dumping when it is
+func dumpEnabled() bool {
+	return dumpThreshold.Load() > 0
+}
+
+// shouldDump reports whether either value exceeds the dump threshold
+func shouldDump(expected, input []byte) bool {
+	threshold := dumpThreshold.Load()
+	return threshold > 0 && (int64(len(expected)) > threshold || int64(len(input)) > threshold)
+}
+
+func dumpDir() string {
+	if dir := os.Getenv(DumpDirEnv); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "assertions-failures")
+}
+
+// writeDump writes contents to a new file in the dump directory named after the test and returns its path
+func writeDump(tb testing.TB, suffix string, contents []byte) (string, error) {
+	dir := dumpDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	name := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(tb.Name())
+	f, err := os.CreateTemp(dir, name+"-*-"+suffix)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(contents); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + fmt.Sprintf("... (%v bytes)", len(s))
+}
+
+// dumpFailure writes both sides of a failed comparison to files and fails the test with their paths
+// and a summary of the first few differences
+func dumpFailure(tb testing.TB, header string, expected, input []byte, diffs []difference) {
+	const failureFormat = "%v, values were written to files\n > expected: %v\n < input:    %v\n%v differences%v\n%v"
+	const dumpErrorFormat = "%v, values could not be written to files\n > error: %v\n"
+
+	expectedPath, err := writeDump(tb, "expected", expected)
+	if err != nil {
+		errorfNow(tb, dumpErrorFormat, header, err)
+		return
+	}
+	inputPath, err := writeDump(tb, "input", input)
+	if err != nil {
+		errorfNow(tb, dumpErrorFormat, header, err)
+		return
+	}
+
+	shown := diffs
+	more := ""
+	if len(shown) > dumpSummaryLimit {
+		shown = shown[:dumpSummaryLimit]
+		more = fmt.Sprintf(", showing the first %v", dumpSummaryLimit)
+	}
+	truncated := make([]difference, len(shown))
+	for i, d := range shown {
+		truncated[i] = difference{path: d.path, expected: truncate(d.expected, dumpLeafLimit), input: truncate(d.input, dumpLeafLimit), note: d.note}
+	}
+
+	errorfNow(tb, failureFormat, header, expectedPath, inputPath, len(diffs), more, formatDifferences(truncated))
+}
@@ -0,0 +1,60 @@
+package assertions
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDumpLargeValues(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DumpDirEnv, dir)
+
+	SetDumpThreshold(64)
+	defer SetDumpThreshold(0)
+
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		files    int
+		mustFail bool
+	}{
+		{name: "small values", expected: "a", input: "b", files: 0, mustFail: true},
+		{name: "large equal values", expected: strings.Repeat("a", 100), input: strings.Repeat("a", 100), files: 0, mustFail: false},
+		{name: "large values", expected: strings.Repeat("a", 100), input: strings.Repeat("b", 100), files: 2, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before, err := os.ReadDir(dir)
+			NoError(t, err)
+
+			tb := NewTester(t, tc.mustFail)
+			Equal(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+
+			after, err := os.ReadDir(dir)
+			NoError(t, err)
+			Equal(t, tc.files, len(after)-len(before))
+		})
+	}
+}
+
+func TestDumpContents(t *testing.T) {
+	Equal(t, []byte("raw"), dumpContents([]byte("raw")))
+	Equal(t, []byte("raw"), dumpContents("raw"))
+	Equal(t, []byte("[]int{1, 2}"), dumpContents([]int{1, 2}))
+}
+
+func TestWriteDump(t *testing.T) {
+	t.Setenv(DumpDirEnv, t.TempDir())
+
+	path, err := writeDump(t, "expected", []byte("contents"))
+	NoError(t, err)
+
+	contents, err := os.ReadFile(path)
+	NoError(t, err)
+	Equal(t, "contents", string(contents))
+	Equal(t, true, strings.HasSuffix(path, "-expected"))
+}
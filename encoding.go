@@ -0,0 +1,82 @@
+package assertions
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Text is a string or byte slice
+type Text interface {
+	~string | ~[]byte
+}
+
+// firstInvalidUTF8 returns the offset of the first byte that does not start a valid UTF-8 sequence, or -1
+func firstInvalidUTF8(b []byte) int {
+	for offset := 0; offset < len(b); {
+		r, size := utf8.DecodeRune(b[offset:])
+		if r == utf8.RuneError && size <= 1 {
+			return offset
+		}
+		offset += size
+	}
+	return -1
+}
+
+// firstRune returns the offset and length of the first rune for which reject returns true, or -1
+func firstRune(b []byte, reject func(rune) bool) (int, int) {
+	for offset := 0; offset < len(b); {
+		r, size := utf8.DecodeRune(b[offset:])
+		if reject(r) {
+			return offset, size
+		}
+		offset += size
+	}
+	return -1, 0
+}
+
+// ValidUTF8 asserts that input is valid UTF-8, reporting the byte offset of the first invalid sequence
+func ValidUTF8[T Text](tb testing.TB, input T) {
+	const failureFormat = "invalid UTF-8\n > offset: %v\n > byte:   0x%02x\n"
+
+	summary.recordAssertion()
+
+	b := []byte(input)
+	if offset := firstInvalidUTF8(b); offset >= 0 {
+		errorfNow(tb, failureFormat, offset, b[offset])
+		return
+	}
+}
+
+// ASCIIOnly asserts that every byte of input is 7-bit ASCII, reporting the byte offset of the first that is not
+func ASCIIOnly[T Text](tb testing.TB, input T) {
+	const failureFormat = "non-ASCII byte\n > offset: %v\n > byte:   0x%02x\n"
+
+	summary.recordAssertion()
+
+	b := []byte(input)
+	for offset, c := range b {
+		if c > unicode.MaxASCII {
+			errorfNow(tb, failureFormat, offset, c)
+			return
+		}
+	}
+}
+
+// NoControlChars asserts that input contains no control characters other than tab, newline and carriage return.
+// The byte offset of the first control character is reported
+func NoControlChars[T Text](tb testing.TB, input T) {
+	const failureFormat = "control character\n > offset: %v\n > bytes:  % x\n > rune:   %U\n"
+
+	summary.recordAssertion()
+
+	b := []byte(input)
+	offset, size := firstRune(b, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r'
+	})
+	if offset >= 0 {
+		r, _ := utf8.DecodeRune(b[offset:])
+		errorfNow(tb, failureFormat, offset, b[offset:offset+size], r)
+		return
+	}
+}
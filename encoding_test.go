@@ -0,0 +1,74 @@
+package assertions
+
+import "testing"
+
+func TestValidUTF8(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    []byte
+		mustFail bool
+	}{
+		{name: "empty", input: nil, mustFail: false},
+		{name: "ascii", input: []byte("hello"), mustFail: false},
+		{name: "multibyte", input: []byte("héllo 世界"), mustFail: false},
+		{name: "encoded replacement character", input: []byte("�"), mustFail: false},
+		{name: "invalid byte", input: []byte("ab\xffcd"), mustFail: true},
+		{name: "truncated sequence", input: []byte("ab\xe4\xb8"), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ValidUTF8(tb, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestASCIIOnly(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		mustFail bool
+	}{
+		{name: "empty", input: "", mustFail: false},
+		{name: "ascii", input: "GET / HTTP/1.1\r\n", mustFail: false},
+		{name: "accented", input: "café", mustFail: true},
+		{name: "high byte", input: "\x80", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ASCIIOnly(tb, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestNoControlChars(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		mustFail bool
+	}{
+		{name: "plain", input: "hello world", mustFail: false},
+		{name: "whitespace allowed", input: "a\tb\r\nc\n", mustFail: false},
+		{name: "unicode", input: "héllo", mustFail: false},
+		{name: "nul", input: "a\x00b", mustFail: true},
+		{name: "escape", input: "\x1b[31m", mustFail: true},
+		{name: "delete", input: "\x7f", mustFail: true},
+		{name: "c1 control", input: "a\u0085b", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			NoControlChars(tb, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
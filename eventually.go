@@ -0,0 +1,185 @@
+package assertions
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type waitConfig struct {
+	clock Clock
+	ctx   context.Context
+}
+
+// WaitOption configures waiting assertions such as Eventually and Never
+type WaitOption func(*waitConfig)
+
+// WithClock makes a waiting assertion measure time with clock instead of the real clock.
+// With a FakeClock another goroutine must advance the clock for the assertion to make progress
+func WithClock(clock Clock) WaitOption {
+	return func(c *waitConfig) {
+		c.clock = clock
+	}
+}
+
+// WithContext stops a waiting assertion early when ctx is done, failing the assertion.
+// The default is the context returned by tb.Context on Go 1.24 and later, so waiting stops once the test ends.
+// That context is already canceled while cleanups run, so waits started from a cleanup use context.Background
+func WithContext(ctx context.Context) WaitOption {
+	return func(c *waitConfig) {
+		c.ctx = ctx
+	}
+}
+
+// contextTB is implemented by testing.TB from Go 1.24
+type contextTB interface {
+	Context() context.Context
+}
+
+func newWaitConfig(tb testing.TB, opts []WaitOption) waitConfig {
+	cfg := waitConfig{clock: RealClock(), ctx: context.Background()}
+	if c, ok := tb.(contextTB); ok && c.Context().Err() == nil {
+		cfg.ctx = c.Context()
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// poll calls cond every interval until it returns true, timeout has passed or the context is done.
+// cond is always called at least once, poll returns whether cond succeeded, the time elapsed
+// and the context's error if waiting was stopped by it
+func (cfg waitConfig) poll(timeout, interval time.Duration, cond func() bool) (bool, time.Duration, error) {
+	start := cfg.clock.Now()
+	deadline := start.Add(timeout)
+
+	for {
+		if cond() {
+			return true, cfg.clock.Now().Sub(start), nil
+		}
+		if !cfg.clock.Now().Before(deadline) {
+			return false, cfg.clock.Now().Sub(start), nil
+		}
+		select {
+		case <-cfg.clock.After(interval):
+		case <-cfg.ctx.Done():
+			return false, cfg.clock.Now().Sub(start), cfg.ctx.Err()
+		}
+	}
+}
+
+// Eventually asserts that cond returns true within timeout, checking every interval
+func Eventually(tb testing.TB, cond func() bool, timeout, interval time.Duration, opts ...WaitOption) {
+	const failureFormat = "condition was not met within %v\n > checked every %v\n"
+	const canceledFormat = "condition was not met before waiting was stopped after %v\n > timeout: %v\n < error:   %v\n"
+
+	summary.recordAssertion()
+
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(timeout, interval, cond)
+	summary.recordWait(tb, elapsed)
+	if err != nil {
+		errorfNow(tb, canceledFormat, elapsed, timeout, err)
+		return
+	}
+	if !ok {
+		errorfNow(tb, failureFormat, timeout, interval)
+		return
+	}
+}
+
+// Never asserts that cond does not return true at any check during duration, checking every interval
+func Never(tb testing.TB, cond func() bool, duration, interval time.Duration, opts ...WaitOption) {
+	const failureFormat = "condition was met after %v\n > expected it to remain unmet for %v\n"
+	const canceledFormat = "waiting was stopped after %v\n > expected the condition to remain unmet for %v\n < error: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(duration, interval, cond)
+	if err != nil {
+		errorfNow(tb, canceledFormat, elapsed, duration, err)
+		return
+	}
+	if ok {
+		errorfNow(tb, failureFormat, elapsed, duration)
+		return
+	}
+}
+
+// EventuallyEqual asserts that get returns a value equal to expected, as by Equal, within timeout, checking
+// every interval. Failing results print the differences between expected and the last value returned by get
+func EventuallyEqual[T any](tb testing.TB, expected T, get func() T, timeout, interval time.Duration, opts ...WaitOption) {
+	const failureFormat = "value was not equal within %v\n > checked every %v\n%v"
+	const canceledFormat = "value was not equal before waiting was stopped after %v\n > timeout: %v\n < error:   %v\n%v"
+
+	summary.recordAssertion()
+
+	var diffs []difference
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(timeout, interval, func() bool {
+		diffs = diffValues("", expected, get())
+		return len(diffs) == 0
+	})
+	summary.recordWait(tb, elapsed)
+	if err != nil {
+		errorfNow(tb, canceledFormat, elapsed, timeout, err, formatDifferences(diffs))
+		return
+	}
+	if !ok {
+		errorfNow(tb, failureFormat, timeout, interval, formatDifferences(diffs))
+		return
+	}
+}
+
+// Transitions asserts that the state returned by get passes through the states of want in order within timeout,
+// checking every interval. A state may be observed any number of times before the next one, but observing a
+// state that is neither the current nor the next one fails at once. Failing results print the observed states.
+// Only changes of state are observed, so want must not hold the same state twice in a row
+func Transitions[S comparable](tb testing.TB, get func() S, want []S, timeout, interval time.Duration, opts ...WaitOption) {
+	const unexpectedFormat = "state changed unexpectedly\n > expected: %v\n < observed: %v\n"
+	const failureFormat = "states were not observed within %v\n > checked every %v\n > expected: %v\n < observed: %v\n"
+	const canceledFormat = "states were not observed before waiting was stopped after %v\n > timeout: %v\n < error:   %v\n > expected: %v\n < observed: %v\n"
+	const repeatedFormat = "expected states repeat %v at index %v, only changes of state are observed\n > expected: %v\n"
+
+	summary.recordAssertion()
+
+	if len(want) == 0 {
+		return
+	}
+	for i := 1; i < len(want); i++ {
+		if want[i] == want[i-1] {
+			errorfNow(tb, repeatedFormat, formatValue(want[i]), i, want)
+			return
+		}
+	}
+
+	var trace []S
+	unexpected := false
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(timeout, interval, func() bool {
+		s := get()
+		if len(trace) > 0 && trace[len(trace)-1] == s {
+			return false
+		}
+		trace = append(trace, s)
+		if s != want[len(trace)-1] {
+			unexpected = true
+			return true
+		}
+		return len(trace) == len(want)
+	})
+	summary.recordWait(tb, elapsed)
+	switch {
+	case unexpected:
+		errorfNow(tb, unexpectedFormat, want, trace)
+		return
+	case err != nil:
+		errorfNow(tb, canceledFormat, elapsed, timeout, err, want, trace)
+		return
+	case !ok:
+		errorfNow(tb, failureFormat, timeout, interval, want, trace)
+		return
+	}
+}
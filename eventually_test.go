@@ -0,0 +1,238 @@
+package assertions
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// advanceWhileWaiting advances clock by step every time something waits on it, until stop is closed
+func advanceWhileWaiting(clock *FakeClock, step time.Duration, stop <-chan struct{}) {
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			clock.BlockUntilTimers(1)
+			clock.Advance(step)
+		}
+	}()
+}
+
+func TestEventually(t *testing.T) {
+	cases := []struct {
+		name      string
+		succeedAt int32
+		mustFail  bool
+	}{
+		{name: "immediately", succeedAt: 1, mustFail: false},
+		{name: "after a few checks", succeedAt: 5, mustFail: false},
+		{name: "too late", succeedAt: 100, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Time{})
+			stop := make(chan struct{})
+			defer close(stop)
+			advanceWhileWaiting(clock, time.Second, stop)
+
+			var checks atomic.Int32
+			cond := func() bool { return checks.Add(1) >= tc.succeedAt }
+
+			tb := NewTester(t, tc.mustFail)
+			Eventually(tb, cond, 10*time.Second, time.Second, WithClock(clock))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestNever(t *testing.T) {
+	cases := []struct {
+		name      string
+		succeedAt int32
+		mustFail  bool
+	}{
+		{name: "never met", succeedAt: 100, mustFail: false},
+		{name: "met immediately", succeedAt: 1, mustFail: true},
+		{name: "met later", succeedAt: 5, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Time{})
+			stop := make(chan struct{})
+			defer close(stop)
+			advanceWhileWaiting(clock, time.Second, stop)
+
+			var checks atomic.Int32
+			cond := func() bool { return checks.Add(1) >= tc.succeedAt }
+
+			tb := NewTester(t, tc.mustFail)
+			Never(tb, cond, 10*time.Second, time.Second, WithClock(clock))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEventuallyRealClock(t *testing.T) {
+	deadline := time.Now().Add(20 * time.Millisecond)
+
+	Eventually(t, func() bool { return time.Now().After(deadline) }, time.Second, time.Millisecond)
+}
+
+func TestWaitContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		name     string
+		never    bool
+		met      bool
+		ctx      context.Context
+		mustFail bool
+	}{
+		{name: "eventually canceled", never: false, met: false, ctx: canceled, mustFail: true},
+		{name: "eventually met before cancelation", never: false, met: true, ctx: canceled, mustFail: false},
+		{name: "never canceled", never: true, met: false, ctx: canceled, mustFail: true},
+		{name: "never met before cancelation", never: true, met: true, ctx: canceled, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cond := func() bool { return tc.met }
+
+			tb := NewTester(t, tc.mustFail)
+			if tc.never {
+				Never(tb, cond, time.Hour, time.Minute, WithContext(tc.ctx))
+			} else {
+				Eventually(tb, cond, time.Hour, time.Minute, WithContext(tc.ctx))
+			}
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEventuallyInCleanup(t *testing.T) {
+	t.Run("cleanup", func(t *testing.T) {
+		t.Cleanup(func() {
+			polls := 0
+			Eventually(t, func() bool {
+				polls++
+				return polls == 3
+			}, time.Second, time.Millisecond)
+		})
+	})
+}
+
+func TestEventuallyEqual(t *testing.T) {
+	cases := []struct {
+		name       string
+		completeAt int32
+		mustFail   bool
+	}{
+		{name: "immediately", completeAt: 1, mustFail: false},
+		{name: "after a few checks", completeAt: 5, mustFail: false},
+		{name: "too late", completeAt: 100, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Time{})
+			stop := make(chan struct{})
+			defer close(stop)
+			advanceWhileWaiting(clock, time.Second, stop)
+
+			var checks atomic.Int32
+			get := func() []string {
+				if checks.Add(1) >= tc.completeAt {
+					return []string{"a", "b"}
+				}
+				return []string{"a"}
+			}
+
+			tb := NewTester(t, tc.mustFail)
+			EventuallyEqual(tb, []string{"a", "b"}, get, 10*time.Second, time.Second, WithClock(clock))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEventuallyEqualMessage(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	EventuallyEqual(tb, map[string]int{"a": 1}, func() map[string]int { return map[string]int{"a": 2} }, time.Hour, time.Minute,
+		WithClock(NewFakeClock(time.Time{})), WithContext(canceled))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"value was not equal before waiting was stopped after 0s\n > timeout: 1h0m0s\n < error:   context canceled\n" +
+		" ~ [\"a\"]:\n   > expected: 1\n   < input:    2\n"}, tb.logs)
+}
+
+func TestTransitions(t *testing.T) {
+	cases := []struct {
+		name     string
+		observed []string
+		mustFail bool
+	}{
+		{name: "in order", observed: []string{"pending", "running", "done"}, mustFail: false},
+		{name: "with repeats", observed: []string{"pending", "pending", "running", "running", "running", "done"}, mustFail: false},
+		{name: "skipped state", observed: []string{"pending", "done"}, mustFail: true},
+		{name: "wrong first state", observed: []string{"running", "done"}, mustFail: true},
+		{name: "never finishes", observed: []string{"pending", "running"}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewFakeClock(time.Time{})
+			stop := make(chan struct{})
+			defer close(stop)
+			advanceWhileWaiting(clock, time.Second, stop)
+
+			var checks atomic.Int32
+			get := func() string {
+				i := int(checks.Add(1)) - 1
+				return tc.observed[min(i, len(tc.observed)-1)]
+			}
+
+			tb := NewTester(t, tc.mustFail)
+			Transitions(tb, get, []string{"pending", "running", "done"}, 10*time.Second, time.Second, WithClock(clock))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestTransitionsMessage(t *testing.T) {
+	observed := []string{"pending", "pending", "failed"}
+	checks := 0
+	get := func() string {
+		checks++
+		return observed[min(checks, len(observed))-1]
+	}
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	Transitions(tb, get, []string{"pending", "running", "done"}, time.Hour, time.Nanosecond)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"state changed unexpectedly\n > expected: [pending running done]\n < observed: [pending failed]\n"}, tb.logs)
+}
+
+func TestTransitionsRepeatedState(t *testing.T) {
+	checks := 0
+	get := func() string {
+		checks++
+		return "pending"
+	}
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	Transitions(tb, get, []string{"pending", "pending", "done"}, time.Hour, time.Nanosecond)
+	tb.AssertExpectation()
+
+	Equal(t, 0, checks)
+	Equal(t, []string{"expected states repeat \"pending\" at index 1, only changes of state are observed\n > expected: [pending pending done]\n"}, tb.logs)
+}
@@ -0,0 +1,24 @@
+package assertions
+
+import "fmt"
+
+// Explain returns the first reason expected and input are not equal as compared by Equal, or an empty string
+// if they are equal. The reason names the path to the difference and, for differences that are hard to see
+// in printed values, says what they are, such as a slice that is nil where the other is empty or values of
+// different concrete types held by interfaces. Equal includes these notes in its failures
+func Explain(expected, input any, opts ...CompareOption) string {
+	diffs := diffValues("", expected, input, append(opts[:len(opts):len(opts)], StopAfter(1))...)
+	if len(diffs) == 0 {
+		return ""
+	}
+
+	d := diffs[0]
+	path := d.path
+	if path == "" {
+		path = "(value)"
+	}
+	if d.note != "" {
+		return fmt.Sprintf("%v: %v", path, d.note)
+	}
+	return fmt.Sprintf("%v: expected %v, input %v", path, d.expected, d.input)
+}
@@ -0,0 +1,40 @@
+package assertions
+
+import "testing"
+
+func TestExplain(t *testing.T) {
+	type request struct {
+		Tags    []string
+		Payload any
+		Headers map[string]string
+	}
+
+	cases := []struct {
+		name     string
+		expected any
+		input    any
+		reason   string
+	}{
+		{name: "equal", expected: request{Tags: []string{"a"}}, input: request{Tags: []string{"a"}}, reason: ""},
+		{name: "nil and empty slice", expected: request{Tags: nil}, input: request{Tags: []string{}}, reason: ".Tags: one slice is nil and the other is empty, see EqualLoose"},
+		{name: "nil and empty map", expected: request{Headers: map[string]string{}}, input: request{}, reason: ".Headers: one map is nil and the other is empty, see EqualLoose"},
+		{name: "concrete types", expected: request{Payload: 1}, input: request{Payload: int64(1)}, reason: ".Payload: different types int and int64"},
+		{name: "first difference only", expected: []int{1, 2, 3}, input: []int{1, 5, 6}, reason: "[1]: expected 2, input 5"},
+		{name: "root", expected: "a", input: "b", reason: `(value): expected "a", input "b"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Equal(t, tc.reason, Explain(tc.expected, tc.input))
+		})
+	}
+}
+
+func TestEqualExplainsFailures(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	Equal(tb, []string(nil), []string{})
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Values are not equal\n ~ (value):\n   > expected: []string(nil)\n   < input:    []string{}\n   ~ one slice is nil and the other is empty, see EqualLoose\n"}, tb.logs)
+}
@@ -0,0 +1,109 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fdDirs list the open descriptors of the process, /proc/self/fd on Linux and /dev/fd on macOS and the BSDs
+var fdDirs = []string{"/proc/self/fd", "/dev/fd"}
+
+// runtimeDescriptors are opened by the Go runtime the first time the network poller is used and stay
+// open for the rest of the process
+var runtimeDescriptors = []string{"anon_inode:[eventpoll]", "anon_inode:[eventfd]"}
+
+// openDescriptors returns the open descriptors of the process with what each refers to, where the platform
+// can say. ok is false if the platform has no way of listing descriptors
+func openDescriptors() (descriptors map[int]string, ok bool) {
+	for _, dir := range fdDirs {
+		f, err := os.Open(dir)
+		if err != nil {
+			continue
+		}
+		names, err := f.Readdirnames(-1)
+		self := int(f.Fd())
+		f.Close()
+		if err != nil {
+			continue
+		}
+
+		descriptors = make(map[int]string, len(names))
+		for _, name := range names {
+			fd, err := strconv.Atoi(name)
+			if err != nil || fd == self {
+				continue
+			}
+			target, err := os.Readlink(filepath.Join(dir, name))
+			if err != nil {
+				target = "(unknown)"
+			}
+			descriptors[fd] = target
+		}
+		return descriptors, true
+	}
+	return nil, false
+}
+
+// leakedDescriptors formats the descriptors that are open now and were not open, or referred to something
+// else, in before
+func leakedDescriptors(before map[int]string) string {
+	now, _ := openDescriptors()
+	var fds []int
+	for fd, target := range now {
+		if before[fd] != target && !slices.Contains(runtimeDescriptors, target) {
+			fds = append(fds, fd)
+		}
+	}
+	slices.Sort(fds)
+
+	var b strings.Builder
+	for _, fd := range fds {
+		fmt.Fprintf(&b, " ~ fd %v: %v\n", fd, now[fd])
+	}
+	return b.String()
+}
+
+// NoFDLeaks records the file descriptors open now and returns a function asserting that no others are
+// open, listing the files and sockets of any that are. The check runs when the returned function is
+// called, or at cleanup if it has not been called by then. Call it at the start of the test so that the
+// check runs after the cleanups registered by the test. Descriptors are given a moment to be closed by
+// other goroutines before they count as leaked. As descriptors belong to the process, tests using it must
+// not run in parallel with tests that open files. On platforms that cannot list descriptors it only logs
+func NoFDLeaks(tb testing.TB) func() {
+	const unsupportedFormat = "open file descriptors cannot be listed on this platform, not checking for leaks\n"
+	const failureFormat = "Test leaked file descriptors\n%v"
+
+	summary.recordAssertion()
+
+	before, ok := openDescriptors()
+	if !ok {
+		tb.Logf(unsupportedFormat)
+		return func() {}
+	}
+
+	var once sync.Once
+	check := func() {
+		once.Do(func() {
+			deadline := time.Now().Add(leakGracePeriod)
+			leaked := leakedDescriptors(before)
+			for leaked != "" && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+				leaked = leakedDescriptors(before)
+			}
+			if leaked != "" {
+				errorfNow(tb, failureFormat, leaked)
+				return
+			}
+		})
+	}
+
+	tb.Cleanup(check)
+	return check
+}
@@ -0,0 +1,44 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNoFDLeaks(t *testing.T) {
+	if _, ok := openDescriptors(); !ok {
+		t.Skip("open file descriptors cannot be listed on this platform")
+	}
+
+	cases := []struct {
+		name     string
+		leak     bool
+		mustFail bool
+	}{
+		{name: "closed", leak: false, mustFail: false},
+		{name: "left open", leak: true, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "leak")
+			tb := &recordingTB{TesterTB: NewTester(t, tc.mustFail)}
+
+			check := NoFDLeaks(tb)
+			f, err := os.Create(path)
+			NoError(t, err)
+			if !tc.leak {
+				f.Close()
+			}
+			check()
+			f.Close()
+			tb.AssertExpectation()
+
+			if tc.mustFail && len(tb.logs) == 1 && !strings.Contains(tb.logs[0], path) {
+				t.Fatalf("Leaked file was not listed:\n%v", tb.logs[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// FieldCheck is a check against a single, possibly nested, field of a struct.
+// FieldChecks are created with FieldEqual, FieldNonZero and FieldMatches
+type FieldCheck struct {
+	name string
+	// check returns a description of the failure, or an empty string when the field passes
+	check func(field reflect.Value) string
+}
+
+// FieldEqual checks that the named field is equal to expected with the semantics of Equal
+func FieldEqual(name string, expected any) FieldCheck {
+	return FieldCheck{
+		name: name,
+		check: func(field reflect.Value) string {
+			d := differ{cfg: compareConfig{comparers: snapshotComparers()}}
+			d.walk(&diffPath{}, asType(expected, field.Type()), field)
+			if len(d.diffs) == 0 {
+				return ""
+			}
+			return "not equal\n" + formatDifferences(d.diffs)
+		},
+	}
+}
+
+// FieldNonZero checks that the named field does not hold the zero value for its type
+func FieldNonZero(name string) FieldCheck {
+	return FieldCheck{
+		name: name,
+		check: func(field reflect.Value) string {
+			if field.IsZero() {
+				return fmt.Sprintf("is the zero value %v", formatReflect(field))
+			}
+			return ""
+		},
+	}
+}
+
+// FieldMatches checks that pred returns true for the value of the named field.
+// The field must be exported
+func FieldMatches(name string, pred func(any) bool) FieldCheck {
+	return FieldCheck{
+		name: name,
+		check: func(field reflect.Value) string {
+			if !field.CanInterface() {
+				return "is unexported and cannot be passed to a predicate"
+			}
+			if !pred(field.Interface()) {
+				return fmt.Sprintf("does not match the predicate\n   < input: %v", formatReflect(field))
+			}
+			return ""
+		},
+	}
+}
+
+// lookupField resolves a dot separated field path such as "Address.Zip" against v,
+// following pointers along the way
+func lookupField(v reflect.Value, name string) (reflect.Value, error) {
+	for _, part := range strings.Split(name, ".") {
+		if !v.IsValid() {
+			return reflect.Value{}, fmt.Errorf("value is nil before field %v", part)
+		}
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Errorf("nil %v before field %v", v.Type(), part)
+			}
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%v is not a struct", v.Type())
+		}
+
+		field := v.FieldByName(part)
+		if !field.IsValid() {
+			return reflect.Value{}, fmt.Errorf("%v has no field %v", v.Type(), part)
+		}
+		v = field
+	}
+
+	return v, nil
+}
+
+// Fields asserts that each check passes against the corresponding field of input, which must be a struct
+// or a pointer to one. Fields not named by a check are ignored.
+// Failing results report every failing check
+func Fields[T any](tb testing.TB, input T, checks ...FieldCheck) {
+	const nilFormat = "Fields do not match, value is nil\n"
+	const failureFormat = "Fields do not match\n%v"
+
+	summary.recordAssertion()
+
+	root := reflect.ValueOf(input)
+	if !root.IsValid() {
+		errorfNow(tb, nilFormat)
+		return
+	}
+
+	var b strings.Builder
+	for _, c := range checks {
+		field, err := lookupField(root, c.name)
+		if err != nil {
+			fmt.Fprintf(&b, " ~ %v: %v\n", c.name, err)
+			continue
+		}
+
+		if msg := c.check(field); msg != "" {
+			fmt.Fprintf(&b, " ~ %v: %v\n", c.name, strings.TrimSuffix(msg, "\n"))
+		}
+	}
+
+	if b.Len() > 0 {
+		errorfNow(tb, failureFormat, b.String())
+		return
+	}
+}
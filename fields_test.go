@@ -0,0 +1,118 @@
+package assertions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	type address struct {
+		Zip string
+	}
+	type user struct {
+		ID      int
+		Name    string
+		Email   string
+		Tags    []string
+		Address *address
+		secret  string
+	}
+
+	fixture := &user{
+		ID:      42,
+		Name:    "x",
+		Tags:    []string{"a"},
+		Address: &address{Zip: "12345"},
+		secret:  "s",
+	}
+
+	cases := []struct {
+		name     string
+		checks   []FieldCheck
+		mustFail bool
+	}{
+		{name: "no checks", checks: nil, mustFail: false},
+		{name: "equal", checks: []FieldCheck{FieldEqual("Name", "x"), FieldEqual("Tags", []string{"a"})}, mustFail: false},
+		{name: "not equal", checks: []FieldCheck{FieldEqual("Name", "y")}, mustFail: true},
+		{name: "wrong type", checks: []FieldCheck{FieldEqual("ID", int64(42))}, mustFail: true},
+		{name: "nested", checks: []FieldCheck{FieldEqual("Address.Zip", "12345")}, mustFail: false},
+		{name: "unexported", checks: []FieldCheck{FieldEqual("secret", "s")}, mustFail: false},
+		{name: "non zero", checks: []FieldCheck{FieldNonZero("ID"), FieldNonZero("Address")}, mustFail: false},
+		{name: "nested non zero", checks: []FieldCheck{FieldNonZero("Address.Zip")}, mustFail: false},
+		{name: "zero", checks: []FieldCheck{FieldNonZero("ID"), FieldNonZero("Email")}, mustFail: true},
+		{name: "matches", checks: []FieldCheck{FieldMatches("Name", func(v any) bool { return strings.HasPrefix(v.(string), "x") })}, mustFail: false},
+		{name: "does not match", checks: []FieldCheck{FieldMatches("ID", func(v any) bool { return v.(int) < 10 })}, mustFail: true},
+		{name: "unexported predicate", checks: []FieldCheck{FieldMatches("secret", func(v any) bool { return true })}, mustFail: true},
+		{name: "missing field", checks: []FieldCheck{FieldNonZero("Phone")}, mustFail: true},
+		{name: "not a struct", checks: []FieldCheck{FieldNonZero("Name.First")}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Fields(tb, fixture, tc.checks...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestFieldsNilPointer(t *testing.T) {
+	type user struct {
+		Address *struct{ Zip string }
+	}
+
+	tb := NewTester(t, true)
+
+	Fields(tb, user{}, FieldNonZero("Address.Zip"))
+	tb.AssertExpectation()
+}
+
+func TestFieldEqualNilAndInterface(t *testing.T) {
+	type user struct {
+		Any     any
+		Err     error
+		Ptr     *int
+		Tags    []string
+		Default any
+	}
+
+	fixture := user{Any: "x", Ptr: nil}
+
+	cases := []struct {
+		name     string
+		check    FieldCheck
+		mustFail bool
+	}{
+		{name: "interface", check: FieldEqual("Any", "x"), mustFail: false},
+		{name: "interface differs", check: FieldEqual("Any", "y"), mustFail: true},
+		{name: "interface different type", check: FieldEqual("Any", 1), mustFail: true},
+		{name: "nil interface", check: FieldEqual("Default", nil), mustFail: false},
+		{name: "nil error", check: FieldEqual("Err", nil), mustFail: false},
+		{name: "nil pointer", check: FieldEqual("Ptr", nil), mustFail: false},
+		{name: "nil slice", check: FieldEqual("Tags", nil), mustFail: false},
+		{name: "non-nil expected for nil pointer", check: FieldEqual("Ptr", Ptr(1)), mustFail: true},
+		{name: "nil expected for interface", check: FieldEqual("Any", nil), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+			Fields(tb, fixture, tc.check)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestFieldsNil(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	Fields[any](tb, nil, FieldNonZero("Name"))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Fields do not match, value is nil\n"}, tb.logs)
+
+	_, err := lookupField(reflect.Value{}, "Name")
+	Equal(t, "value is nil before field Name", err.Error())
+}
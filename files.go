@@ -0,0 +1,238 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tailLimit is the number of bytes at the end of a file or output printed by failing assertions
+const tailLimit = 1024
+
+// formatTail quotes the end of content, where output written by a process is most telling
+func formatTail(content string) string {
+	if len(content) <= tailLimit {
+		return fmt.Sprintf("%q", content)
+	}
+	return fmt.Sprintf("(%v bytes, last %v) ...%q", len(content), tailLimit, content[len(content)-tailLimit:])
+}
+
+// EventuallyFileExists asserts that a file exists at path within timeout, checking every interval.
+// Failing results print the error of the last check
+func EventuallyFileExists(tb testing.TB, path string, timeout, interval time.Duration, opts ...WaitOption) {
+	const failureFormat = "file did not exist within %v\n > checked every %v\n < error:   %v\n"
+	const canceledFormat = "file did not exist before waiting was stopped after %v\n > timeout: %v\n < error:   %v\n < last:    %v\n"
+
+	summary.recordAssertion()
+
+	var statErr error
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(timeout, interval, func() bool {
+		_, statErr = os.Stat(path)
+		return statErr == nil
+	})
+	summary.recordWait(tb, elapsed)
+	if err != nil {
+		errorfNow(tb, canceledFormat, elapsed, timeout, err, statErr)
+		return
+	}
+	if !ok {
+		errorfNow(tb, failureFormat, timeout, interval, statErr)
+		return
+	}
+}
+
+// EventuallyFileContains asserts that the file at path contains substr within timeout, checking every interval.
+// Failing results print the end of the file's content at the last check, or the error reading it
+func EventuallyFileContains(tb testing.TB, path, substr string, timeout, interval time.Duration, opts ...WaitOption) {
+	const failureFormat = "file did not contain %q within %v\n > checked every %v\n < last:    %v\n"
+	const canceledFormat = "file did not contain %q before waiting was stopped after %v\n > timeout: %v\n < error:   %v\n < last:    %v\n"
+
+	summary.recordAssertion()
+
+	var last string
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := cfg.poll(timeout, interval, func() bool {
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			last = readErr.Error()
+			return false
+		}
+		last = formatTail(string(content))
+		return strings.Contains(string(content), substr)
+	})
+	summary.recordWait(tb, elapsed)
+	if err != nil {
+		errorfNow(tb, canceledFormat, substr, elapsed, timeout, err, last)
+		return
+	}
+	if !ok {
+		errorfNow(tb, failureFormat, substr, timeout, interval, last)
+		return
+	}
+}
+
+type pathConfig struct {
+	foldCase bool
+	goos     []string
+}
+
+// PathOption configures PathsEqual
+type PathOption func(*pathConfig)
+
+// PathFoldCase compares paths case-insensitively, only when running on one of goos if any are given,
+// e.g. PathFoldCase("windows", "darwin") for the case-insensitive file systems of those platforms
+func PathFoldCase(goos ...string) PathOption {
+	return func(c *pathConfig) {
+		c.foldCase = true
+		c.goos = goos
+	}
+}
+
+// normalizePath cleans p with forward slashes as the separator and an upper case drive letter
+func normalizePath(p string, foldCase bool) string {
+	p = path.Clean(strings.ReplaceAll(p, "\\", "/"))
+	if len(p) >= 2 && p[1] == ':' && ('a' <= p[0] && p[0] <= 'z' || 'A' <= p[0] && p[0] <= 'Z') {
+		p = strings.ToUpper(p[:1]) + p[1:]
+	}
+	if foldCase {
+		p = strings.ToLower(p)
+	}
+	return p
+}
+
+// PathsEqual asserts that expected and input name the same path once both are cleaned as by filepath.Clean,
+// with either slash accepted as the separator and drive letters compared case-insensitively. It does not
+// touch the file system, so symbolic links and relative paths are not resolved
+func PathsEqual(tb testing.TB, expected, input string, opts ...PathOption) {
+	const failureFormat = "Paths are not equal\n > expected: %q\n < input:    %q\n ~ normalized to %q and %q\n"
+
+	summary.recordAssertion()
+
+	var cfg pathConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	foldCase := cfg.foldCase && (len(cfg.goos) == 0 || slices.Contains(cfg.goos, runtime.GOOS))
+
+	if e, i := normalizePath(expected, foldCase), normalizePath(input, foldCase); e != i {
+		errorfNow(tb, failureFormat, expected, input, e, i)
+		return
+	}
+}
+
+// defaultModeMask selects the permission, setuid, setgid and sticky bits, leaving out the file type
+const defaultModeMask = fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+
+type modeConfig struct {
+	mask fs.FileMode
+}
+
+// ModeOption configures FileMode
+type ModeOption func(*modeConfig)
+
+// ModeMask compares only the bits of mask, e.g. ModeMask(0o077) to assert on the permissions of
+// group and others regardless of the owner's. Type bits such as fs.ModeDir may be included
+func ModeMask(mask fs.FileMode) ModeOption {
+	return func(c *modeConfig) {
+		c.mask = mask
+	}
+}
+
+// FileMode asserts that the file at path, following symbolic links, has the mode want. By default the
+// permission, setuid, setgid and sticky bits are compared, see ModeMask. Failing results print modes
+// symbolically as by ls, with the differing bits
+func FileMode(tb testing.TB, path string, want fs.FileMode, opts ...ModeOption) {
+	const statFormat = "File could not be read\n ~ path:  %v\n < error: %v\n"
+	const failureFormat = "File mode is not as expected\n ~ path:     %v\n > expected: %v\n < input:    %v\n ~ differing: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := modeConfig{mask: defaultModeMask}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		errorfNow(tb, statFormat, path, err)
+		return
+	}
+	if mode := info.Mode() & cfg.mask; mode != want&cfg.mask {
+		errorfNow(tb, failureFormat, path, want&cfg.mask, mode, (mode^want)&cfg.mask)
+		return
+	}
+}
+
+// IsSymlinkTo asserts that link is a symbolic link to target. A relative link is resolved against the
+// directory containing it and both are made absolute before comparing, so a link written as "../v2/app"
+// is to target "releases/v2/app" when link is "releases/current/app". The target need not exist
+func IsSymlinkTo(tb testing.TB, link, target string) {
+	const statFormat = "Link could not be read\n ~ path:  %v\n < error: %v\n"
+	const notLinkFormat = "File is not a symbolic link\n ~ path: %v\n ~ mode: %v\n"
+	const failureFormat = "Symbolic link points elsewhere\n ~ link:     %v\n > expected: %v\n < input:    %v\n ~ written as %q\n"
+
+	summary.recordAssertion()
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		errorfNow(tb, statFormat, link, err)
+		return
+	}
+	if info.Mode()&fs.ModeSymlink == 0 {
+		errorfNow(tb, notLinkFormat, link, info.Mode())
+		return
+	}
+	dest, err := os.Readlink(link)
+	if err != nil {
+		errorfNow(tb, statFormat, link, err)
+		return
+	}
+
+	resolved := dest
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(filepath.Dir(link), resolved)
+	}
+	resolved, errResolved := filepath.Abs(resolved)
+	expected, errExpected := filepath.Abs(target)
+	if err := errors.Join(errResolved, errExpected); err != nil {
+		errorfNow(tb, statFormat, link, err)
+		return
+	}
+	if resolved != expected {
+		errorfNow(tb, failureFormat, link, expected, resolved, dest)
+		return
+	}
+}
+
+// SameInode asserts that a and b, following symbolic links, are the same file rather than copies,
+// as hard links to one file are, as by os.SameFile
+func SameInode(tb testing.TB, a, b string) {
+	const statFormat = "File could not be read\n ~ path:  %v\n < error: %v\n"
+	const failureFormat = "Files are not the same\n > expected: %v\n < input:    %v\n ~ sizes %v and %v, modified %v and %v\n"
+
+	summary.recordAssertion()
+
+	infoA, err := os.Stat(a)
+	if err != nil {
+		errorfNow(tb, statFormat, a, err)
+		return
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		errorfNow(tb, statFormat, b, err)
+		return
+	}
+	if !os.SameFile(infoA, infoB) {
+		errorfNow(tb, failureFormat, a, b, infoA.Size(), infoB.Size(), infoA.ModTime().Format(time.RFC3339Nano), infoB.ModTime().Format(time.RFC3339Nano))
+		return
+	}
+}
@@ -0,0 +1,240 @@
+package assertions
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventuallyFileExists(t *testing.T) {
+	cases := []struct {
+		name      string
+		createdAt int32
+		mustFail  bool
+	}{
+		{name: "created at once", createdAt: 1, mustFail: false},
+		{name: "created while waiting", createdAt: 3, mustFail: false},
+		{name: "never created", createdAt: 100, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "out")
+			clock := NewFakeClock(time.Time{})
+			stop := make(chan struct{})
+			defer close(stop)
+
+			var checks atomic.Int32
+			go func() {
+				for {
+					if checks.Add(1) >= tc.createdAt {
+						os.WriteFile(path, nil, 0o600)
+					}
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					clock.BlockUntilTimers(1)
+					clock.Advance(time.Second)
+				}
+			}()
+
+			tb := NewTester(t, tc.mustFail)
+			EventuallyFileExists(tb, path, 10*time.Second, time.Second, WithClock(clock))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEventuallyFileContains(t *testing.T) {
+	dir := t.TempDir()
+	written := filepath.Join(dir, "written")
+	NoError(t, os.WriteFile(written, []byte("starting\nready\n"), 0o600))
+
+	cases := []struct {
+		name     string
+		path     string
+		substr   string
+		mustFail bool
+	}{
+		{name: "contains", path: written, substr: "ready", mustFail: false},
+		{name: "does not contain", path: written, substr: "stopped", mustFail: true},
+		{name: "missing", path: filepath.Join(dir, "missing"), substr: "ready", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EventuallyFileContains(tb, tc.path, tc.substr, 0, time.Millisecond)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEventuallyFileContainsMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", tailLimit)+"starting\n"), 0o600))
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	EventuallyFileContains(tb, path, "ready", 0, time.Millisecond)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"file did not contain \"ready\" within 0s\n > checked every 1ms\n < last:    (1033 bytes, last 1024) ...\"" +
+		strings.Repeat("x", tailLimit-9) + "starting\\n\"\n"}, tb.logs)
+}
+
+func TestPathsEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		opts     []PathOption
+		mustFail bool
+	}{
+		{name: "identical", expected: "a/b/c", input: "a/b/c", mustFail: false},
+		{name: "unclean", expected: "a/b/c", input: "./a//b/../b/c/", mustFail: false},
+		{name: "backslashes", expected: "a/b/c", input: `a\b\c`, mustFail: false},
+		{name: "drive letter", expected: `C:\Users\me`, input: "c:/Users/me", mustFail: false},
+		{name: "different", expected: "a/b/c", input: "a/b/d", mustFail: true},
+		{name: "case differs", expected: "a/B", input: "a/b", mustFail: true},
+		{name: "case folded", expected: "a/B", input: "a/b", opts: []PathOption{PathFoldCase()}, mustFail: false},
+		{name: "case folded elsewhere", expected: "a/B", input: "a/b", opts: []PathOption{PathFoldCase("plan9")}, mustFail: true},
+		{name: "absolute and relative", expected: "/a/b", input: "a/b", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			PathsEqual(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestPathsEqualMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	PathsEqual(tb, `out\a.txt`, "out/b.txt")
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Paths are not equal\n > expected: \"out\\\\a.txt\"\n < input:    \"out/b.txt\"\n ~ normalized to \"out/a.txt\" and \"out/b.txt\"\n"}, tb.logs)
+}
+
+func TestFileMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret")
+	NoError(t, os.WriteFile(path, nil, 0o600))
+	NoError(t, os.Chmod(path, 0o640))
+
+	cases := []struct {
+		name     string
+		path     string
+		want     fs.FileMode
+		opts     []ModeOption
+		mustFail bool
+	}{
+		{name: "matches", path: path, want: 0o640, mustFail: false},
+		{name: "differs", path: path, want: 0o600, mustFail: true},
+		{name: "masked", path: path, want: 0o600, opts: []ModeOption{ModeMask(0o707)}, mustFail: false},
+		{name: "masked differs", path: path, want: 0o644, opts: []ModeOption{ModeMask(0o707)}, mustFail: true},
+		{name: "directory type", path: dir, want: fs.ModeDir, opts: []ModeOption{ModeMask(fs.ModeType)}, mustFail: false},
+		{name: "missing", path: filepath.Join(dir, "missing"), want: 0o640, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			FileMode(tb, tc.path, tc.want, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestFileModeMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script")
+	NoError(t, os.WriteFile(path, nil, 0o600))
+	NoError(t, os.Chmod(path, 0o644))
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	FileMode(tb, path, 0o755)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"File mode is not as expected\n ~ path:     " + path + "\n > expected: -rwxr-xr-x\n < input:    -rw-r--r--\n ~ differing: ---x--x--x\n"}, tb.logs)
+}
+
+func TestIsSymlinkTo(t *testing.T) {
+	dir := t.TempDir()
+	NoError(t, os.MkdirAll(filepath.Join(dir, "releases", "v2"), 0o755))
+	NoError(t, os.MkdirAll(filepath.Join(dir, "releases", "current"), 0o755))
+	target := filepath.Join(dir, "releases", "v2", "app")
+	NoError(t, os.WriteFile(target, nil, 0o600))
+
+	relative := filepath.Join(dir, "releases", "current", "app")
+	if err := os.Symlink(filepath.Join("..", "v2", "app"), relative); err != nil {
+		t.Skipf("symbolic links are not available: %v", err)
+	}
+	absolute := filepath.Join(dir, "absolute")
+	NoError(t, os.Symlink(target, absolute))
+
+	cases := []struct {
+		name     string
+		link     string
+		target   string
+		mustFail bool
+	}{
+		{name: "relative link", link: relative, target: target, mustFail: false},
+		{name: "absolute link", link: absolute, target: target, mustFail: false},
+		{name: "other target", link: absolute, target: relative, mustFail: true},
+		{name: "not a link", link: target, target: target, mustFail: true},
+		{name: "missing", link: filepath.Join(dir, "missing"), target: target, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			IsSymlinkTo(tb, tc.link, tc.target)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSameInode(t *testing.T) {
+	dir := t.TempDir()
+	original := filepath.Join(dir, "original")
+	NoError(t, os.WriteFile(original, []byte("data"), 0o600))
+	copied := filepath.Join(dir, "copy")
+	NoError(t, os.WriteFile(copied, []byte("data"), 0o600))
+	linked := filepath.Join(dir, "link")
+	if err := os.Link(original, linked); err != nil {
+		t.Skipf("hard links are not available: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		a        string
+		b        string
+		mustFail bool
+	}{
+		{name: "same path", a: original, b: original, mustFail: false},
+		{name: "hard link", a: original, b: linked, mustFail: false},
+		{name: "copy", a: original, b: copied, mustFail: true},
+		{name: "missing", a: original, b: filepath.Join(dir, "missing"), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SameInode(tb, tc.a, tc.b)
+			tb.AssertExpectation()
+		})
+	}
+}
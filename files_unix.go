@@ -0,0 +1,61 @@
+//go:build unix
+
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+	"testing"
+)
+
+// formatOwner prints the numeric id of a user or group with its name where it can be looked up
+func formatOwner(id int, lookup func(id string) (string, error)) string {
+	if id < 0 {
+		return "any"
+	}
+	if name, err := lookup(strconv.Itoa(id)); err == nil {
+		return fmt.Sprintf("%v (%v)", id, name)
+	}
+	return strconv.Itoa(id)
+}
+
+func lookupUser(id string) (string, error) {
+	u, err := user.LookupId(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Username, nil
+}
+
+func lookupGroup(id string) (string, error) {
+	g, err := user.LookupGroupId(id)
+	if err != nil {
+		return "", err
+	}
+	return g.Name, nil
+}
+
+// FileOwnedBy asserts that the file at path, following symbolic links, is owned by the user uid and the group gid.
+// Either may be -1 to accept any owner, as with os.Chown. It is only available on Unix
+func FileOwnedBy(tb testing.TB, path string, uid, gid int) {
+	const statFormat = "File could not be read\n ~ path:  %v\n < error: %v\n"
+	const failureFormat = "File is not owned as expected\n ~ path:     %v\n > expected: user %v, group %v\n < input:    user %v, group %v\n"
+
+	summary.recordAssertion()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		errorfNow(tb, statFormat, path, err)
+		return
+	}
+	st := info.Sys().(*syscall.Stat_t)
+	fileUID, fileGID := int(st.Uid), int(st.Gid)
+	if (uid >= 0 && uid != fileUID) || (gid >= 0 && gid != fileGID) {
+		errorfNow(tb, failureFormat, path, formatOwner(uid, lookupUser), formatOwner(gid, lookupGroup),
+			formatOwner(fileUID, lookupUser), formatOwner(fileGID, lookupGroup))
+		return
+	}
+}
@@ -0,0 +1,39 @@
+//go:build unix
+
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileOwnedBy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owned")
+	NoError(t, os.WriteFile(path, nil, 0o600))
+	uid, gid := os.Getuid(), os.Getgid()
+
+	cases := []struct {
+		name     string
+		path     string
+		uid      int
+		gid      int
+		mustFail bool
+	}{
+		{name: "owner", path: path, uid: uid, gid: gid, mustFail: false},
+		{name: "any group", path: path, uid: uid, gid: -1, mustFail: false},
+		{name: "any owner", path: path, uid: -1, gid: -1, mustFail: false},
+		{name: "other user", path: path, uid: uid + 1, gid: -1, mustFail: true},
+		{name: "other group", path: path, uid: -1, gid: gid + 1, mustFail: true},
+		{name: "missing", path: path + ".missing", uid: uid, gid: gid, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			FileOwnedBy(tb, tc.path, tc.uid, tc.gid)
+			tb.AssertExpectation()
+		})
+	}
+}
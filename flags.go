@@ -0,0 +1,82 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// Integer is any integer type
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// describeBits lists the bits set in bits, using names where available
+func describeBits[T Integer](bits T, names map[T]string) string {
+	parts := make([]string, 0)
+	for i := range int(unsafe.Sizeof(bits)) * 8 {
+		bit := T(1) << i
+		if bits&bit == 0 {
+			continue
+		}
+		parts = append(parts, bitName(i, bit, names))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func bitName[T Integer](i int, bit T, names map[T]string) string {
+	if name, ok := names[bit]; ok {
+		return fmt.Sprintf("bit %v (%v)", i, name)
+	}
+	return fmt.Sprintf("bit %v", i)
+}
+
+func mergeNames[T Integer](names []map[T]string) map[T]string {
+	merged := make(map[T]string)
+	for _, m := range names {
+		for bit, name := range m {
+			merged[bit] = name
+		}
+	}
+	return merged
+}
+
+// HasFlags asserts that every bit set in mask is also set in input.
+// Optional maps from single bit values to names are used to label the missing bits
+func HasFlags[T Integer](tb testing.TB, mask, input T, names ...map[T]string) {
+	const failureFormat = "Flags are not set\n > mask:    %#x\n < input:   %#x\n > missing: %v\n"
+
+	summary.recordAssertion()
+
+	if missing := mask &^ input; missing != 0 {
+		errorfNow(tb, failureFormat, mask, input, describeBits(missing, mergeNames(names)))
+		return
+	}
+}
+
+// FlagsEqual asserts that expected and input have exactly the same bits set, listing each bit that differs.
+// Optional maps from single bit values to names are used to label the differing bits
+func FlagsEqual[T Integer](tb testing.TB, expected, input T, names ...map[T]string) {
+	const failureFormat = "Flags are not equal\n > expected: %#x\n < input:    %#x\n%v"
+
+	summary.recordAssertion()
+
+	if expected == input {
+		return
+	}
+
+	merged := mergeNames(names)
+	var b strings.Builder
+	for i := range int(unsafe.Sizeof(expected)) * 8 {
+		bit := T(1) << i
+		switch {
+		case expected&bit != 0 && input&bit == 0:
+			fmt.Fprintf(&b, " ~ %v: expected set, got clear\n", bitName(i, bit, merged))
+		case expected&bit == 0 && input&bit != 0:
+			fmt.Fprintf(&b, " ~ %v: expected clear, got set\n", bitName(i, bit, merged))
+		}
+	}
+
+	errorfNow(tb, failureFormat, expected, input, b.String())
+}
@@ -0,0 +1,65 @@
+package assertions
+
+import "testing"
+
+type permission uint8
+
+const (
+	permRead permission = 1 << iota
+	permWrite
+	permExec
+)
+
+var permissionNames = map[permission]string{permRead: "read", permWrite: "write", permExec: "exec"}
+
+func TestHasFlags(t *testing.T) {
+	cases := []struct {
+		name     string
+		mask     permission
+		input    permission
+		mustFail bool
+	}{
+		{name: "empty mask", mask: 0, input: 0, mustFail: false},
+		{name: "exact", mask: permRead | permWrite, input: permRead | permWrite, mustFail: false},
+		{name: "superset", mask: permRead, input: permRead | permExec, mustFail: false},
+		{name: "missing bit", mask: permRead | permWrite, input: permRead, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			HasFlags(tb, tc.mask, tc.input, permissionNames)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestFlagsEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected int64
+		input    int64
+		mustFail bool
+	}{
+		{name: "equal", expected: 0b1010, input: 0b1010, mustFail: false},
+		{name: "extra bit", expected: 0b1010, input: 0b1011, mustFail: true},
+		{name: "missing bit", expected: 0b1010, input: 0b0010, mustFail: true},
+		{name: "sign bit", expected: -1, input: 1<<63 - 1, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			FlagsEqual(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestDescribeBits(t *testing.T) {
+	Equal(t, "bit 0 (read), bit 2 (exec)", describeBits(permRead|permExec, permissionNames))
+	Equal(t, "bit 1, bit 7", describeBits(uint8(0b10000010), nil))
+	Equal(t, "", describeBits(0, permissionNames))
+}
@@ -0,0 +1,126 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = make(map[reflect.Type]func(any) string)
+)
+
+// RegisterFormatter sets the function used to print values of type T in failure messages,
+// replacing the default formatting for that type. Registering a formatter that returns
+// fmt.Sprintf("%#v", v) restores the Go syntax representation for a type implementing fmt.Stringer.
+// Formatters are global and are typically registered from an init function or TestMain
+func RegisterFormatter[T any](fn func(T) string) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	formatters[reflect.TypeFor[T]()] = func(v any) string {
+		return fn(v.(T))
+	}
+}
+
+func registeredFormatter(t reflect.Type) (func(any) string, bool) {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+
+	fn, ok := formatters[t]
+	return fn, ok
+}
+
+// formatValue prints v for a failure message. Registered formatters take precedence,
+// then errors and fmt.Stringers are printed as their message qualified by the type name,
+// and everything else uses the Go syntax representation
+func formatValue(v any) string {
+	return formatWith(v, false)
+}
+
+// formatMismatch prints v where it differs from the value it was compared with.
+// It extends formatValue by printing integers in decimal and hex, see formatInteger
+func formatMismatch(v any) string {
+	return formatWith(v, true)
+}
+
+func formatWith(v any, mismatch bool) (out string) {
+	if v == nil {
+		return "nil"
+	}
+
+	if fn, ok := registeredFormatter(reflect.TypeOf(v)); ok {
+		return fn(v)
+	}
+
+	// A String or Error method may not handle a nil receiver
+	defer func() {
+		if recover() != nil {
+			out = fmt.Sprintf("%#v", v)
+		}
+	}()
+
+	switch s := v.(type) {
+	case error:
+		return fmt.Sprintf("%T(%v)", v, s.Error())
+	case fmt.Stringer:
+		return fmt.Sprintf("%T(%v)", v, s.String())
+	}
+
+	if mismatch {
+		if out, ok := formatInteger(reflect.ValueOf(v)); ok {
+			return out
+		}
+	}
+
+	return fmt.Sprintf("%#v", v)
+}
+
+var byteUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// formatInteger prints integers as decimal followed by hex, which makes flag and mask differences readable.
+// Values that are a whole number of KiB are assumed to be sizes and also printed with binary units.
+// Values below 10, where hex adds nothing, and non integers are not handled
+func formatInteger(v reflect.Value) (string, bool) {
+	var dec, hex string
+	var size uint64
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if n > -10 && n < 10 {
+			return "", false
+		}
+		dec, hex = strconv.FormatInt(n, 10), "0x"+strconv.FormatInt(n, 16)
+		if n < 0 {
+			// The magnitude is taken as unsigned, -n overflows for math.MinInt64
+			hex = "-0x" + strconv.FormatUint(uint64(^n)+1, 16)
+		} else {
+			size = uint64(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		if n < 10 {
+			return "", false
+		}
+		dec, hex = strconv.FormatUint(n, 10), "0x"+strconv.FormatUint(n, 16)
+		size = n
+	default:
+		return "", false
+	}
+
+	if size < 1024 || size%1024 != 0 {
+		return fmt.Sprintf("%v (%v)", dec, hex), true
+	}
+
+	// Use the largest unit that divides the size exactly
+	unit := 0
+	size /= 1024
+	for size >= 1024 && size%1024 == 0 && unit < len(byteUnits)-1 {
+		size /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%v (%v, %v %v)", dec, hex, size, byteUnits[unit]), true
+}
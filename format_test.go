@@ -0,0 +1,97 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+type celsius float64
+
+type point struct {
+	X, Y int
+}
+
+func (p *point) String() string {
+	return fmt.Sprintf("(%v, %v)", p.X, p.Y)
+}
+
+func TestFormatValue(t *testing.T) {
+	RegisterFormatter(func(c celsius) string { return fmt.Sprintf("%.1f°C", float64(c)) })
+
+	cases := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{name: "nil", input: nil, expected: "nil"},
+		{name: "plain", input: []int{1}, expected: "[]int{1}"},
+		{name: "string", input: "x", expected: `"x"`},
+		{name: "error", input: errors.New("boom"), expected: "*errors.errorString(boom)"},
+		{name: "stringer", input: 1500 * time.Millisecond, expected: "time.Duration(1.5s)"},
+		{name: "pointer stringer", input: &point{X: 1, Y: 2}, expected: "*assertions.point((1, 2))"},
+		{name: "nil pointer stringer", input: (*point)(nil), expected: "(*assertions.point)(nil)"},
+		{name: "registered", input: celsius(21.5), expected: "21.5°C"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Equal(t, tc.expected, formatValue(tc.input))
+		})
+	}
+}
+
+func TestFormatDifferencesUseStringers(t *testing.T) {
+	type timeout struct {
+		Read time.Duration
+	}
+
+	diffs := diffValues("", timeout{Read: time.Second}, timeout{Read: 2 * time.Second})
+	Equal(t, []difference{{path: ".Read", expected: "time.Duration(1s)", input: "time.Duration(2s)"}}, diffs)
+}
+
+func TestFormatMismatchIntegers(t *testing.T) {
+	type flags uint8
+
+	cases := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{name: "small", input: 7, expected: "7"},
+		{name: "decimal and hex", input: 255, expected: "255 (0xff)"},
+		{name: "negative", input: int8(-16), expected: "-16 (-0x10)"},
+		{name: "min int64", input: int64(math.MinInt64), expected: "-9223372036854775808 (-0x8000000000000000)"},
+		{name: "min int8", input: int8(math.MinInt8), expected: "-128 (-0x80)"},
+		{name: "named unsigned", input: flags(0x12), expected: "18 (0x12)"},
+		{name: "kibibytes", input: 4096, expected: "4096 (0x1000, 4 KiB)"},
+		{name: "mebibytes", input: uint64(3 << 20), expected: "3145728 (0x300000, 3 MiB)"},
+		{name: "not a whole size", input: 1536 + 1, expected: "1537 (0x601)"},
+		{name: "kibibytes not mebibytes", input: 1536 * 1024, expected: "1572864 (0x180000, 1536 KiB)"},
+		{name: "stringer wins", input: 2 * time.Second, expected: "time.Duration(2s)"},
+		{name: "non integer", input: 2.5, expected: "2.5"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Equal(t, tc.expected, formatMismatch(tc.input))
+		})
+	}
+
+	Equal(t, "255", formatValue(255))
+}
+
+func TestDiffIntegersInHex(t *testing.T) {
+	type header struct {
+		Flags uint16
+		size  int
+	}
+
+	diffs := diffValues("", header{Flags: 0x0f0f, size: 2048}, header{Flags: 0x0f1f, size: 1024})
+	Equal(t, []difference{
+		{path: ".Flags", expected: "3855 (0xf0f)", input: "3871 (0xf1f)"},
+		{path: ".size", expected: "2048 (0x800, 2 KiB)", input: "1024 (0x400, 1 KiB)"},
+	}, diffs)
+}
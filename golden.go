@@ -0,0 +1,156 @@
+package assertions
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	_ "image/png" // golden images are stored as PNG
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"unicode/utf8"
+)
+
+// UpdateGoldenEnv names the environment variable that, when set to a non-empty value, makes golden
+// assertions write their input to the golden file instead of comparing against it
+const UpdateGoldenEnv = "ASSERTIONS_UPDATE_GOLDEN"
+
+// updateGolden reports whether golden files should be rewritten. Besides UpdateGoldenEnv this honours
+// an -update flag defined by the test binary, the package does not define the flag itself so that it
+// cannot collide with one that already exists
+func updateGolden() bool {
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		update, _ := strconv.ParseBool(f.Value.String())
+		return update
+	}
+	return false
+}
+
+type goldenConfig struct {
+	compare         func(tb testing.TB, expected, input []byte)
+	keepLineEndings bool
+}
+
+// GoldenOption configures Golden
+type GoldenOption func(*goldenConfig)
+
+// GoldenCompare replaces the byte comparison of Golden with compare, which is given the contents
+// of the golden file and the input and should fail tb if they do not match
+func GoldenCompare(compare func(tb testing.TB, expected, input []byte)) GoldenOption {
+	return func(c *goldenConfig) {
+		c.compare = compare
+	}
+}
+
+// GoldenKeepLineEndings compares text golden files with their line endings as written. By default \r\n
+// in text is treated as \n on both sides, so golden files survive checkouts that convert line endings.
+// Comparisons selected with GoldenCompare or GoldenImage are given the contents as written
+func GoldenKeepLineEndings() GoldenOption {
+	return func(c *goldenConfig) {
+		c.keepLineEndings = true
+	}
+}
+
+// GoldenImage compares golden files as encoded images, using ImagesEqual with the given tolerances.
+// The golden file and input may be PNG or any other format registered with the image package
+func GoldenImage(perPixelTolerance, maxDifferingPixels int) GoldenOption {
+	return GoldenCompare(func(tb testing.TB, expected, input []byte) {
+		const invalidFormat = "Image could not be decoded\n ~ %v\n > error: %v\n"
+
+		ei, _, err := image.Decode(bytes.NewReader(expected))
+		if err != nil {
+			errorfNow(tb, invalidFormat, "golden file", err)
+			return
+		}
+		ii, _, err := image.Decode(bytes.NewReader(input))
+		if err != nil {
+			errorfNow(tb, invalidFormat, "input", err)
+			return
+		}
+
+		ImagesEqual(tb, ei, ii, perPixelTolerance, maxDifferingPixels)
+	})
+}
+
+// contentsDifference describes how input differs from expected, as a line diff for text and by the offset
+// of the first difference otherwise. It returns an empty string when they are equal
+func contentsDifference(expected, input []byte) string {
+	if bytes.Equal(expected, input) {
+		return ""
+	}
+	if utf8.Valid(expected) && utf8.Valid(input) {
+		return lineDiff(string(expected), string(input))
+	}
+	return fmt.Sprintf(" ~ %v\n", describeBytesDifference(expected, input))
+}
+
+// goldenBytesEqual is the default comparison of Golden
+func goldenBytesEqual(tb testing.TB, expected, input []byte) {
+	const failureFormat = "Input does not match the golden file\n%v"
+
+	if diff := contentsDifference(expected, input); diff != "" {
+		errorfNow(tb, failureFormat, diff)
+		return
+	}
+}
+
+// Golden asserts that input matches the contents of the golden file at path, conventionally under testdata.
+// Text is compared line by line treating \r\n as \n, see GoldenKeepLineEndings, and other contents byte by byte.
+// GoldenImage and GoldenCompare select comparisons that understand the format. Running the tests with the
+// ASSERTIONS_UPDATE_GOLDEN environment variable set, or with -update when the test binary defines that flag,
+// writes input to path instead
+func Golden(tb testing.TB, path string, input []byte, opts ...GoldenOption) {
+	const updateErrorFormat = "Golden file could not be updated\n ~ %v\n > error: %v\n"
+	const updatedFormat = "updated golden file %v\n"
+	const missingFormat = "Golden file does not exist\n ~ %v\n ~ set %v=1 to create it\n"
+	const readErrorFormat = "Golden file could not be read\n ~ %v\n > error: %v\n"
+
+	summary.recordAssertion()
+
+	var cfg goldenConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			errorfNow(tb, updateErrorFormat, path, err)
+			return
+		}
+		if err := os.WriteFile(path, input, 0o644); err != nil {
+			errorfNow(tb, updateErrorFormat, path, err)
+			return
+		}
+		tb.Logf(updatedFormat, path)
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		errorfNow(tb, missingFormat, path, UpdateGoldenEnv)
+		return
+	}
+	if err != nil {
+		errorfNow(tb, readErrorFormat, path, err)
+		return
+	}
+
+	if cfg.compare != nil {
+		cfg.compare(tb, expected, input)
+		return
+	}
+
+	// Binary contents are never normalized, \r\n is meaningful there
+	if !cfg.keepLineEndings && utf8.Valid(expected) && utf8.Valid(input) {
+		expected = []byte(normalizeLineEndings(string(expected)))
+		input = []byte(normalizeLineEndings(string(input)))
+	}
+	goldenBytesEqual(tb, expected, input)
+}
@@ -0,0 +1,91 @@
+package assertions
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img *image.NRGBA) []byte {
+	var buf bytes.Buffer
+	NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestGolden(t *testing.T) {
+	t.Setenv(DumpDirEnv, t.TempDir())
+	dir := t.TempDir()
+
+	write := func(name string, contents []byte) string {
+		path := filepath.Join(dir, name)
+		NoError(t, os.WriteFile(path, contents, 0o644))
+		return path
+	}
+
+	text := write("report.golden", []byte("total: 3\nfailed: 0\n"))
+	binary := write("blob.golden", []byte{0, 1, 2, 3})
+	crlf := write("crlf.golden", []byte{0xff, '\r', '\n'})
+	img := write("chart.png", encodePNG(t, filledImage(2, 2, color.Black)))
+
+	cases := []struct {
+		name     string
+		path     string
+		input    []byte
+		opts     []GoldenOption
+		mustFail bool
+	}{
+		{name: "text match", path: text, input: []byte("total: 3\nfailed: 0\n"), mustFail: false},
+		{name: "text mismatch", path: text, input: []byte("total: 3\nfailed: 1\n"), mustFail: true},
+		{name: "windows line endings", path: text, input: []byte("total: 3\r\nfailed: 0\r\n"), mustFail: false},
+		{name: "keep line endings", path: text, input: []byte("total: 3\r\nfailed: 0\r\n"), opts: []GoldenOption{GoldenKeepLineEndings()}, mustFail: true},
+		{name: "binary line endings", path: crlf, input: []byte{0xff, '\n'}, mustFail: true},
+		{name: "binary match", path: binary, input: []byte{0, 1, 2, 3}, mustFail: false},
+		{name: "binary mismatch", path: binary, input: []byte{0, 1, 2, 4}, mustFail: true},
+		{name: "missing file", path: filepath.Join(dir, "missing.golden"), input: nil, mustFail: true},
+		{
+			name:     "image within tolerance",
+			path:     img,
+			input:    encodePNG(t, filledImage(2, 2, color.NRGBA{R: 3, A: 255})),
+			opts:     []GoldenOption{GoldenImage(5, 0)},
+			mustFail: false,
+		},
+		{
+			name:     "image outside tolerance",
+			path:     img,
+			input:    encodePNG(t, filledImage(2, 2, color.White)),
+			opts:     []GoldenOption{GoldenImage(5, 0)},
+			mustFail: true,
+		},
+		{
+			name:     "image not decodable",
+			path:     img,
+			input:    []byte("not an image"),
+			opts:     []GoldenOption{GoldenImage(5, 0)},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Golden(tb, tc.path, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	t.Setenv(UpdateGoldenEnv, "1")
+	path := filepath.Join(t.TempDir(), "testdata", "new.golden")
+
+	Golden(t, path, []byte("created\n"))
+
+	contents, err := os.ReadFile(path)
+	NoError(t, err)
+	Equal(t, "created\n", string(contents))
+}
@@ -0,0 +1,8 @@
+module github.com/jcopi/assertions/gonum
+
+go 1.23.0
+
+require (
+	github.com/jcopi/assertions v0.1.0
+	gonum.org/v1/gonum v0.15.1
+)
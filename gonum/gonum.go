@@ -0,0 +1,47 @@
+// Package gonum provides tolerance based assertions for gonum matrices and vectors, reporting failures
+// as the assertions package does for [][]float64 and []float64. Slices of float32 need no adapter,
+// assertions.EqualMatrix and assertions.VectorWithin accept them directly.
+// It is a separate module so the assertions package stays free of dependencies
+package gonum
+
+import (
+	"testing"
+
+	"github.com/jcopi/assertions"
+	"gonum.org/v1/gonum/mat"
+)
+
+// rows copies the elements of m into a slice of rows
+func rows(m mat.Matrix) [][]float64 {
+	r, c := m.Dims()
+	out := make([][]float64, r)
+	for i := range out {
+		out[i] = make([]float64, c)
+		for j := range out[i] {
+			out[i][j] = m.At(i, j)
+		}
+	}
+	return out
+}
+
+// components copies the elements of v into a slice
+func components(v mat.Vector) []float64 {
+	out := make([]float64, v.Len())
+	for i := range out {
+		out[i] = v.AtVec(i)
+	}
+	return out
+}
+
+// EqualMatrix asserts that expected and input have the same dimensions and that every element of input is
+// within tolerance of the same element of expected, as by assertions.EqualMatrix. Any mat.Matrix may be
+// compared, e.g. a *mat.Dense against a *mat.SymDense
+func EqualMatrix(tb testing.TB, expected, input mat.Matrix, tolerance float64) {
+	assertions.EqualMatrix(tb, rows(expected), rows(input), tolerance)
+}
+
+// VectorWithin asserts that expected and input have the same length and that every element of input is
+// within tolerance of the same element of expected, as by assertions.VectorWithin
+func VectorWithin(tb testing.TB, expected, input mat.Vector, tolerance float64) {
+	assertions.VectorWithin(tb, components(expected), components(input), tolerance)
+}
@@ -0,0 +1,70 @@
+package gonum
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+func TestEqualMatrix(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected mat.Matrix
+		input    mat.Matrix
+		mustFail bool
+	}{
+		{name: "equal", expected: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), input: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), mustFail: false},
+		{name: "within", expected: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), input: mat.NewDense(2, 2, []float64{1, 2.05, 3, 4}), mustFail: false},
+		{name: "outside", expected: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), input: mat.NewDense(2, 2, []float64{1, 2, 3, 5}), mustFail: true},
+		{name: "different dimensions", expected: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), input: mat.NewDense(1, 4, []float64{1, 2, 3, 4}), mustFail: true},
+		{name: "transposed", expected: mat.NewDense(2, 2, []float64{1, 2, 3, 4}), input: mat.NewDense(2, 2, []float64{1, 3, 2, 4}).T(), mustFail: false},
+		{name: "symmetric", expected: mat.NewDense(2, 2, []float64{1, 2, 2, 1}), input: mat.NewSymDense(2, []float64{1, 2, 2, 1}), mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			EqualMatrix(tb, tc.expected, tc.input, 0.1)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
+
+func TestVectorWithin(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected mat.Vector
+		input    mat.Vector
+		mustFail bool
+	}{
+		{name: "within", expected: mat.NewVecDense(3, []float64{1, 2, 3}), input: mat.NewVecDense(3, []float64{1, 2.05, 3}), mustFail: false},
+		{name: "outside", expected: mat.NewVecDense(3, []float64{1, 2, 3}), input: mat.NewVecDense(3, []float64{1, 2.5, 3}), mustFail: true},
+		{name: "different lengths", expected: mat.NewVecDense(3, []float64{1, 2, 3}), input: mat.NewVecDense(2, []float64{1, 2}), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			VectorWithin(tb, tc.expected, tc.input, 0.1)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package assertions
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// GoroutineTB is a testing.TB that may be used from goroutines other than the
+// one running the test. Failures, skips and cleanup functions are recorded
+// rather than passed on and are replayed on the parent TB when Wait is called
+// from the test goroutine.
+type GoroutineTB struct {
+	testing.TB
+
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	logs     []goroutineLog
+	cleanups []func()
+	failed   bool
+	skipped  bool
+}
+
+var _ testing.TB = &GoroutineTB{}
+
+// goroutineLog is a message recorded by a GoroutineTB, warnings are replayed as warnings
+type goroutineLog struct {
+	message string
+	warning bool
+}
+
+// replayLogs logs messages recorded by a GoroutineTB on tb
+func replayLogs(tb testing.TB, logs []goroutineLog) {
+	warn := warnerOf(tb)
+	for _, l := range logs {
+		if l.warning && warn != nil {
+			warn(l.message)
+			continue
+		}
+		tb.Log(l.message)
+	}
+}
+
+// Go returns a GoroutineTB wrapping tb. Assertions made against the returned
+// TB are safe to call from any goroutine, their failures are reported on tb
+// once Wait is called.
+func Go(tb testing.TB) *GoroutineTB {
+	return &GoroutineTB{TB: tb}
+}
+
+// Go runs fn in a new goroutine tracked by g. Wait blocks until every
+// goroutine started this way has returned.
+func (g *GoroutineTB) Go(fn func(tb testing.TB)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn(g)
+	}()
+}
+
+// Wait blocks until all goroutines started with Go have returned, then
+// replays any recorded logs, cleanup functions, failures and skips on the
+// parent TB. A failure takes precedence over a skip. Wait must be called from
+// the test goroutine, after it returns g may be used for further goroutines.
+func (g *GoroutineTB) Wait() {
+	g.wg.Wait()
+
+	g.mu.Lock()
+	logs, cleanups, failed, skipped := g.logs, g.cleanups, g.failed, g.skipped
+	g.logs, g.cleanups, g.failed, g.skipped = nil, nil, false, false
+	g.mu.Unlock()
+
+	replayLogs(g.TB, logs)
+	for _, fn := range cleanups {
+		g.TB.Cleanup(fn)
+	}
+
+	switch {
+	case failed:
+		g.TB.FailNow()
+	case skipped:
+		g.TB.SkipNow()
+	}
+}
+
+func (g *GoroutineTB) record(msg string, fail bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if msg != "" {
+		g.logs = append(g.logs, goroutineLog{message: msg})
+	}
+	if fail {
+		g.failed = true
+	}
+}
+
+// warnFunc implements warner. Warnings are recorded and replayed by Wait when the parent TB warns
+func (g *GoroutineTB) warnFunc() func(message string) {
+	if warnerOf(g.TB) == nil {
+		return nil
+	}
+	return func(message string) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		g.logs = append(g.logs, goroutineLog{message: message, warning: true})
+	}
+}
+
+// skip records msg and a skip, then stops the calling goroutine
+func (g *GoroutineTB) skip(msg string) {
+	g.record(msg, false)
+
+	g.mu.Lock()
+	g.skipped = true
+	g.mu.Unlock()
+
+	runtime.Goexit()
+}
+
+// Cleanup implements testing.TB. fn is registered on the parent TB by Wait.
+func (g *GoroutineTB) Cleanup(fn func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cleanups = append(g.cleanups, fn)
+}
+
+// Error implements testing.TB.
+func (g *GoroutineTB) Error(args ...any) {
+	g.record(fmt.Sprint(args...), true)
+}
+
+// Errorf implements testing.TB.
+func (g *GoroutineTB) Errorf(format string, args ...any) {
+	g.record(fmt.Sprintf(format, args...), true)
+}
+
+// Fail implements testing.TB.
+func (g *GoroutineTB) Fail() {
+	g.record("", true)
+}
+
+// FailNow implements testing.TB. The calling goroutine is stopped with
+// runtime.Goexit, the failure itself is reported by Wait.
+func (g *GoroutineTB) FailNow() {
+	g.record("", true)
+	runtime.Goexit()
+}
+
+// Failed implements testing.TB.
+func (g *GoroutineTB) Failed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.failed || g.TB.Failed()
+}
+
+// Fatal implements testing.TB.
+func (g *GoroutineTB) Fatal(args ...any) {
+	g.record(fmt.Sprint(args...), true)
+	runtime.Goexit()
+}
+
+// Fatalf implements testing.TB.
+func (g *GoroutineTB) Fatalf(format string, args ...any) {
+	g.record(fmt.Sprintf(format, args...), true)
+	runtime.Goexit()
+}
+
+// Skip implements testing.TB. The calling goroutine is stopped with
+// runtime.Goexit, the test is skipped by Wait.
+func (g *GoroutineTB) Skip(args ...any) {
+	g.skip(fmt.Sprint(args...))
+}
+
+// SkipNow implements testing.TB.
+func (g *GoroutineTB) SkipNow() {
+	g.skip("")
+}
+
+// Skipf implements testing.TB.
+func (g *GoroutineTB) Skipf(format string, args ...any) {
+	g.skip(fmt.Sprintf(format, args...))
+}
+
+// Skipped implements testing.TB.
+func (g *GoroutineTB) Skipped() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.skipped || g.TB.Skipped()
+}
+
+// Log implements testing.TB.
+func (g *GoroutineTB) Log(args ...any) {
+	g.record(fmt.Sprint(args...), false)
+}
+
+// Logf implements testing.TB.
+func (g *GoroutineTB) Logf(format string, args ...any) {
+	g.record(fmt.Sprintf(format, args...), false)
+}
+
+type concurrentConfig struct {
+	gosched bool
+}
+
+// ConcurrentOption configures RunConcurrently
+type ConcurrentOption func(*concurrentConfig)
+
+// WithGosched makes every goroutine started by RunConcurrently yield with
+// runtime.Gosched before running, shuffling the order in which they start.
+func WithGosched() ConcurrentOption {
+	return func(c *concurrentConfig) {
+		c.gosched = true
+	}
+}
+
+type goroutinePanic struct {
+	index int
+	msg   any
+	stack string
+}
+
+// RunConcurrently runs fn in n goroutines, passing each its index in [0, n),
+// and waits for all of them to return. The goroutines are released together
+// to maximize overlap, which pairs well with the -race flag.
+// A panic in any goroutine is recovered and reported as a failure.
+func RunConcurrently(tb testing.TB, n int, fn func(i int), opts ...ConcurrentOption) {
+	const failureFormat = "%v of %v goroutines panicked\n"
+	const panicFormat = " > goroutine %v: %#v\n > stack: %v\n"
+
+	summary.recordAssertion()
+
+	var cfg concurrentConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		panics  []goroutinePanic
+		release = make(chan struct{})
+	)
+
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-release
+
+			if cfg.gosched {
+				runtime.Gosched()
+			}
+
+			panicked, msg, stack := panicHandler(func() { fn(i) })
+			if panicked {
+				mu.Lock()
+				panics = append(panics, goroutinePanic{index: i, msg: msg, stack: stack})
+				mu.Unlock()
+			}
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+
+	if len(panics) > 0 {
+		for _, p := range panics {
+			tb.Logf(panicFormat, p.index, p.msg, p.stack)
+		}
+		errorfNow(tb, failureFormat, len(panics), n)
+		return
+	}
+}
+
+// ConcurrentReadSafe runs fn while other goroutines repeatedly read every part of values, such as
+// maps and slices shared with goroutines started by fn. Unsynchronized writes to values then race
+// with those reads, which the -race flag reports. Without it, values are compared with copies taken
+// before fn was called and any modification is reported as a failure
+func ConcurrentReadSafe(tb testing.TB, fn func(), values ...any) {
+	const failureFormat = "Values were modified while being read concurrently\n%v"
+
+	summary.recordAssertion()
+
+	snapshots := make([]any, len(values))
+	for i, v := range values {
+		snapshots[i] = Clone(v)
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		diffs = make([][]difference, len(values))
+		done  = make(chan struct{})
+	)
+
+	for i := range values {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if d := diffValues(fmt.Sprintf("values[%v]", i), snapshots[i], values[i]); len(d) > 0 {
+					mu.Lock()
+					diffs[i] = d
+					mu.Unlock()
+					return
+				}
+
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+	}
+
+	fn()
+	close(done)
+	wg.Wait()
+
+	var all []difference
+	for i, d := range diffs {
+		// Modifications made after the last read are only seen by comparing once more
+		if len(d) == 0 {
+			d = diffValues(fmt.Sprintf("values[%v]", i), snapshots[i], values[i])
+		}
+		all = append(all, d...)
+	}
+
+	if len(all) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(all))
+		return
+	}
+}
@@ -0,0 +1,215 @@
+package assertions
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestGoroutineTB(t *testing.T) {
+	cases := []struct {
+		name     string
+		workers  []func(tb testing.TB)
+		mustFail bool
+	}{
+		{
+			name:     "no workers",
+			workers:  nil,
+			mustFail: false,
+		},
+		{
+			name: "passing workers",
+			workers: []func(tb testing.TB){
+				func(tb testing.TB) { NoError(tb, nil) },
+				func(tb testing.TB) { Equal(tb, 1, 1) },
+			},
+			mustFail: false,
+		},
+		{
+			name: "one failing worker",
+			workers: []func(tb testing.TB){
+				func(tb testing.TB) { NoError(tb, nil) },
+				func(tb testing.TB) { NoError(tb, errors.New("error")) },
+			},
+			mustFail: true,
+		},
+		{
+			name: "failure stops the worker",
+			workers: []func(tb testing.TB){
+				func(tb testing.TB) {
+					Error(tb, nil)
+					panic("unreachable")
+				},
+			},
+			mustFail: true,
+		},
+		{
+			name: "non fatal failure",
+			workers: []func(tb testing.TB){
+				func(tb testing.TB) { tb.Errorf("error") },
+			},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			g := Go(tb)
+			for _, worker := range tc.workers {
+				g.Go(worker)
+			}
+			g.Wait()
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestGoroutineTBReuse(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	g := Go(tb)
+	g.Go(func(tb testing.TB) { tb.Errorf("first") })
+	g.Wait()
+	tb.AssertExpectation()
+	Equal(t, false, g.failed)
+
+	g.Go(func(tb testing.TB) { tb.Logf("second") })
+	g.Wait()
+	Equal(t, []string{"first", "second"}, tb.logs)
+}
+
+func TestGoroutineTBSkip(t *testing.T) {
+	var sub *testing.T
+	reached, cleaned := false, false
+	t.Run("skipped", func(t *testing.T) {
+		sub = t
+		g := Go(t)
+		g.Go(func(tb testing.TB) {
+			tb.Cleanup(func() { cleaned = true })
+			tb.Skip("not supported")
+			panic("unreachable")
+		})
+		g.Wait()
+		reached = true
+	})
+
+	Equal(t, true, sub.Skipped())
+	Equal(t, false, sub.Failed())
+	Equal(t, false, reached)
+	Equal(t, true, cleaned)
+}
+
+func TestRunConcurrently(t *testing.T) {
+	cases := []struct {
+		name     string
+		n        int
+		fn       func(i int)
+		opts     []ConcurrentOption
+		mustFail bool
+	}{
+		{
+			name:     "no goroutines",
+			n:        0,
+			fn:       func(i int) { panic(i) },
+			mustFail: false,
+		},
+		{
+			name:     "no panics",
+			n:        8,
+			fn:       func(i int) {},
+			mustFail: false,
+		},
+		{
+			name:     "gosched",
+			n:        8,
+			fn:       func(i int) {},
+			opts:     []ConcurrentOption{WithGosched()},
+			mustFail: false,
+		},
+		{
+			name: "one panic",
+			n:    8,
+			fn: func(i int) {
+				if i == 3 {
+					panic("three")
+				}
+			},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			RunConcurrently(tb, tc.n, tc.fn, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestRunConcurrentlyRunsAll(t *testing.T) {
+	const n = 16
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	RunConcurrently(t, n, func(i int) {
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+	})
+
+	Equal(t, n, len(seen))
+}
+
+func TestConcurrentReadSafe(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   func() []any
+		fn       func(values []any)
+		racy     bool
+		mustFail bool
+	}{
+		{
+			name:     "no values",
+			values:   func() []any { return nil },
+			fn:       func(values []any) {},
+			mustFail: false,
+		},
+		{
+			name:   "concurrent readers",
+			values: func() []any { return []any{map[string]int{"a": 1}, []int{1, 2}} },
+			fn: func(values []any) {
+				RunConcurrently(t, 4, func(i int) {
+					_ = values[0].(map[string]int)["a"]
+					_ = values[1].([]int)[i%2]
+				})
+			},
+			mustFail: false,
+		},
+		{
+			name:     "modified",
+			values:   func() []any { return []any{[]int{1, 2}} },
+			fn:       func(values []any) { values[0].([]int)[1] = 3 },
+			racy:     true,
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.racy && raceEnabled {
+				t.Skip("the race detector reports the modification itself")
+			}
+
+			tb := NewTester(t, tc.mustFail)
+
+			values := tc.values()
+			ConcurrentReadSafe(tb, func() { tc.fn(values) }, values...)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package assertions
+
+import (
+	"go/format"
+	"testing"
+)
+
+// GoSourceEqual asserts that expected and input are the same Go source once both are formatted with gofmt,
+// so generated code may be compared without regard to whitespace, alignment or the order of imports.
+// Both may be whole files or lists of declarations or statements, as accepted by go/format.
+// Failing results print a line diff of the formatted sources
+func GoSourceEqual(tb testing.TB, expected, input string) {
+	const invalidFormat = "Go source could not be parsed\n ~ %v\n > error: %v\n"
+	const failureFormat = "Go sources are not equal\n%v"
+
+	summary.recordAssertion()
+
+	fe, err := format.Source([]byte(expected))
+	if err != nil {
+		errorfNow(tb, invalidFormat, "expected", err)
+		return
+	}
+	fi, err := format.Source([]byte(input))
+	if err != nil {
+		errorfNow(tb, invalidFormat, "input", err)
+		return
+	}
+
+	if diff := lineDiff(string(fe), string(fi)); diff != "" {
+		errorfNow(tb, failureFormat, diff)
+		return
+	}
+}
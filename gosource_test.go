@@ -0,0 +1,64 @@
+package assertions
+
+import "testing"
+
+func TestGoSourceEqual(t *testing.T) {
+	const source = `package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "hello")
+}
+`
+
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		mustFail bool
+	}{
+		{
+			name:     "identical",
+			expected: source,
+			input:    source,
+			mustFail: false,
+		},
+		{
+			name:     "whitespace and import order",
+			expected: source,
+			input:    "package main\nimport (\n\"os\"\n\"fmt\"\n)\nfunc main() {\n    fmt.Fprintln( os.Stderr,\"hello\" )\n}\n",
+			mustFail: false,
+		},
+		{
+			name:     "different code",
+			expected: source,
+			input:    "package main\nimport (\n\"fmt\"\n\"os\"\n)\nfunc main() { fmt.Fprintln(os.Stdout, \"hello\") }\n",
+			mustFail: true,
+		},
+		{
+			name:     "statements",
+			expected: "x := 1\ny := x",
+			input:    "x:=1\ny:=x",
+			mustFail: false,
+		},
+		{
+			name:     "invalid input",
+			expected: source,
+			input:    "package main\nfunc {",
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			GoSourceEqual(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package assertions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// graphQLResponse is the standard envelope of a GraphQL response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type graphQLError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+// code returns the error code in the extensions of e, if any
+func (e graphQLError) code() string {
+	code, _ := e.Extensions["code"].(string)
+	return code
+}
+
+func decodeGraphQL(body []byte) (graphQLResponse, error) {
+	var resp graphQLResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, err
+	}
+	if resp.Data == nil {
+		resp.Data = json.RawMessage("null")
+	}
+	return resp, nil
+}
+
+// formatGraphQLErrors lists errors with their paths and codes
+func formatGraphQLErrors(errs []graphQLError) string {
+	var b strings.Builder
+	for _, e := range errs {
+		fmt.Fprintf(&b, " ~ %q", e.Message)
+		if len(e.Path) > 0 {
+			path := make([]string, len(e.Path))
+			for i, p := range e.Path {
+				path[i] = fmt.Sprint(p)
+			}
+			fmt.Fprintf(&b, " at %v", strings.Join(path, "."))
+		}
+		if code := e.code(); code != "" {
+			fmt.Fprintf(&b, ", code %v", code)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// GraphQLNoErrors asserts that body is a GraphQL response without errors. Failing results print the message,
+// path and code of every error
+func GraphQLNoErrors(tb testing.TB, body []byte) {
+	const invalidFormat = "Body is not a GraphQL response\n < error: %v\n"
+	const failureFormat = "GraphQL response has errors\n%v"
+
+	summary.recordAssertion()
+
+	resp, err := decodeGraphQL(body)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	if len(resp.Errors) > 0 {
+		errorfNow(tb, failureFormat, formatGraphQLErrors(resp.Errors))
+		return
+	}
+}
+
+// GraphQLDataEq asserts that the data of the GraphQL response body is equivalent to expectedJSON, as by JSONEq
+// with opts. Failing results print the path of every differing value and the errors of the response, if any
+func GraphQLDataEq(tb testing.TB, expectedJSON string, body []byte, opts ...JSONOption) {
+	const invalidFormat = "Body is not a GraphQL response\n < error: %v\n"
+	const compareFormat = "GraphQL data could not be compared\n > error: %v\n"
+	const failureFormat = "GraphQL data is not equal\n%v"
+	const errorsFormat = "GraphQL data is not equal\n%v ~ the response has errors:\n%v"
+
+	summary.recordAssertion()
+
+	resp, err := decodeGraphQL(body)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	diffs, err := jsonDifferences([]byte(expectedJSON), resp.Data, opts...)
+	if err != nil {
+		errorfNow(tb, compareFormat, err)
+		return
+	}
+	if len(diffs) > 0 && len(resp.Errors) > 0 {
+		errorfNow(tb, errorsFormat, formatDifferences(diffs), formatGraphQLErrors(resp.Errors))
+		return
+	}
+	if len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(diffs))
+		return
+	}
+}
+
+// GraphQLErrorCode asserts that the GraphQL response body has an error whose extensions code is code,
+// such as "UNAUTHENTICATED". Failing results print the errors the response has
+func GraphQLErrorCode(tb testing.TB, body []byte, code string) {
+	const invalidFormat = "Body is not a GraphQL response\n < error: %v\n"
+	const noErrorsFormat = "GraphQL response has no errors\n > code: %v\n"
+	const failureFormat = "GraphQL response has no error with the code\n > code: %v\n < errors:\n%v"
+
+	summary.recordAssertion()
+
+	resp, err := decodeGraphQL(body)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	if len(resp.Errors) == 0 {
+		errorfNow(tb, noErrorsFormat, code)
+		return
+	}
+	for _, e := range resp.Errors {
+		if e.code() == code {
+			return
+		}
+	}
+	errorfNow(tb, failureFormat, code, formatGraphQLErrors(resp.Errors))
+}
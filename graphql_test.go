@@ -0,0 +1,89 @@
+package assertions
+
+import (
+	"testing"
+)
+
+const (
+	graphQLData   = `{"data": {"user": {"id": "1", "name": "ada", "roles": ["admin"]}}}`
+	graphQLFailed = `{"data": {"user": null}, "errors": [{"message": "not signed in", "path": ["user"], "extensions": {"code": "UNAUTHENTICATED"}}]}`
+)
+
+func TestGraphQLNoErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		mustFail bool
+	}{
+		{name: "data", body: graphQLData, mustFail: false},
+		{name: "empty errors", body: `{"data": {}, "errors": []}`, mustFail: false},
+		{name: "errors", body: graphQLFailed, mustFail: true},
+		{name: "not json", body: `<html>`, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			GraphQLNoErrors(tb, []byte(tc.body))
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestGraphQLDataEq(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		body     string
+		opts     []JSONOption
+		mustFail bool
+	}{
+		{name: "equal", expected: `{"user": {"name": "ada", "id": "1", "roles": ["admin"]}}`, body: graphQLData, mustFail: false},
+		{name: "different", expected: `{"user": {"name": "grace", "id": "1", "roles": ["admin"]}}`, body: graphQLData, mustFail: true},
+		{name: "subset", expected: `{"user": {"name": "ada"}}`, body: graphQLData, opts: []JSONOption{JSONSubset()}, mustFail: false},
+		{name: "null data", expected: `{"user": null}`, body: graphQLFailed, mustFail: false},
+		{name: "missing data", expected: `null`, body: `{"errors": [{"message": "syntax error"}]}`, mustFail: false},
+		{name: "invalid expected", expected: `{`, body: graphQLData, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			GraphQLDataEq(tb, tc.expected, []byte(tc.body), tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestGraphQLDataEqMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	GraphQLDataEq(tb, `{"user": {"id": "1"}}`, []byte(graphQLFailed))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"GraphQL data is not equal\n ~ [\"user\"]:\n   > expected: map[string]interface {}{\"id\":\"1\"}\n   < input:    nil\n" +
+		" ~ the response has errors:\n ~ \"not signed in\" at user, code UNAUTHENTICATED\n"}, tb.logs)
+}
+
+func TestGraphQLErrorCode(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		code     string
+		mustFail bool
+	}{
+		{name: "has code", body: graphQLFailed, code: "UNAUTHENTICATED", mustFail: false},
+		{name: "other code", body: graphQLFailed, code: "FORBIDDEN", mustFail: true},
+		{name: "no errors", body: graphQLData, code: "UNAUTHENTICATED", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			GraphQLErrorCode(tb, []byte(tc.body), tc.code)
+			tb.AssertExpectation()
+		})
+	}
+}
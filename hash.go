@@ -0,0 +1,101 @@
+package assertions
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"testing"
+)
+
+// hashAlgorithms are the algorithms HashEqual and HMACValid accept, by the names used in files such as SHA256SUMS
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// lookupHash returns the hash named algo, which is case-insensitive and may contain a dash as in "SHA-256"
+func lookupHash(algo string) (func() hash.Hash, bool) {
+	h, ok := hashAlgorithms[strings.ReplaceAll(strings.ToLower(algo), "-", "")]
+	return h, ok
+}
+
+// redactSecret prints a secret by its length and a short fingerprint, enough to tell two secrets apart
+// without their values appearing in test logs
+func redactSecret(secret []byte) string {
+	sum := crypto.SHA256.New()
+	sum.Write(secret)
+	return fmt.Sprintf("<redacted %v bytes, sha256 %x…>", len(secret), sum.Sum(nil)[:4])
+}
+
+// HashEqual asserts that the algo digest of data is expectedHex, e.g. HashEqual(t, "sha256", want, data).
+// The algorithms are md5, sha1, sha224, sha256, sha384 and sha512, and expectedHex is case-insensitive
+func HashEqual(tb testing.TB, algo, expectedHex string, data []byte) {
+	const algoFormat = "Hash algorithm is not supported\n < algorithm: %q\n"
+	const failureFormat = "Hashes are not equal\n ~ algorithm: %v\n > expected:  %v\n < input:     %x\n ~ data:      %v bytes\n"
+
+	summary.recordAssertion()
+
+	newHash, ok := lookupHash(algo)
+	if !ok {
+		errorfNow(tb, algoFormat, algo)
+		return
+	}
+	h := newHash()
+	h.Write(data)
+	if sum := h.Sum(nil); !strings.EqualFold(hex.EncodeToString(sum), expectedHex) {
+		errorfNow(tb, failureFormat, algo, strings.ToLower(expectedHex), sum, len(data))
+		return
+	}
+}
+
+// HMACValid asserts that macHex is the algo HMAC of data under key, with the algorithms of HashEqual.
+// The key is never printed and the computed MAC only as a fingerprint, so failures leak neither
+func HMACValid(tb testing.TB, algo string, key, data []byte, macHex string) {
+	const algoFormat = "HMAC algorithm is not supported\n < algorithm: %q\n"
+	const hexFormat = "HMAC is not hex\n < error: %v\n"
+	const failureFormat = "HMAC is not valid\n ~ algorithm: %v\n ~ key:       %v\n > expected:  %v\n < input:     %v\n"
+
+	summary.recordAssertion()
+
+	newHash, ok := lookupHash(algo)
+	if !ok {
+		errorfNow(tb, algoFormat, algo)
+		return
+	}
+	mac, err := hex.DecodeString(macHex)
+	if err != nil {
+		errorfNow(tb, hexFormat, err)
+		return
+	}
+	h := hmac.New(newHash, key)
+	h.Write(data)
+	if sum := h.Sum(nil); !hmac.Equal(sum, mac) {
+		errorfNow(tb, failureFormat, algo, redactSecret(key), redactSecret(sum), redactSecret(mac))
+		return
+	}
+}
+
+// ConstantTimeEqual asserts that the secrets expected and input are equal, comparing them in constant time
+// as production code must. Failing results print only their lengths and fingerprints, never their values
+func ConstantTimeEqual(tb testing.TB, expected, input []byte) {
+	const failureFormat = "Secrets are not equal\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	if subtle.ConstantTimeCompare(expected, input) != 1 {
+		errorfNow(tb, failureFormat, redactSecret(expected), redactSecret(input))
+		return
+	}
+}
@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestHashEqual(t *testing.T) {
+	data := []byte("hello world")
+
+	cases := []struct {
+		name     string
+		algo     string
+		expected string
+		mustFail bool
+	}{
+		{name: "sha256", algo: "sha256", expected: "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", mustFail: false},
+		{name: "upper case", algo: "SHA-256", expected: "B94D27B9934D3E08A52E52D7DA7DABFAC484EFE37A5380EE9088F7ACE2EFCDE9", mustFail: false},
+		{name: "md5", algo: "md5", expected: "5eb63bbbe01eeed093cb22bb8f5acdc3", mustFail: false},
+		{name: "sha1", algo: "sha1", expected: "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed", mustFail: false},
+		{name: "different", algo: "sha256", expected: "00", mustFail: true},
+		{name: "wrong algorithm", algo: "sha1", expected: "5eb63bbbe01eeed093cb22bb8f5acdc3", mustFail: true},
+		{name: "unsupported", algo: "crc32", expected: "0d4a1185", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			HashEqual(tb, tc.algo, tc.expected, data)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestHashEqualMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	HashEqual(tb, "md5", "00", []byte("hello world"))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Hashes are not equal\n ~ algorithm: md5\n > expected:  00\n < input:     5eb63bbbe01eeed093cb22bb8f5acdc3\n ~ data:      11 bytes\n"}, tb.logs)
+}
+
+func TestHMACValid(t *testing.T) {
+	key, data := []byte("key"), []byte("message")
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	mac := hex.EncodeToString(h.Sum(nil))
+
+	cases := []struct {
+		name     string
+		algo     string
+		key      []byte
+		mac      string
+		mustFail bool
+	}{
+		{name: "valid", algo: "sha256", key: key, mac: mac, mustFail: false},
+		{name: "other key", algo: "sha256", key: []byte("other"), mac: mac, mustFail: true},
+		{name: "other algorithm", algo: "sha512", key: key, mac: mac, mustFail: true},
+		{name: "not hex", algo: "sha256", key: key, mac: "zz", mustFail: true},
+		{name: "unsupported", algo: "crc32", key: key, mac: mac, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			HMACValid(tb, tc.algo, tc.key, data, tc.mac)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestConstantTimeEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []byte
+		input    []byte
+		mustFail bool
+	}{
+		{name: "equal", expected: []byte("s3cret"), input: []byte("s3cret"), mustFail: false},
+		{name: "different", expected: []byte("s3cret"), input: []byte("s3cres"), mustFail: true},
+		{name: "different length", expected: []byte("s3cret"), input: []byte("s3cre"), mustFail: true},
+		{name: "empty", expected: nil, input: []byte{}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ConstantTimeEqual(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestConstantTimeEqualRedacts(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	ConstantTimeEqual(tb, []byte("hunter2"), []byte("hunter3"))
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Secrets are not equal\n > expected: " + redactSecret([]byte("hunter2")) + "\n < input:    " + redactSecret([]byte("hunter3")) + "\n"}, tb.logs)
+}
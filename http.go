@@ -0,0 +1,161 @@
+package assertions
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// RecordedRequest is a copy of a request sent through an HTTPRecorder
+type RecordedRequest struct {
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+// HTTPRecorder is an http.RoundTripper that records every request sent through it and answers
+// with a canned response instead of making a network call
+type HTTPRecorder struct {
+	// Respond builds the response for each request, when nil an empty 200 OK response is returned
+	Respond func(req *http.Request) (*http.Response, error)
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+}
+
+var _ http.RoundTripper = &HTTPRecorder{}
+
+// NewHTTPRecorder returns an HTTPRecorder that answers every request with an empty 200 OK response
+func NewHTTPRecorder() *HTTPRecorder {
+	return &HTTPRecorder{}
+}
+
+// Client returns an http.Client that sends its requests through r
+func (r *HTTPRecorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *HTTPRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL,
+		Header: req.Header.Clone(),
+	}
+
+	// RoundTrip must not modify req, Respond is given a copy with the body that was read
+	forward := req
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body = body
+		forward = req.Clone(req.Context())
+		forward.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	r.mu.Lock()
+	r.requests = append(r.requests, recorded)
+	r.mu.Unlock()
+
+	if r.Respond != nil {
+		return r.Respond(forward)
+	}
+
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    forward,
+	}, nil
+}
+
+// Requests returns a copy of the requests recorded so far, in the order they were sent
+func (r *HTTPRecorder) Requests() []RecordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]RecordedRequest(nil), r.requests...)
+}
+
+// nthRequest returns the request at index n, failing if fewer requests were recorded
+func (r *HTTPRecorder) nthRequest(tb testing.TB, n int) (RecordedRequest, bool) {
+	const failureFormat = "Request was not recorded\n > expected index: %v\n < recorded:       %v requests\n"
+
+	requests := r.Requests()
+	if n < 0 || n >= len(requests) {
+		errorfNow(tb, failureFormat, n, len(requests))
+		return RecordedRequest{}, false
+	}
+
+	return requests[n], true
+}
+
+// RequestCount asserts that exactly expected requests have been recorded
+func (r *HTTPRecorder) RequestCount(tb testing.TB, expected int) {
+	const failureFormat = "Unexpected number of requests\n > expected: %v\n < recorded: %v\n"
+
+	summary.recordAssertion()
+
+	if count := len(r.Requests()); count != expected {
+		errorfNow(tb, failureFormat, expected, count)
+		return
+	}
+}
+
+// NthRequestHasHeader asserts that the request at index n, counting from 0, has a header key with the value expected
+// among its values
+func (r *HTTPRecorder) NthRequestHasHeader(tb testing.TB, n int, key, expected string) {
+	const failureFormat = "Request header does not match\n > request: %v %v %v\n > header:  %v\n > expected: %q\n < values:   %q\n"
+
+	summary.recordAssertion()
+
+	req, ok := r.nthRequest(tb, n)
+	if !ok {
+		return
+	}
+
+	values := req.Header.Values(key)
+	for _, v := range values {
+		if v == expected {
+			return
+		}
+	}
+
+	errorfNow(tb, failureFormat, n, req.Method, req.URL, key, expected, values)
+}
+
+// NthRequestBodyJSONEq asserts that the body of the request at index n, counting from 0, is JSON equivalent to expected.
+// Options relax the comparison as for JSONEq
+func (r *HTTPRecorder) NthRequestBodyJSONEq(tb testing.TB, n int, expected string, opts ...JSONOption) {
+	const invalidFormat = "Request body could not be compared\n > request: %v %v %v\n > error: %v\n"
+	const failureFormat = "Request body does not match\n > request: %v %v %v\n%v"
+
+	summary.recordAssertion()
+
+	req, ok := r.nthRequest(tb, n)
+	if !ok {
+		return
+	}
+
+	diffs, err := jsonDifferences([]byte(expected), req.Body, opts...)
+	if err != nil {
+		errorfNow(tb, invalidFormat, n, req.Method, req.URL, err)
+		return
+	}
+
+	if len(diffs) > 0 {
+		errorfNow(tb, failureFormat, n, req.Method, req.URL, formatDifferences(diffs))
+		return
+	}
+}
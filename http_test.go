@@ -0,0 +1,89 @@
+package assertions
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHTTPRecorder(t *testing.T) {
+	rec := NewHTTPRecorder()
+	client := rec.Client()
+
+	req, err := http.NewRequest(http.MethodPost, "http://example.test/users", strings.NewReader(`{"name": "x", "tags": ["a", "b"]}`))
+	NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("Accept", "text/plain")
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	NoError(t, err)
+	resp.Body.Close()
+	Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Get("http://example.test/users/1")
+	NoError(t, err)
+	resp.Body.Close()
+
+	cases := []struct {
+		name     string
+		assert   func(tb testing.TB)
+		mustFail bool
+	}{
+		{name: "count", assert: func(tb testing.TB) { rec.RequestCount(tb, 2) }, mustFail: false},
+		{name: "wrong count", assert: func(tb testing.TB) { rec.RequestCount(tb, 1) }, mustFail: true},
+		{name: "header", assert: func(tb testing.TB) { rec.NthRequestHasHeader(tb, 0, "Content-Type", "application/json") }, mustFail: false},
+		{name: "second header value", assert: func(tb testing.TB) { rec.NthRequestHasHeader(tb, 0, "Accept", "application/json") }, mustFail: false},
+		{name: "missing header", assert: func(tb testing.TB) { rec.NthRequestHasHeader(tb, 1, "Content-Type", "application/json") }, mustFail: true},
+		{name: "out of range", assert: func(tb testing.TB) { rec.NthRequestHasHeader(tb, 2, "Accept", "*/*") }, mustFail: true},
+		{name: "json body", assert: func(tb testing.TB) { rec.NthRequestBodyJSONEq(tb, 0, `{"tags": ["a", "b"], "name": "x"}`) }, mustFail: false},
+		{name: "json body differs", assert: func(tb testing.TB) { rec.NthRequestBodyJSONEq(tb, 0, `{"tags": ["b", "a"], "name": "x"}`) }, mustFail: true},
+		{name: "empty body", assert: func(tb testing.TB) { rec.NthRequestBodyJSONEq(tb, 1, `{}`) }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.assert(tb)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestHTTPRecorderRespond(t *testing.T) {
+	rec := NewHTTPRecorder()
+	rec.Respond = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTeapot, Body: http.NoBody, Request: req}, nil
+	}
+
+	resp, err := rec.Client().Get("http://example.test/")
+	NoError(t, err)
+	resp.Body.Close()
+
+	Equal(t, http.StatusTeapot, resp.StatusCode)
+	Equal(t, "/", rec.Requests()[0].URL.Path)
+}
+
+func TestHTTPRecorderDoesNotModifyRequest(t *testing.T) {
+	rec := NewHTTPRecorder()
+	var forwarded string
+	rec.Respond = func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		forwarded = string(body)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, err
+	}
+
+	body := io.NopCloser(strings.NewReader("payload"))
+	req, err := http.NewRequest(http.MethodPut, "http://example.test/", body)
+	NoError(t, err)
+
+	resp, err := rec.RoundTrip(req)
+	NoError(t, err)
+	resp.Body.Close()
+
+	Equal(t, true, req.Body == body)
+	Equal(t, "payload", forwarded)
+	Equal(t, []byte("payload"), rec.Requests()[0].Body)
+}
@@ -0,0 +1,46 @@
+package assertions
+
+import (
+	"testing"
+)
+
+// Idempotent asserts that fn succeeds both times it is called, as a migration or a reconciler must when
+// it runs again over its own result. Use IdempotentState to also assert the second run changes nothing
+func Idempotent(tb testing.TB, fn func() error) {
+	const failureFormat = "Operation failed on its %v run\n < error: %v\n"
+
+	summary.recordAssertion()
+
+	for _, run := range []string{"first", "second"} {
+		if err := fn(); err != nil {
+			errorfNow(tb, failureFormat, run, err)
+			return
+		}
+	}
+}
+
+// IdempotentState asserts that fn succeeds both times it is called and that the state observed by snapshot
+// after the second run is equal, as by Equal, to the state after the first. Failing results print the
+// differences made by the second run
+func IdempotentState[S any](tb testing.TB, fn func() error, snapshot func() S, opts ...CompareOption) {
+	const failedFormat = "Operation failed on its %v run\n < error: %v\n"
+	const failureFormat = "Second run changed the state\n%v"
+
+	summary.recordAssertion()
+
+	if err := fn(); err != nil {
+		errorfNow(tb, failedFormat, "first", err)
+		return
+	}
+	first := snapshot()
+	if err := fn(); err != nil {
+		errorfNow(tb, failedFormat, "second", err)
+		return
+	}
+
+	d := compareValues("", first, snapshot(), opts...)
+	if len(d.diffs) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(d.diffs)+d.stoppedNote())
+		return
+	}
+}
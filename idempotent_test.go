@@ -0,0 +1,79 @@
+package assertions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIdempotent(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func() func() error
+		mustFail bool
+	}{
+		{name: "always succeeds", fn: func() func() error { return func() error { return nil } }, mustFail: false},
+		{
+			name: "fails when run again",
+			fn: func() func() error {
+				created := false
+				return func() error {
+					if created {
+						return errors.New("table already exists")
+					}
+					created = true
+					return nil
+				}
+			},
+			mustFail: true,
+		},
+		{name: "fails at once", fn: func() func() error { return func() error { return errors.New("boom") } }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Idempotent(tb, tc.fn())
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestIdempotentState(t *testing.T) {
+	cases := []struct {
+		name     string
+		apply    func(state map[string]int)
+		mustFail bool
+	}{
+		{name: "sets", apply: func(state map[string]int) { state["replicas"] = 3 }, mustFail: false},
+		{name: "increments", apply: func(state map[string]int) { state["replicas"]++ }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := map[string]int{}
+			fn := func() error {
+				tc.apply(state)
+				return nil
+			}
+
+			tb := NewTester(t, tc.mustFail)
+			IdempotentState(tb, fn, func() map[string]int { return Clone(state) })
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestIdempotentStateMessage(t *testing.T) {
+	state := map[string]int{}
+	fn := func() error {
+		state["replicas"]++
+		return nil
+	}
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	IdempotentState(tb, fn, func() map[string]int { return Clone(state) })
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Second run changed the state\n ~ [\"replicas\"]:\n   > expected: 1\n   < input:    2\n"}, tb.logs)
+}
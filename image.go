@@ -0,0 +1,98 @@
+package assertions
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// channelDistance returns the largest difference between the 8 bit non-premultiplied channels of a and b
+func channelDistance(a, b color.Color) int {
+	ca, cb := color.NRGBAModel.Convert(a).(color.NRGBA), color.NRGBAModel.Convert(b).(color.NRGBA)
+	distance := 0
+	for _, d := range []int{
+		int(ca.R) - int(cb.R),
+		int(ca.G) - int(cb.G),
+		int(ca.B) - int(cb.B),
+		int(ca.A) - int(cb.A),
+	} {
+		distance = max(distance, d, -d)
+	}
+	return distance
+}
+
+func formatColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
+
+// imageDiff compares expected and input pixel by pixel, returning the differing pixels in red over a faded
+// gray copy of expected, the number of differing pixels and the first of them
+func imageDiff(expected, input image.Image, tolerance int) (*image.NRGBA, int, image.Point) {
+	bounds := expected.Bounds()
+	offset := input.Bounds().Min.Sub(bounds.Min)
+	diff := image.NewNRGBA(image.Rectangle{Max: bounds.Size()})
+
+	count := 0
+	first := image.Point{}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			e, i := expected.At(x, y), input.At(x+offset.X, y+offset.Y)
+			p := image.Point{X: x - bounds.Min.X, Y: y - bounds.Min.Y}
+
+			if channelDistance(e, i) > tolerance {
+				if count == 0 {
+					first = p
+				}
+				count++
+				diff.SetNRGBA(p.X, p.Y, color.NRGBA{R: 0xff, A: 0xff})
+				continue
+			}
+
+			gray := color.GrayModel.Convert(e).(color.Gray)
+			faded := 0xc0 + gray.Y/4
+			diff.SetNRGBA(p.X, p.Y, color.NRGBA{R: faded, G: faded, B: faded, A: 0xff})
+		}
+	}
+	return diff, count, first
+}
+
+func writeImageDump(tb testing.TB, img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return writeDump(tb, "diff.png", buf.Bytes())
+}
+
+// ImagesEqual asserts that expected and input have the same size and that at most maxDifferingPixels pixels
+// have a channel differing by more than perPixelTolerance, on a scale of 0 to 255.
+// On failure an image marking the differing pixels in red is written next to other failure dumps, see DumpDirEnv
+func ImagesEqual(tb testing.TB, expected, input image.Image, perPixelTolerance, maxDifferingPixels int) {
+	const sizeFormat = "Images are not the same size\n > expected: %v\n < input:    %v\n"
+	const failureFormat = "Images are not equal\n ~ %v of %v pixels differ by more than %v, at most %v may differ\n ~ first difference at %v\n > expected: %v\n < input:    %v\n ~ diff: %v\n"
+
+	summary.recordAssertion()
+
+	if expected.Bounds().Size() != input.Bounds().Size() {
+		errorfNow(tb, sizeFormat, expected.Bounds().Size(), input.Bounds().Size())
+		return
+	}
+
+	diff, count, first := imageDiff(expected, input, perPixelTolerance)
+	if count <= maxDifferingPixels {
+		return
+	}
+
+	path, err := writeImageDump(tb, diff)
+	if err != nil {
+		path = fmt.Sprintf("(not written: %v)", err)
+	}
+
+	e := expected.At(expected.Bounds().Min.X+first.X, expected.Bounds().Min.Y+first.Y)
+	i := input.At(input.Bounds().Min.X+first.X, input.Bounds().Min.Y+first.Y)
+	errorfNow(tb, failureFormat, count, diff.Bounds().Dx()*diff.Bounds().Dy(), perPixelTolerance, maxDifferingPixels, first, formatColor(e), formatColor(i), path)
+}
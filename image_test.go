@@ -0,0 +1,86 @@
+package assertions
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func filledImage(w, h int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestImagesEqual(t *testing.T) {
+	t.Setenv(DumpDirEnv, t.TempDir())
+
+	base := filledImage(4, 4, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+
+	slightlyOff := filledImage(4, 4, color.NRGBA{R: 12, G: 20, B: 30, A: 255})
+
+	twoPixels := filledImage(4, 4, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+	twoPixels.Set(1, 1, color.White)
+	twoPixels.Set(2, 3, color.Black)
+
+	// The same pixels as base with a different origin
+	shifted := image.NewNRGBA(image.Rect(5, 5, 9, 9))
+	copy(shifted.Pix, base.Pix)
+
+	cases := []struct {
+		name      string
+		input     image.Image
+		tolerance int
+		maxPixels int
+		mustFail  bool
+	}{
+		{name: "identical", input: base, tolerance: 0, maxPixels: 0, mustFail: false},
+		{name: "within tolerance", input: slightlyOff, tolerance: 2, maxPixels: 0, mustFail: false},
+		{name: "outside tolerance", input: slightlyOff, tolerance: 1, maxPixels: 0, mustFail: true},
+		{name: "allowed differing pixels", input: twoPixels, tolerance: 0, maxPixels: 2, mustFail: false},
+		{name: "too many differing pixels", input: twoPixels, tolerance: 0, maxPixels: 1, mustFail: true},
+		{name: "different origin", input: shifted, tolerance: 0, maxPixels: 0, mustFail: false},
+		{name: "different size", input: filledImage(4, 5, color.Black), tolerance: 255, maxPixels: 100, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ImagesEqual(tb, base, tc.input, tc.tolerance, tc.maxPixels)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestImagesEqualWritesDiff(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DumpDirEnv, dir)
+
+	input := filledImage(3, 2, color.Black)
+	input.Set(2, 1, color.White)
+
+	tb := NewTester(t, true)
+	ImagesEqual(tb, filledImage(3, 2, color.Black), input, 0, 0)
+	tb.AssertExpectation()
+
+	entries, err := os.ReadDir(dir)
+	NoError(t, err)
+	Equal(t, 1, len(entries))
+
+	f, err := os.Open(filepath.Join(dir, entries[0].Name()))
+	NoError(t, err)
+	defer f.Close()
+
+	diff, err := png.Decode(f)
+	NoError(t, err)
+	Equal(t, "#ff0000ff", formatColor(diff.At(2, 1)))
+	Equal(t, "#c0c0c0ff", formatColor(diff.At(0, 0)))
+}
@@ -0,0 +1,314 @@
+package assertions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type jsonConfig struct {
+	unordered [][]string
+	ignored   [][]string
+	subset    bool
+}
+
+// JSONOption relaxes the comparison of JSON documents by JSONEq and the HTTP assertions.
+//
+// Options select locations with paths of object keys and array indices separated by dots,
+// where * matches any key or index, e.g. "items.*.tags" or "meta.requestId". The empty path
+// selects the whole document. Keys containing dots cannot be selected
+type JSONOption func(*jsonConfig)
+
+func splitJSONPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// JSONUnordered compares the arrays at paths regardless of order, as multisets of elements
+func JSONUnordered(paths ...string) JSONOption {
+	return func(c *jsonConfig) {
+		for _, p := range paths {
+			c.unordered = append(c.unordered, splitJSONPath(p))
+		}
+	}
+}
+
+// JSONIgnore skips the values at paths, they may differ or be absent from either document
+func JSONIgnore(paths ...string) JSONOption {
+	return func(c *jsonConfig) {
+		for _, p := range paths {
+			c.ignored = append(c.ignored, splitJSONPath(p))
+		}
+	}
+}
+
+// JSONSubset allows objects in the input to have fields that are not in the expected document,
+// so only the fields a contract cares about need to be written out
+func JSONSubset() JSONOption {
+	return func(c *jsonConfig) {
+		c.subset = true
+	}
+}
+
+// matchesJSONPath reports whether location is selected by any of patterns
+func matchesJSONPath(patterns [][]string, location []string) bool {
+	return slices.ContainsFunc(patterns, func(pattern []string) bool {
+		return slices.EqualFunc(pattern, location, func(p, l string) bool {
+			return p == "*" || p == l
+		})
+	})
+}
+
+// decodeJSON decodes data into generic values. Numbers are decoded as json.Number, keeping their literal
+// so integers beyond the precision of float64 can be compared exactly, see jsonNumbersEqual
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if err := dec.Decode(new(any)); err != io.EOF {
+		return nil, errors.New("invalid data after top-level value")
+	}
+	return v, nil
+}
+
+// jsonNumbersEqual compares two JSON numbers by value. Integers are compared exactly, so IDs such as
+// 9007199254740993 and 9007199254740992 differ, any other number is compared as a float64 so 1 and 1.0
+// are equal
+func jsonNumbersEqual(a, b json.Number) bool {
+	ai, aok := new(big.Int).SetString(a.String(), 10)
+	bi, bok := new(big.Int).SetString(b.String(), 10)
+	if aok && bok {
+		return ai.Cmp(bi) == 0
+	}
+
+	af, aerr := a.Float64()
+	bf, berr := b.Float64()
+	return aerr == nil && berr == nil && af == bf
+}
+
+// jsonLiteral is a JSON number printed in failure messages as it was written
+type jsonLiteral string
+
+func (l jsonLiteral) GoString() string {
+	return string(l)
+}
+
+// jsonDisplay returns v with every json.Number replaced by a jsonLiteral, for printing
+func jsonDisplay(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		return jsonLiteral(v)
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = jsonDisplay(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for n, e := range v {
+			out[n] = jsonDisplay(e)
+		}
+		return out
+	}
+	return v
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// formatJSON prints a decoded JSON value for a failure message
+func formatJSON(v any) string {
+	return formatValue(jsonDisplay(v))
+}
+
+// jsonDiffer compares decoded JSON documents. location holds the keys and indices leading to
+// the values being compared, path is the same location written as a Go access expression
+type jsonDiffer struct {
+	cfg   jsonConfig
+	diffs []difference
+}
+
+func (d *jsonDiffer) walk(location []string, path string, expected, input any) {
+	if matchesJSONPath(d.cfg.ignored, location) {
+		return
+	}
+
+	switch e := expected.(type) {
+	case json.Number:
+		i, ok := input.(json.Number)
+		if !ok {
+			break
+		}
+		if !jsonNumbersEqual(e, i) {
+			d.diffs = append(d.diffs, difference{path: path, expected: e.String(), input: i.String()})
+		}
+		return
+
+	case map[string]any:
+		i, ok := input.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(e)+len(i))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		for k := range i {
+			if _, ok := e[k]; !ok && !d.cfg.subset {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			keyLocation := append(slices.Clip(location), k)
+			keyPath := fmt.Sprintf("%v[%q]", path, k)
+			ev, eok := e[k]
+			iv, iok := i[k]
+			switch {
+			case matchesJSONPath(d.cfg.ignored, keyLocation):
+			case !eok:
+				d.diffs = append(d.diffs, difference{path: keyPath, expected: missingValue, input: formatJSON(iv)})
+			case !iok:
+				d.diffs = append(d.diffs, difference{path: keyPath, expected: formatJSON(ev), input: missingValue})
+			default:
+				d.walk(keyLocation, keyPath, ev, iv)
+			}
+		}
+		return
+
+	case []any:
+		i, ok := input.([]any)
+		if !ok {
+			break
+		}
+		if matchesJSONPath(d.cfg.unordered, location) {
+			d.walkUnordered(location, path, e, i)
+			return
+		}
+
+		for n := range max(len(e), len(i)) {
+			elementLocation := append(slices.Clip(location), strconv.Itoa(n))
+			elementPath := fmt.Sprintf("%v[%v]", path, n)
+			switch {
+			case matchesJSONPath(d.cfg.ignored, elementLocation):
+			case n >= len(e):
+				d.diffs = append(d.diffs, difference{path: elementPath, expected: missingValue, input: formatJSON(i[n])})
+			case n >= len(i):
+				d.diffs = append(d.diffs, difference{path: elementPath, expected: formatJSON(e[n]), input: missingValue})
+			default:
+				d.walk(elementLocation, elementPath, e[n], i[n])
+			}
+		}
+		return
+	}
+
+	// null differs from any other value without needing a note
+	if et, it := jsonType(expected), jsonType(input); et != it && expected != nil && input != nil {
+		d.diffs = append(d.diffs, difference{path: path, expected: formatJSON(expected), input: formatJSON(input), note: fmt.Sprintf("different JSON types %v and %v", et, it)})
+		return
+	}
+	d.diffs = append(d.diffs, diffValues(path, jsonDisplay(expected), jsonDisplay(input))...)
+}
+
+// walkUnordered pairs each expected element with the first unused input element equal to it,
+// reporting the elements left over on either side
+func (d *jsonDiffer) walkUnordered(location []string, path string, expected, input []any) {
+	used := make([]bool, len(input))
+
+	for n, ev := range expected {
+		found := false
+		for m, iv := range input {
+			if used[m] {
+				continue
+			}
+			elementDiffer := jsonDiffer{cfg: d.cfg}
+			elementDiffer.walk(append(slices.Clip(location), strconv.Itoa(m)), "", ev, iv)
+			if len(elementDiffer.diffs) == 0 {
+				used[m], found = true, true
+				break
+			}
+		}
+		if !found {
+			d.diffs = append(d.diffs, difference{path: fmt.Sprintf("%v[%v]", path, n), expected: formatJSON(ev), input: missingValue})
+		}
+	}
+
+	for m, iv := range input {
+		if !used[m] {
+			d.diffs = append(d.diffs, difference{path: fmt.Sprintf("%v[%v]", path, m), expected: missingValue, input: formatJSON(iv)})
+		}
+	}
+}
+
+// jsonDifferences decodes both documents and returns the differences between them
+func jsonDifferences(expected, input []byte, opts ...JSONOption) ([]difference, error) {
+	ev, err := decodeJSON(expected)
+	if err != nil {
+		return nil, fmt.Errorf("expected is not valid JSON: %w", err)
+	}
+	iv, err := decodeJSON(input)
+	if err != nil {
+		return nil, fmt.Errorf("input is not valid JSON: %w", err)
+	}
+
+	var d jsonDiffer
+	for _, opt := range opts {
+		opt(&d.cfg)
+	}
+	d.walk(nil, "", ev, iv)
+	return d.diffs, nil
+}
+
+// JSONEq asserts that expected and input are equivalent JSON documents, regardless of formatting and the
+// order of object fields. Numbers are compared by value, integers exactly however large. Options relax the
+// comparison for unordered arrays, ignored fields and extra fields in input. Failing results print the path
+// of every differing value
+func JSONEq(tb testing.TB, expected, input string, opts ...JSONOption) {
+	const invalidFormat = "JSON documents could not be compared\n > error: %v\n"
+	const failureFormat = "JSON documents are not equal\n%v"
+
+	summary.recordAssertion()
+
+	diffs, err := jsonDifferences([]byte(expected), []byte(input), opts...)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+
+	if len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(diffs))
+		return
+	}
+}
@@ -0,0 +1,168 @@
+package assertions
+
+import "testing"
+
+func TestJSONEq(t *testing.T) {
+	const order = `{"id": 7, "items": [{"sku": "a", "tags": ["x", "y"]}, {"sku": "b", "tags": []}], "meta": {"requestId": "r1"}}`
+
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		opts     []JSONOption
+		mustFail bool
+	}{
+		{
+			name:     "formatting and field order",
+			expected: order,
+			input:    `{"meta":{"requestId":"r1"},"items":[{"tags":["x","y"],"sku":"a"},{"sku":"b","tags":[]}],"id":7.0}`,
+			mustFail: false,
+		},
+		{
+			name:     "different value",
+			expected: `{"id": 7}`,
+			input:    `{"id": 8}`,
+			mustFail: true,
+		},
+		{
+			name:     "array order matters",
+			expected: `{"tags": ["x", "y"]}`,
+			input:    `{"tags": ["y", "x"]}`,
+			mustFail: true,
+		},
+		{
+			name:     "unordered array",
+			expected: `{"tags": ["x", "y", "x"]}`,
+			input:    `{"tags": ["y", "x", "x"]}`,
+			opts:     []JSONOption{JSONUnordered("tags")},
+			mustFail: false,
+		},
+		{
+			name:     "unordered array with different counts",
+			expected: `{"tags": ["x", "y", "x"]}`,
+			input:    `{"tags": ["y", "y", "x"]}`,
+			opts:     []JSONOption{JSONUnordered("tags")},
+			mustFail: true,
+		},
+		{
+			name:     "unordered nested wildcard",
+			expected: `{"items": [{"tags": ["x", "y"]}, {"tags": ["z"]}]}`,
+			input:    `{"items": [{"tags": ["y", "x"]}, {"tags": ["z"]}]}`,
+			opts:     []JSONOption{JSONUnordered("items.*.tags")},
+			mustFail: false,
+		},
+		{
+			name:     "unordered objects",
+			expected: `[{"id": 1}, {"id": 2}]`,
+			input:    `[{"id": 2}, {"id": 1}]`,
+			opts:     []JSONOption{JSONUnordered("")},
+			mustFail: false,
+		},
+		{
+			name:     "ignored field",
+			expected: `{"id": 7, "meta": {"requestId": "r1"}}`,
+			input:    `{"id": 7, "meta": {"requestId": "r2"}}`,
+			opts:     []JSONOption{JSONIgnore("meta.requestId")},
+			mustFail: false,
+		},
+		{
+			name:     "ignored field missing",
+			expected: `{"id": 7, "updatedAt": "2024-01-01"}`,
+			input:    `{"id": 7}`,
+			opts:     []JSONOption{JSONIgnore("updatedAt")},
+			mustFail: false,
+		},
+		{
+			name:     "ignored array elements",
+			expected: `{"items": [{"sku": "a", "id": 1}]}`,
+			input:    `{"items": [{"sku": "a", "id": 2}]}`,
+			opts:     []JSONOption{JSONIgnore("items.*.id")},
+			mustFail: false,
+		},
+		{
+			name:     "extra field",
+			expected: `{"id": 7}`,
+			input:    `{"id": 7, "name": "x"}`,
+			mustFail: true,
+		},
+		{
+			name:     "subset",
+			expected: `{"id": 7, "owner": {"name": "x"}}`,
+			input:    `{"id": 7, "name": "x", "owner": {"name": "x", "email": "x@example.test"}}`,
+			opts:     []JSONOption{JSONSubset()},
+			mustFail: false,
+		},
+		{
+			name:     "subset missing field",
+			expected: `{"id": 7, "name": "x"}`,
+			input:    `{"id": 7}`,
+			opts:     []JSONOption{JSONSubset()},
+			mustFail: true,
+		},
+		{
+			name:     "invalid",
+			expected: `{"id": 7}`,
+			input:    `{"id": `,
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			JSONEq(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestJSONDifferencesPaths(t *testing.T) {
+	diffs, err := jsonDifferences(
+		[]byte(`{"items": [{"sku": "a"}, {"sku": "b"}], "total": 2}`),
+		[]byte(`{"items": [{"sku": "a"}, {"sku": "c"}, {"sku": "d"}]}`),
+	)
+	NoError(t, err)
+	Equal(t, []difference{
+		{path: `["items"][1]["sku"]`, expected: `"b"`, input: `"c"`},
+		{path: `["items"][2]`, expected: missingValue, input: `map[string]interface {}{"sku":"d"}`},
+		{path: `["total"]`, expected: "2", input: missingValue},
+	}, diffs)
+}
+
+func TestJSONNumbers(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		mustFail bool
+	}{
+		{name: "same integer", expected: `{"id": 9007199254740993}`, input: `{"id": 9007199254740993}`, mustFail: false},
+		{name: "integers beyond float64", expected: `{"id": 9007199254740993}`, input: `{"id": 9007199254740992}`, mustFail: true},
+		{name: "beyond int64", expected: `[123456789012345678901234567890]`, input: `[123456789012345678901234567891]`, mustFail: true},
+		{name: "decimal equals integer", expected: `[1]`, input: `[1.0]`, mustFail: false},
+		{name: "exponent", expected: `[1e3]`, input: `[1000]`, mustFail: false},
+		{name: "decimals", expected: `[0.1]`, input: `[0.10]`, mustFail: false},
+		{name: "different decimals", expected: `[0.1]`, input: `[0.2]`, mustFail: true},
+		{name: "trailing data", expected: `[1]`, input: `[1] [2]`, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			JSONEq(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestJSONNumbersMessage(t *testing.T) {
+	diffs, err := jsonDifferences([]byte(`{"id": 9007199254740993, "n": 1, "list": [2]}`), []byte(`{"id": 9007199254740992, "n": "1", "list": {"a": 2}}`))
+	NoError(t, err)
+	Equal(t, []difference{
+		{path: `["id"]`, expected: "9007199254740993", input: "9007199254740992"},
+		{path: `["list"]`, expected: "[]interface {}{2}", input: `map[string]interface {}{"a":2}`, note: "different JSON types array and object"},
+		{path: `["n"]`, expected: "1", input: `"1"`, note: "different JSON types number and string"},
+	}, diffs)
+}
@@ -0,0 +1,215 @@
+package assertions
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// jwt is a JSON Web Token split into its parts, parsed without verifying its signature
+type jwt struct {
+	header    map[string]json.RawMessage
+	claims    map[string]json.RawMessage
+	signed    string
+	signature []byte
+}
+
+func parseJWT(token string) (jwt, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwt{}, fmt.Errorf("token has %v parts, a signed JWT has 3", len(parts))
+	}
+
+	var t jwt
+	for i, dest := range []*map[string]json.RawMessage{&t.header, &t.claims} {
+		data, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			return jwt{}, fmt.Errorf("part %v is not base64url: %w", i+1, err)
+		}
+		if err := json.Unmarshal(data, dest); err != nil {
+			return jwt{}, fmt.Errorf("part %v is not a JSON object: %w", i+1, err)
+		}
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwt{}, fmt.Errorf("signature is not base64url: %w", err)
+	}
+	t.signed, t.signature = parts[0]+"."+parts[1], signature
+	return t, nil
+}
+
+// alg returns the signing algorithm named by the header
+func (t jwt) alg() string {
+	var alg string
+	json.Unmarshal(t.header["alg"], &alg)
+	return alg
+}
+
+// formatClaims prints the claims of the token, which unlike the token itself grant nothing
+func (t jwt) formatClaims() string {
+	data, _ := json.Marshal(t.claims)
+	return string(data)
+}
+
+// jwtHashes are the hashes of the HMAC, RSA and ECDSA algorithms by the size in their names
+var jwtHashes = map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+
+// verifyJWT verifies the signature of t with key, which must suit the algorithm of the token
+func verifyJWT(t jwt, key any) error {
+	alg := t.alg()
+	if alg == "EdDSA" {
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("%v needs an ed25519.PublicKey, got %T", alg, key)
+		}
+		if !ed25519.Verify(pub, []byte(t.signed), t.signature) {
+			return errors.New("signature does not match")
+		}
+		return nil
+	}
+
+	hash, ok := jwtHashes[alg[min(2, len(alg)):]]
+	if !ok || len(alg) != 5 {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write([]byte(t.signed))
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("%v needs a []byte secret, got %T", alg, key)
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write([]byte(t.signed))
+		if !hmac.Equal(mac.Sum(nil), t.signature) {
+			return errors.New("signature does not match")
+		}
+		return nil
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%v needs an *rsa.PublicKey, got %T", alg, key)
+		}
+		if alg[0] == 'P' {
+			return rsa.VerifyPSS(pub, hash, digest, t.signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, t.signature)
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%v needs an *ecdsa.PublicKey, got %T", alg, key)
+		}
+		// The signature is r and s as fixed size big endian integers
+		size := len(t.signature) / 2
+		if len(t.signature) == 0 || len(t.signature)%2 != 0 {
+			return errors.New("signature is not a pair of integers")
+		}
+		r, s := new(big.Int).SetBytes(t.signature[:size]), new(big.Int).SetBytes(t.signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("signature does not match")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported algorithm %q", alg)
+}
+
+// JWTClaimEqual asserts that the JWT token has the claim with a value equal to expected, compared as by JSONEq
+// with the JSON encoding of expected, so 3600 equals a numeric claim and []string{"api"} an array. The signature
+// is not verified, see JWTValidSignature. Failing results print the claims of the token but never the token
+func JWTClaimEqual(tb testing.TB, token, claim string, expected any) {
+	const invalidFormat = "Token is not a JWT\n < error: %v\n"
+	const missingFormat = "JWT has no claim %q\n < claims: %v\n"
+	const encodeFormat = "Expected claim could not be encoded as JSON\n < error: %v\n"
+	const failureFormat = "JWT claim %q is not equal\n%v"
+
+	summary.recordAssertion()
+
+	t, err := parseJWT(token)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	value, ok := t.claims[claim]
+	if !ok {
+		errorfNow(tb, missingFormat, claim, t.formatClaims())
+		return
+	}
+	data, err := json.Marshal(expected)
+	if err != nil {
+		errorfNow(tb, encodeFormat, err)
+		return
+	}
+	diffs, err := jsonDifferences(data, value)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	if len(diffs) > 0 {
+		errorfNow(tb, failureFormat, claim, formatDifferences(diffs))
+		return
+	}
+}
+
+// JWTValidSignature asserts that the signature of the JWT token verifies with key: a []byte secret for the
+// HS algorithms, an *rsa.PublicKey for RS and PS, an *ecdsa.PublicKey for ES and an ed25519.PublicKey for EdDSA.
+// Unsigned tokens with the algorithm none always fail
+func JWTValidSignature(tb testing.TB, token string, key any) {
+	const invalidFormat = "Token is not a JWT\n < error: %v\n"
+	const failureFormat = "JWT signature is not valid\n ~ alg:   %v\n < error: %v\n"
+
+	summary.recordAssertion()
+
+	t, err := parseJWT(token)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	if err := verifyJWT(t, key); err != nil {
+		errorfNow(tb, failureFormat, t.alg(), err)
+		return
+	}
+}
+
+// JWTExpiresWithin asserts that the JWT token has an exp claim no more than tolerance before or after expected,
+// such as time.Now().Add(time.Hour) for a token issued with a lifetime of an hour
+func JWTExpiresWithin(tb testing.TB, token string, expected time.Time, tolerance time.Duration) {
+	const invalidFormat = "Token is not a JWT\n < error: %v\n"
+	const missingFormat = "JWT has no numeric exp claim\n > expected: %v\n < claims:   %v\n"
+	const failureFormat = "JWT expiry is off by %v\n > expected: %v ± %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	t, err := parseJWT(token)
+	if err != nil {
+		errorfNow(tb, invalidFormat, err)
+		return
+	}
+	var exp float64
+	if err := json.Unmarshal(t.claims["exp"], &exp); err != nil {
+		errorfNow(tb, missingFormat, expected, t.formatClaims())
+		return
+	}
+
+	sec, frac := math.Modf(exp)
+	expires := time.Unix(int64(sec), int64(frac*1e9))
+	if off := expires.Sub(expected).Abs(); off > tolerance {
+		errorfNow(tb, failureFormat, off, expected, tolerance, expires)
+		return
+	}
+}
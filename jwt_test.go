@@ -0,0 +1,148 @@
+package assertions
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// signJWT returns a token with claims signed by sign using the algorithm alg
+func signJWT(t *testing.T, alg string, claims map[string]any, sign func(signed []byte) []byte) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	NoError(t, err)
+	payload, err := json.Marshal(claims)
+	NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+var testClaims = map[string]any{"sub": "user-1", "aud": []string{"api"}, "admin": true, "exp": 1700000000}
+
+func TestJWTClaimEqual(t *testing.T) {
+	token := signJWT(t, "HS256", testClaims, func([]byte) []byte { return []byte("sig") })
+
+	cases := []struct {
+		name     string
+		token    string
+		claim    string
+		expected any
+		mustFail bool
+	}{
+		{name: "string", token: token, claim: "sub", expected: "user-1", mustFail: false},
+		{name: "array", token: token, claim: "aud", expected: []string{"api"}, mustFail: false},
+		{name: "number", token: token, claim: "exp", expected: 1700000000, mustFail: false},
+		{name: "bool", token: token, claim: "admin", expected: true, mustFail: false},
+		{name: "different", token: token, claim: "sub", expected: "user-2", mustFail: true},
+		{name: "missing", token: token, claim: "scope", expected: "read", mustFail: true},
+		{name: "not a token", token: "abc", claim: "sub", expected: "user-1", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			JWTClaimEqual(tb, tc.token, tc.claim, tc.expected)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestJWTValidSignature(t *testing.T) {
+	secret := []byte("secret")
+	hs256 := signJWT(t, "HS256", testClaims, func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	})
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	NoError(t, err)
+	rs256 := signJWT(t, "RS256", testClaims, func(signed []byte) []byte {
+		sig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, sha256Sum(signed))
+		NoError(t, err)
+		return sig
+	})
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	NoError(t, err)
+	es256 := signJWT(t, "ES256", testClaims, func(signed []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, sha256Sum(signed))
+		NoError(t, err)
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	})
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	NoError(t, err)
+	eddsa := signJWT(t, "EdDSA", testClaims, func(signed []byte) []byte { return ed25519.Sign(edKey, signed) })
+
+	unsigned := signJWT(t, "none", testClaims, func([]byte) []byte { return nil })
+
+	cases := []struct {
+		name     string
+		token    string
+		key      any
+		mustFail bool
+	}{
+		{name: "HS256", token: hs256, key: secret, mustFail: false},
+		{name: "HS256 wrong secret", token: hs256, key: []byte("other"), mustFail: true},
+		{name: "RS256", token: rs256, key: &rsaKey.PublicKey, mustFail: false},
+		{name: "ES256", token: es256, key: &ecKey.PublicKey, mustFail: false},
+		{name: "EdDSA", token: eddsa, key: edPub, mustFail: false},
+		{name: "wrong key type", token: rs256, key: secret, mustFail: true},
+		{name: "none", token: unsigned, key: secret, mustFail: true},
+		{name: "tampered", token: hs256[:len(hs256)-2] + "AA", key: secret, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			JWTValidSignature(tb, tc.token, tc.key)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestJWTExpiresWithin(t *testing.T) {
+	expected := time.Unix(1700000000, 0)
+	token := signJWT(t, "HS256", testClaims, func([]byte) []byte { return []byte("sig") })
+	noExpiry := signJWT(t, "HS256", map[string]any{"sub": "user-1"}, func([]byte) []byte { return []byte("sig") })
+
+	cases := []struct {
+		name      string
+		token     string
+		tolerance time.Duration
+		expected  time.Time
+		mustFail  bool
+	}{
+		{name: "exact", token: token, expected: expected, tolerance: 0, mustFail: false},
+		{name: "within", token: token, expected: expected.Add(30 * time.Second), tolerance: time.Minute, mustFail: false},
+		{name: "outside", token: token, expected: expected.Add(time.Hour), tolerance: time.Minute, mustFail: true},
+		{name: "no expiry", token: noExpiry, expected: expected, tolerance: time.Minute, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			JWTExpiresWithin(tb, tc.token, tc.expected, tc.tolerance)
+			tb.AssertExpectation()
+		})
+	}
+}
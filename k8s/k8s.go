@@ -0,0 +1,101 @@
+// Package k8s compares Kubernetes objects with the assertions package, ignoring the fields the API server
+// populates so that objects read back from a cluster or a fake client equal the objects a test built.
+// Typed objects, unstructured objects and maps decoded from JSON or YAML may be compared with each other.
+// It is a separate module so the assertions package stays free of dependencies
+package k8s
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jcopi/assertions"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// serverFields are the metadata fields populated by the API server, removed before comparing
+var serverFields = []string{"resourceVersion", "managedFields", "creationTimestamp", "uid", "generation", "selfLink"}
+
+type config struct {
+	ignored [][]string
+}
+
+// Option configures ObjectEqual
+type Option func(*config)
+
+// IgnoreFields also removes the fields at the given dotted paths before comparing,
+// e.g. IgnoreFields("status", "metadata.annotations")
+func IgnoreFields(paths ...string) Option {
+	return func(c *config) {
+		for _, p := range paths {
+			c.ignored = append(c.ignored, strings.Split(p, "."))
+		}
+	}
+}
+
+// toUnstructured returns a copy of obj as the map of its JSON representation
+func toUnstructured(obj any) (map[string]any, error) {
+	switch o := obj.(type) {
+	case *unstructured.Unstructured:
+		return runtime.DeepCopyJSON(o.Object), nil
+	case unstructured.Unstructured:
+		return runtime.DeepCopyJSON(o.Object), nil
+	case map[string]any:
+		return runtime.DeepCopyJSON(o), nil
+	}
+
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+	if v.IsNil() {
+		return nil, fmt.Errorf("cannot compare a nil %T", obj)
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(v.Interface())
+}
+
+// removeField deletes the field at path from object, if it is there
+func removeField(object map[string]any, path []string) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := object[key].(map[string]any)
+		if !ok {
+			return
+		}
+		object = next
+	}
+	delete(object, path[len(path)-1])
+}
+
+// ObjectEqual asserts that expected and input are equal, as by assertions.Equal, once both are converted to
+// their JSON representation and the server populated metadata fields resourceVersion, managedFields,
+// creationTimestamp, uid, generation and selfLink are removed. Failing results print the path to each difference
+func ObjectEqual(tb testing.TB, expected, input any, opts ...Option) {
+	cfg := config{}
+	for _, f := range serverFields {
+		cfg.ignored = append(cfg.ignored, []string{"metadata", f})
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	e, err := toUnstructured(expected)
+	if err != nil {
+		assertions.NoError(tb, fmt.Errorf("converting expected: %w", err))
+		return
+	}
+	i, err := toUnstructured(input)
+	if err != nil {
+		assertions.NoError(tb, fmt.Errorf("converting input: %w", err))
+		return
+	}
+	for _, path := range cfg.ignored {
+		removeField(e, path)
+		removeField(i, path)
+	}
+
+	assertions.Equal(tb, e, i)
+}
@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+type widgetSpec struct {
+	Replicas int    `json:"replicas"`
+	Image    string `json:"image"`
+}
+
+type widget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              widgetSpec        `json:"spec"`
+	Status            map[string]string `json:"status,omitempty"`
+}
+
+func newWidget(replicas int) *widget {
+	return &widget{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "example.com/v1", Kind: "Widget"},
+		ObjectMeta: metav1.ObjectMeta{Name: "w", Namespace: "default", Labels: map[string]string{"app": "w"}},
+		Spec:       widgetSpec{Replicas: replicas, Image: "w:1"},
+	}
+}
+
+// fromServer returns w as the API server would, with the fields it populates set
+func fromServer(w *widget) *widget {
+	out := *w
+	out.ResourceVersion = "12345"
+	out.UID = types.UID("0b8c1a5e")
+	out.Generation = 2
+	out.CreationTimestamp = metav1.Now()
+	out.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}}
+	out.Status = map[string]string{"phase": "Ready"}
+	return &out
+}
+
+func TestObjectEqual(t *testing.T) {
+	unstructuredWidget := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]any{
+			"name":              "w",
+			"namespace":         "default",
+			"labels":            map[string]any{"app": "w"},
+			"resourceVersion":   "7",
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+		},
+		"spec": map[string]any{"replicas": int64(3), "image": "w:1"},
+	}}
+
+	cases := []struct {
+		name     string
+		expected any
+		input    any
+		opts     []Option
+		mustFail bool
+	}{
+		{name: "same", expected: newWidget(3), input: newWidget(3), mustFail: false},
+		{name: "server fields", expected: newWidget(3), input: fromServer(newWidget(3)), opts: []Option{IgnoreFields("status")}, mustFail: false},
+		{name: "status compared", expected: newWidget(3), input: fromServer(newWidget(3)), mustFail: true},
+		{name: "different spec", expected: newWidget(3), input: fromServer(newWidget(2)), opts: []Option{IgnoreFields("status")}, mustFail: true},
+		{name: "typed and unstructured", expected: newWidget(3), input: unstructuredWidget, mustFail: false},
+		{name: "value and pointer", expected: *newWidget(3), input: newWidget(3), mustFail: false},
+		{name: "nil", expected: newWidget(3), input: (*widget)(nil), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			ObjectEqual(tb, tc.expected, tc.input, tc.opts...)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
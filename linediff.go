@@ -0,0 +1,104 @@
+package assertions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// lineDiffContext is the number of unchanged lines printed around each change
+	lineDiffContext = 3
+	// lineDiffLimit bounds the size of the table used to align lines, larger inputs are summarized instead
+	lineDiffLimit = 4_000_000
+)
+
+type lineOp struct {
+	kind byte // ' ' for unchanged, '-' for only in expected, '+' for only in input
+	line string
+}
+
+// alignLines returns the edit script turning expected into input that keeps the longest common subsequence of lines
+func alignLines(expected, input []string) []lineOp {
+	n, m := len(expected), len(input)
+
+	// lcs[i][j] is the length of the longest common subsequence of expected[i:] and input[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if expected[i] == input[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]lineOp, 0, max(n, m))
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && expected[i] == input[j]:
+			ops = append(ops, lineOp{' ', expected[i]})
+			i, j = i+1, j+1
+		case j >= m || (i < n && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, lineOp{'-', expected[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', input[j]})
+			j++
+		}
+	}
+	return ops
+}
+
+// lineDiff formats the lines removed from expected with - and those added in input with +,
+// along with a few unchanged lines around each change. It returns an empty string when they are equal
+func lineDiff(expected, input string) string {
+	if expected == input {
+		return ""
+	}
+
+	el, il := splitLines(expected), splitLines(input)
+	if len(el)*len(il) > lineDiffLimit {
+		return fmt.Sprintf("   %v\n", describeBytesDifference([]byte(expected), []byte(input)))
+	}
+
+	ops := alignLines(el, il)
+	if !slices.ContainsFunc(ops, func(op lineOp) bool { return op.kind != ' ' }) {
+		// Inputs that differ only in line terminators have no differing lines once split
+		return fmt.Sprintf("   %v\n", describeBytesDifference([]byte(expected), []byte(input)))
+	}
+
+	// The output only keeps unchanged lines within lineDiffContext of a change
+	keep := make([]bool, len(ops))
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		for j := max(0, i-lineDiffContext); j <= min(len(ops)-1, i+lineDiffContext); j++ {
+			keep[j] = true
+		}
+	}
+
+	var b strings.Builder
+	skipped := false
+	for i, op := range ops {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			b.WriteString("   ...\n")
+			skipped = false
+		}
+		fmt.Fprintf(&b, "   %c %v\n", op.kind, op.line)
+	}
+	if skipped {
+		b.WriteString("   ...\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package assertions
+
+import "testing"
+
+func TestLineDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		diff     string
+	}{
+		{name: "equal", expected: "a\nb\n", input: "a\nb\n", diff: ""},
+		{name: "changed line", expected: "a\nb\nc\n", input: "a\nx\nc\n", diff: "     a\n   - b\n   + x\n     c\n"},
+		{name: "added line", expected: "a\n", input: "a\nb\n", diff: "     a\n   + b\n"},
+		{name: "removed line", expected: "a\nb\n", input: "b\n", diff: "   - a\n     b\n"},
+		{
+			name:     "context",
+			expected: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			input:    "1\n2\n3\n4\n5\n6\n7\n8\nx\n",
+			diff:     "   ...\n     6\n     7\n     8\n   - 9\n   + x\n",
+		},
+		{name: "line endings", expected: "a\n", input: "a\r\n", diff: "   line 1: expected \"a\", got \"a\\r\"\n"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			Equal(t, tc.diff, lineDiff(tc.expected, tc.input))
+		})
+	}
+}
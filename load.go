@@ -0,0 +1,136 @@
+package assertions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+// readFixture reads the file at path with read, failing the test when it cannot be read
+func readFixture(tb testing.TB, path string, read func(name string) ([]byte, error)) ([]byte, bool) {
+	const failureFormat = "Fixture could not be read\n ~ %v\n > error: %v\n"
+
+	data, err := read(path)
+	if err != nil {
+		errorfNow(tb, failureFormat, path, err)
+		return nil, false
+	}
+	return data, true
+}
+
+// offsetPosition returns the 1 based line and column of the byte at offset in data
+func offsetPosition(data []byte, offset int64) (int, int) {
+	offset = min(max(offset, 0), int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// describeJSONError adds the position of syntax and type errors in data to err.
+// The offsets of these errors count the bytes read up to and including the offending one
+func describeJSONError(data []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, column := offsetPosition(data, syntaxErr.Offset-1)
+		return fmt.Sprintf("line %v, column %v: %v", line, column, err)
+	case errors.As(err, &typeErr):
+		line, column := offsetPosition(data, typeErr.Offset-1)
+		return fmt.Sprintf("line %v, column %v: %v", line, column, err)
+	}
+	return err.Error()
+}
+
+func loadWith[T any](tb testing.TB, path string, read func(name string) ([]byte, error), decode func(data []byte, v any) error) T {
+	const failureFormat = "Fixture could not be decoded\n ~ %v\n > error: %v\n"
+
+	var zero T
+	data, ok := readFixture(tb, path, read)
+	if !ok {
+		return zero
+	}
+
+	var v T
+	if err := decode(data, &v); err != nil {
+		errorfNow(tb, failureFormat, path, err)
+		return zero
+	}
+	return v
+}
+
+// fsReader reads files from fsys
+func fsReader(fsys fs.FS) func(name string) ([]byte, error) {
+	return func(name string) ([]byte, error) {
+		return fs.ReadFile(fsys, name)
+	}
+}
+
+// LoadWith reads the file at path, typically under testdata, and decodes it into a T with decode, such as
+// yaml.Unmarshal. The test fails if the file cannot be read or decoded. On failure the zero value of T is returned
+func LoadWith[T any](tb testing.TB, path string, decode func(data []byte, v any) error) T {
+	return loadWith[T](tb, path, os.ReadFile, decode)
+}
+
+// LoadWithFS is LoadWith reading the file from fsys, such as an embed.FS of testdata
+func LoadWithFS[T any](tb testing.TB, fsys fs.FS, path string, decode func(data []byte, v any) error) T {
+	return loadWith[T](tb, path, fsReader(fsys), decode)
+}
+
+// LoadJSON reads the JSON file at path, typically under testdata, and decodes it into a T.
+// The test fails if the file cannot be read or decoded, decoding errors report the line and column.
+// On failure the zero value of T is returned
+func LoadJSON[T any](tb testing.TB, path string) T {
+	return LoadWith[T](tb, path, decodeJSONFixture)
+}
+
+// LoadJSONFS is LoadJSON reading the file from fsys, such as an embed.FS of testdata
+func LoadJSONFS[T any](tb testing.TB, fsys fs.FS, path string) T {
+	return LoadWithFS[T](tb, fsys, path, decodeJSONFixture)
+}
+
+func decodeJSONFixture(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.New(describeJSONError(data, err))
+	}
+	return nil
+}
+
+// LoadGolden reads the golden file at path, failing the test with a hint on how to create it when it does not exist.
+// Prefer Golden for comparisons, LoadGolden suits code that needs the expected contents themselves
+func LoadGolden(tb testing.TB, path string) []byte {
+	const missingFormat = "Golden file does not exist\n ~ %v\n ~ set %v=1 and run a test using Golden to create it\n"
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		errorfNow(tb, missingFormat, path, UpdateGoldenEnv)
+		return nil
+	}
+
+	data, _ := readFixture(tb, path, os.ReadFile)
+	return data
+}
+
+// FSFileEqual asserts that the file at path in fsys, such as an embedded asset, has exactly the contents expected.
+// Failing results print a line diff for text and the offset of the first difference otherwise
+func FSFileEqual(tb testing.TB, fsys fs.FS, path string, expected []byte) {
+	const readFormat = "File could not be read\n ~ %v\n > error: %v\n"
+	const failureFormat = "File contents do not match\n ~ %v\n%v"
+
+	summary.recordAssertion()
+
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		errorfNow(tb, readFormat, path, err)
+		return
+	}
+
+	if diff := contentsDifference(expected, data); diff != "" {
+		errorfNow(tb, failureFormat, path, diff)
+		return
+	}
+}
@@ -0,0 +1,128 @@
+package assertions
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+type loadFixture struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Tags  []string `json:"tags"`
+}
+
+func writeFixture(t *testing.T, name, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func TestLoadJSON(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		expected loadFixture
+		mustFail bool
+	}{
+		{
+			name:     "valid",
+			path:     writeFixture(t, "valid.json", `{"name": "a", "count": 2, "tags": ["x"]}`),
+			expected: loadFixture{Name: "a", Count: 2, Tags: []string{"x"}},
+			mustFail: false,
+		},
+		{
+			name:     "syntax error",
+			path:     writeFixture(t, "syntax.json", "{\n  \"name\": \"a\",\n}"),
+			mustFail: true,
+		},
+		{
+			name:     "wrong type",
+			path:     writeFixture(t, "type.json", `{"count": "two"}`),
+			mustFail: true,
+		},
+		{
+			name:     "missing file",
+			path:     filepath.Join(t.TempDir(), "missing.json"),
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			v := LoadJSON[loadFixture](tb, tc.path)
+			tb.AssertExpectation()
+			Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestDescribeJSONError(t *testing.T) {
+	data := []byte("{\n  \"name\": \"a\",\n}")
+	var v any
+	err := json.Unmarshal(data, &v)
+	Error(t, err)
+	Equal(t, "line 3, column 1: invalid character '}' looking for beginning of object key string", describeJSONError(data, err))
+}
+
+func TestLoadGolden(t *testing.T) {
+	tb := NewTester(t, false)
+	Equal(t, []byte("golden\n"), LoadGolden(tb, writeFixture(t, "a.golden", "golden\n")))
+	tb.AssertExpectation()
+
+	tb = NewTester(t, true)
+	Equal(t, []byte(nil), LoadGolden(tb, filepath.Join(t.TempDir(), "missing.golden")))
+	tb.AssertExpectation()
+}
+
+func TestLoadJSONFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"testdata/a.json":   {Data: []byte(`{"name": "a", "count": 1}`)},
+		"testdata/bad.json": {Data: []byte(`{"name": 1}`)},
+	}
+
+	tb := NewTester(t, false)
+	Equal(t, loadFixture{Name: "a", Count: 1}, LoadJSONFS[loadFixture](tb, fsys, "testdata/a.json"))
+	tb.AssertExpectation()
+
+	tb = NewTester(t, true)
+	LoadJSONFS[loadFixture](tb, fsys, "testdata/bad.json")
+	tb.AssertExpectation()
+
+	tb = NewTester(t, true)
+	LoadJSONFS[loadFixture](tb, fsys, "testdata/missing.json")
+	tb.AssertExpectation()
+}
+
+func TestFSFileEqual(t *testing.T) {
+	fsys := fstest.MapFS{
+		"static/index.html": {Data: []byte("<html>\n<body>\n")},
+		"static/logo.png":   {Data: []byte{0x89, 'P', 'N', 'G'}},
+	}
+
+	cases := []struct {
+		name     string
+		path     string
+		expected []byte
+		mustFail bool
+	}{
+		{name: "equal text", path: "static/index.html", expected: []byte("<html>\n<body>\n"), mustFail: false},
+		{name: "different text", path: "static/index.html", expected: []byte("<html>\n<main>\n"), mustFail: true},
+		{name: "equal binary", path: "static/logo.png", expected: []byte{0x89, 'P', 'N', 'G'}, mustFail: false},
+		{name: "different binary", path: "static/logo.png", expected: []byte{0x89, 'P', 'N', 'X'}, mustFail: true},
+		{name: "missing file", path: "static/missing.css", expected: nil, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			FSFileEqual(tb, fsys, tc.path, tc.expected)
+			tb.AssertExpectation()
+		})
+	}
+}
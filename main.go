@@ -0,0 +1,202 @@
+package assertions
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// colorOutput makes failure messages color expected and input lines, it is only enabled by Main
+var colorOutput atomic.Bool
+
+const (
+	ansiGreen = "\x1b[32m"
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// colorize colors the expected lines of message green and the input lines red
+func colorize(message string) string {
+	lines := strings.SplitAfter(message, "\n")
+	for i, line := range lines {
+		body := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(body, " > "), strings.HasPrefix(body, "   - "):
+			lines[i] = ansiGreen + body + ansiReset + line[len(body):]
+		case strings.HasPrefix(body, " < "), strings.HasPrefix(body, "   + "):
+			lines[i] = ansiRed + body + ansiReset + line[len(body):]
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// detectColor reports whether the output is a terminal that should be colored, honouring NO_COLOR
+func detectColor() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+type mainConfig struct {
+	color            *bool
+	skipSummary      bool
+	skipLeakCheck    bool
+	ignoreGoroutines []string
+}
+
+// MainOption configures Main
+type MainOption func(*mainConfig)
+
+// MainColor forces colored failure messages on or off instead of detecting whether the output is a terminal
+func MainColor(enabled bool) MainOption {
+	return func(c *mainConfig) {
+		c.color = &enabled
+	}
+}
+
+// MainSkipSummary does not print the summary of assertions after the tests have run
+func MainSkipSummary() MainOption {
+	return func(c *mainConfig) {
+		c.skipSummary = true
+	}
+}
+
+// MainSkipLeakCheck does not check for goroutines left running after the tests have run
+func MainSkipLeakCheck() MainOption {
+	return func(c *mainConfig) {
+		c.skipLeakCheck = true
+	}
+}
+
+// MainIgnoreGoroutines excludes goroutines whose stack contains any of the given functions,
+// e.g. "go.opencensus.io/stats/view.(*worker).start", from the leak check
+func MainIgnoreGoroutines(functions ...string) MainOption {
+	return func(c *mainConfig) {
+		c.ignoreGoroutines = append(c.ignoreGoroutines, functions...)
+	}
+}
+
+// goroutineStacks returns the stacks of all goroutines other than the calling one, keyed by goroutine id
+func goroutineStacks() map[string]string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	stacks := make(map[string]string)
+	// The calling goroutine is always listed first
+	for _, stack := range bytes.Split(buf, []byte("\n\n"))[1:] {
+		header, _, _ := strings.Cut(string(stack), "\n")
+		if id, ok := strings.CutPrefix(header, "goroutine "); ok {
+			id, _, _ = strings.Cut(id, " ")
+			stacks[id] = string(stack)
+		}
+	}
+	return stacks
+}
+
+// leakedGoroutines returns the stacks of goroutines that are running now, were not in before and
+// do not contain any of the ignored functions
+func leakedGoroutines(before map[string]string, ignore []string) []string {
+	var leaked []string
+	for id, stack := range goroutineStacks() {
+		if _, ok := before[id]; ok {
+			continue
+		}
+		ignored := false
+		for _, fn := range ignore {
+			if strings.Contains(stack, fn+"(") {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			leaked = append(leaked, stack)
+		}
+	}
+	return leaked
+}
+
+// leakGracePeriod is how long goroutines are given to exit after the tests finish before they count as leaked
+const leakGracePeriod = time.Second
+
+// defaultIgnoredGoroutines are started by the standard library and live for the rest of the process
+var defaultIgnoredGoroutines = []string{"os/signal.signal_recv", "os/signal.loop"}
+
+// Main runs the tests of m with the package's cross-cutting features enabled and exits with their result.
+// Call it from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		assertions.Main(m)
+//	}
+//
+// Main defines the -update flag for Golden unless the test binary already has one, colors failure messages
+// when the output is a terminal and NO_COLOR is not set, prints how random seeds are chosen, prints the
+// summary described by RunWithSummary and fails the run if goroutines started by the tests are still
+// running once they have finished. Options turn these off or adjust them
+func Main(m *testing.M, opts ...MainOption) {
+	const seedFormat = "assertions: random seed %v=%v\n"
+	const derivedSeedFormat = "assertions: random seeds derived from test names, set %v to override\n"
+	const leakFormat = "assertions: %v goroutines are still running after the tests finished\n\n%v\n"
+
+	var cfg mainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "write the input of golden assertions to the golden files")
+	}
+
+	color := detectColor()
+	if cfg.color != nil {
+		color = *cfg.color
+	}
+	colorOutput.Store(color)
+
+	if seed := os.Getenv(SeedEnv); seed != "" {
+		fmt.Printf(seedFormat, SeedEnv, seed)
+	} else {
+		fmt.Printf(derivedSeedFormat, SeedEnv)
+	}
+
+	summary.enabled.Store(!cfg.skipSummary)
+	before := goroutineStacks()
+
+	code := m.Run()
+
+	if !cfg.skipSummary {
+		summary.write(os.Stdout)
+	}
+
+	if !cfg.skipLeakCheck && code == 0 {
+		ignore := slices.Concat(defaultIgnoredGoroutines, cfg.ignoreGoroutines)
+		// Goroutines may still be winding down, give them a moment to exit
+		deadline := time.Now().Add(leakGracePeriod)
+		leaked := leakedGoroutines(before, ignore)
+		for len(leaked) > 0 && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+			leaked = leakedGoroutines(before, ignore)
+		}
+		if len(leaked) > 0 {
+			fmt.Printf(leakFormat, len(leaked), strings.Join(leaked, "\n\n"))
+			code = 1
+		}
+	}
+
+	os.Exit(code)
+}
@@ -0,0 +1,41 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColorize(t *testing.T) {
+	input := "Values are not equal\n > expected: 1\n < input:    2\n ~ note\n   - a\n   + b\n"
+	expected := "Values are not equal\n" +
+		ansiGreen + " > expected: 1" + ansiReset + "\n" +
+		ansiRed + " < input:    2" + ansiReset + "\n" +
+		" ~ note\n" +
+		ansiGreen + "   - a" + ansiReset + "\n" +
+		ansiRed + "   + b" + ansiReset + "\n"
+	Equal(t, expected, colorize(input))
+}
+
+func TestDetectColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	Equal(t, false, detectColor())
+}
+
+func TestLeakedGoroutines(t *testing.T) {
+	before := goroutineStacks()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-stop
+	}()
+
+	leaked := leakedGoroutines(before, nil)
+	Equal(t, 1, len(leaked))
+	Equal(t, true, strings.Contains(leaked[0], "TestLeakedGoroutines"))
+	Equal(t, 0, len(leakedGoroutines(before, []string{"github.com/jcopi/assertions.TestLeakedGoroutines.func1"})))
+
+	close(stop)
+	<-done
+}
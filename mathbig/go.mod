@@ -0,0 +1,5 @@
+module github.com/jcopi/assertions/mathbig
+
+go 1.23.0
+
+require github.com/jcopi/assertions v0.1.0
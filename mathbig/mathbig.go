@@ -0,0 +1,49 @@
+// Package mathbig registers comparers and formatters for the math/big types with the assertions package,
+// so numbers with the same value are equal regardless of their internal representation, such as the
+// precision and rounding mode of a big.Float, and print as numbers in failure messages.
+// Import it for its side effects from a test file:
+//
+//	import _ "github.com/jcopi/assertions/mathbig"
+//
+// It is a separate module so that registering the comparers remains a choice of each test package
+package mathbig
+
+import (
+	"math/big"
+
+	"github.com/jcopi/assertions"
+)
+
+func init() {
+	assertions.RegisterComparer(func(expected, input big.Int) bool {
+		return expected.Cmp(&input) == 0
+	})
+	assertions.RegisterComparer(func(expected, input big.Rat) bool {
+		return expected.Cmp(&input) == 0
+	})
+	FloatPrecision(0)
+
+	assertions.RegisterFormatter(func(v big.Int) string {
+		return "big.Int(" + v.String() + ")"
+	})
+	assertions.RegisterFormatter(func(v big.Rat) string {
+		return "big.Rat(" + v.RatString() + ")"
+	})
+	assertions.RegisterFormatter(func(v big.Float) string {
+		return "big.Float(" + v.Text('g', -1) + ")"
+	})
+}
+
+// FloatPrecision makes big.Float values equal when they round to the same value with prec bits of mantissa,
+// using the rounding mode of the expected value. A precision of 0, the default, compares exact values.
+// Like all comparers this is global, set it from an init function or TestMain
+func FloatPrecision(prec uint) {
+	assertions.RegisterComparer(func(expected, input big.Float) bool {
+		if prec == 0 {
+			return expected.Cmp(&input) == 0
+		}
+		e := new(big.Float).SetMode(expected.Mode()).SetPrec(prec).Set(&expected)
+		i := new(big.Float).SetMode(expected.Mode()).SetPrec(prec).Set(&input)
+		return e.Cmp(i) == 0
+	})
+}
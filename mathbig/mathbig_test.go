@@ -0,0 +1,85 @@
+package mathbig
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jcopi/assertions"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+func TestBigEqual(t *testing.T) {
+	type ledger struct {
+		Balance *big.Rat
+		Count   *big.Int
+		Rate    *big.Float
+	}
+
+	cases := []struct {
+		name     string
+		expected ledger
+		input    ledger
+		mustFail bool
+	}{
+		{
+			name:     "equal values",
+			expected: ledger{Balance: big.NewRat(1, 2), Count: big.NewInt(0), Rate: big.NewFloat(1.5)},
+			input:    ledger{Balance: big.NewRat(2, 4), Count: new(big.Int), Rate: new(big.Float).SetPrec(200).SetFloat64(1.5)},
+			mustFail: false,
+		},
+		{
+			name:     "different rational",
+			expected: ledger{Balance: big.NewRat(1, 2)},
+			input:    ledger{Balance: big.NewRat(1, 3)},
+			mustFail: true,
+		},
+		{
+			name:     "different integer",
+			expected: ledger{Count: big.NewInt(1)},
+			input:    ledger{Count: big.NewInt(2)},
+			mustFail: true,
+		},
+		{
+			name:     "different float",
+			expected: ledger{Rate: big.NewFloat(0.1)},
+			input:    ledger{Rate: new(big.Float).SetPrec(200).SetFloat64(0.1).Add(new(big.Float).SetPrec(200).SetFloat64(0.1), big.NewFloat(1e-30))},
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			assertions.Equal(tb, tc.expected, tc.input)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
+
+func TestFloatPrecision(t *testing.T) {
+	defer FloatPrecision(0)
+
+	expected := big.NewFloat(0.1)
+	input := new(big.Float).SetPrec(200).Add(new(big.Float).SetPrec(200).SetFloat64(0.1), big.NewFloat(1e-30))
+
+	FloatPrecision(53)
+	tb := &tester{TB: t}
+	assertions.Equal(tb, expected, input)
+	if tb.failed {
+		t.Fatalf("values that round to the same float64 were not equal")
+	}
+}
@@ -0,0 +1,98 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// matrixBarWidth is the width of the bar drawn for the row with the most mismatches
+const matrixBarWidth = 20
+
+// matrixShape formats the shape of m as rows x columns, listing the length of each row if they are ragged
+func matrixShape[T any](m [][]T) string {
+	if len(m) == 0 {
+		return "0x0"
+	}
+	lengths := make([]int, len(m))
+	ragged := false
+	for i, row := range m {
+		lengths[i] = len(row)
+		ragged = ragged || lengths[i] != lengths[0]
+	}
+	if ragged {
+		return fmt.Sprintf("%v rows of lengths %v", len(m), lengths)
+	}
+	return fmt.Sprintf("%vx%v", len(m), lengths[0])
+}
+
+// matrixMismatches compares expected and input, which have the same shape, cell by cell. It returns the number of
+// cells differing by more than tolerance in each row and the coordinates of the cell with the largest difference,
+// NaN counting as larger than any other
+func matrixMismatches[T Number](expected, input [][]T, tolerance float64) (rows []int, worstRow, worstColumn int) {
+	rows = make([]int, len(expected))
+	worst := -1.0
+	for r := range expected {
+		for c := range expected[r] {
+			d := math.Abs(float64(input[r][c]) - float64(expected[r][c]))
+			if d <= tolerance {
+				continue
+			}
+			rows[r]++
+			if math.IsNaN(d) {
+				d = math.Inf(1)
+			}
+			if d > worst {
+				worst, worstRow, worstColumn = d, r, c
+			}
+		}
+	}
+	return rows, worstRow, worstColumn
+}
+
+// formatRowMismatches lists the rows with mismatches, each with a bar scaled to the row with the most
+func formatRowMismatches(rows, lengths []int) string {
+	most := 0
+	for _, n := range rows {
+		most = max(most, n)
+	}
+
+	var b strings.Builder
+	for r, n := range rows {
+		if n == 0 {
+			continue
+		}
+		bar := max(1, n*matrixBarWidth/most)
+		fmt.Fprintf(&b, "   [%v] %-*v %v of %v\n", r, matrixBarWidth, strings.Repeat("#", bar), n, lengths[r])
+	}
+	return b.String()
+}
+
+// EqualMatrix asserts that expected and input have the same shape and that every cell of input is within
+// tolerance of the same cell of expected. Failing results print the cell with the largest difference and
+// the number of mismatching cells in each row
+func EqualMatrix[T Number](tb testing.TB, expected, input [][]T, tolerance T) {
+	const shapeFormat = "Matrices have different shapes\n > expected: %v\n < input:    %v\n"
+	const failureFormat = "Matrices are not equal within %v\n ~ %v of %v cells differ\n ~ worst cell [%v][%v]\n > expected: %v\n < input:    %v\n ~ mismatches by row:\n%v"
+
+	summary.recordAssertion()
+
+	if matrixShape(expected) != matrixShape(input) {
+		errorfNow(tb, shapeFormat, matrixShape(expected), matrixShape(input))
+		return
+	}
+
+	rows, r, c := matrixMismatches(expected, input, float64(tolerance))
+	count, cells := 0, 0
+	lengths := make([]int, len(expected))
+	for i, n := range rows {
+		count += n
+		lengths[i] = len(expected[i])
+		cells += lengths[i]
+	}
+	if count > 0 {
+		errorfNow(tb, failureFormat, tolerance, count, cells, r, c, expected[r][c], input[r][c], formatRowMismatches(rows, lengths))
+		return
+	}
+}
@@ -0,0 +1,65 @@
+package assertions
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEqualMatrix(t *testing.T) {
+	cases := []struct {
+		name      string
+		expected  [][]float64
+		input     [][]float64
+		tolerance float64
+		mustFail  bool
+	}{
+		{name: "equal", expected: [][]float64{{1, 2}, {3, 4}}, input: [][]float64{{1, 2}, {3, 4}}, tolerance: 0, mustFail: false},
+		{name: "within", expected: [][]float64{{1, 2}, {3, 4}}, input: [][]float64{{1.01, 2}, {3, 3.99}}, tolerance: 0.1, mustFail: false},
+		{name: "outside", expected: [][]float64{{1, 2}, {3, 4}}, input: [][]float64{{1, 2}, {3, 5}}, tolerance: 0.1, mustFail: true},
+		{name: "nan", expected: [][]float64{{1}}, input: [][]float64{{math.NaN()}}, tolerance: 1, mustFail: true},
+		{name: "both empty", expected: nil, input: [][]float64{}, tolerance: 0, mustFail: false},
+		{name: "different rows", expected: [][]float64{{1}, {2}}, input: [][]float64{{1}}, tolerance: 0, mustFail: true},
+		{name: "different columns", expected: [][]float64{{1, 2}}, input: [][]float64{{1}}, tolerance: 0, mustFail: true},
+		{name: "ragged", expected: [][]float64{{1, 2}, {3}}, input: [][]float64{{1, 2}, {3}}, tolerance: 0, mustFail: false},
+		{name: "different ragged", expected: [][]float64{{1, 2}, {3}}, input: [][]float64{{1}, {2, 3}}, tolerance: 0, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EqualMatrix(tb, tc.expected, tc.input, tc.tolerance)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualMatrixFloat32(t *testing.T) {
+	tb := NewTester(t, false)
+	EqualMatrix(tb, [][]float32{{0.1, 0.2}}, [][]float32{{0.1001, 0.2}}, 0.001)
+	tb.AssertExpectation()
+
+	tb = NewTester(t, true)
+	EqualMatrix(tb, [][]float32{{0.1, 0.2}}, [][]float32{{0.1, 0.3}}, 0.001)
+	tb.AssertExpectation()
+}
+
+func TestEqualMatrixMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	expected := [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}
+	input := [][]int{{1, 1, 1, 1}, {0, 0, 0, 0}, {0, 9, 0, 0}}
+	EqualMatrix(tb, expected, input, 0)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Matrices are not equal within 0\n ~ 5 of 12 cells differ\n ~ worst cell [2][1]\n > expected: 0\n < input:    9\n ~ mismatches by row:\n" +
+		"   [0] #################### 4 of 4\n" +
+		"   [2] #####                1 of 4\n"}, tb.logs)
+}
+
+func TestEqualMatrixShapeMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	EqualMatrix(tb, [][]float64{{1, 2}, {3, 4}}, [][]float64{{1, 2}, {3}}, 0)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Matrices have different shapes\n > expected: 2x2\n < input:    2 rows of lengths [2 1]\n"}, tb.logs)
+}
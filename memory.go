@@ -0,0 +1,41 @@
+package assertions
+
+import (
+	"runtime"
+	"testing"
+)
+
+// memoryGrowthRuns is the number of times NoMemoryGrowth calls fn between measurements
+const memoryGrowthRuns = 10
+
+// heapAlloc collects garbage and returns the bytes of live heap objects. Collecting twice lets objects
+// kept alive by finalizers be freed
+func heapAlloc() int64 {
+	runtime.GC()
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return int64(stats.HeapAlloc)
+}
+
+// NoMemoryGrowth asserts that calling fn repeatedly grows the live heap by at most maxGrowthBytes in total.
+// fn is called once to warm up caches and pools, then the heap is measured around further calls.
+// It is a coarse leak detector for long running components, memory allocated by other goroutines
+// counts as growth so tests using it should not run in parallel
+func NoMemoryGrowth(tb testing.TB, fn func(), maxGrowthBytes int64) {
+	const failureFormat = "Memory grew over %v calls\n > at most: %v bytes\n < growth:  %v bytes\n ~ live heap went from %v to %v bytes\n"
+
+	summary.recordAssertion()
+
+	fn()
+	before := heapAlloc()
+	for range memoryGrowthRuns {
+		fn()
+	}
+	after := heapAlloc()
+
+	if growth := after - before; growth > maxGrowthBytes {
+		errorfNow(tb, failureFormat, memoryGrowthRuns, maxGrowthBytes, growth, before, after)
+		return
+	}
+}
@@ -0,0 +1,28 @@
+package assertions
+
+import (
+	"testing"
+)
+
+func TestNoMemoryGrowth(t *testing.T) {
+	var retained [][]byte
+
+	cases := []struct {
+		name     string
+		fn       func()
+		mustFail bool
+	}{
+		{name: "garbage only", fn: func() { _ = make([]byte, 1<<20) }, mustFail: false},
+		{name: "retained", fn: func() { retained = append(retained, make([]byte, 1<<20)) }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			NoMemoryGrowth(tb, tc.fn, 1<<20)
+			tb.AssertExpectation()
+		})
+	}
+	retained = nil
+}
@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Number is any integer or floating point type
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+// WithinPercent asserts that input differs from expected by at most percent of expected,
+// for quantities such as counters and rates where an absolute tolerance does not scale.
+// When expected is zero input must also be zero, NaN never matches
+func WithinPercent[T Number](tb testing.TB, expected, input T, percent float64) {
+	const failureFormat = "values differ by %.4g%%\n > expected: %v ± %v%%\n < input:    %v\n"
+	const zeroFormat = "values differ from an expected zero\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	e, i := float64(expected), float64(input)
+	if e == 0 {
+		if i != 0 {
+			errorfNow(tb, zeroFormat, expected, input)
+			return
+		}
+		return
+	}
+
+	if diff := math.Abs(i-e) / math.Abs(e) * 100; !(diff <= percent) {
+		errorfNow(tb, failureFormat, diff, expected, percent, input)
+		return
+	}
+}
+
+// vectorWithin fails tb listing the components of input further than tolerance(i) from expected
+func vectorWithin[T Number](tb testing.TB, expected, input []T, tolerance func(i int) float64) {
+	const lengthFormat = "Vectors have different lengths\n > expected: %v\n < input:    %v\n"
+	const failureFormat = "Vector components are out of tolerance\n%v"
+
+	if len(expected) != len(input) {
+		errorfNow(tb, lengthFormat, len(expected), len(input))
+		return
+	}
+
+	var b strings.Builder
+	for i := range expected {
+		tol := tolerance(i)
+		if !(math.Abs(float64(input[i])-float64(expected[i])) <= tol) {
+			fmt.Fprintf(&b, " ~ [%v]:\n   > expected: %v ± %v\n   < input:    %v\n", i, expected[i], tol, input[i])
+		}
+	}
+	if b.Len() > 0 {
+		errorfNow(tb, failureFormat, b.String())
+		return
+	}
+}
+
+// VectorWithin asserts that each component of input is within tolerance of the same component of expected,
+// e.g. VectorWithin(tb, want[:], got[:], 1e-9) for arrays. Failing results list every component out of tolerance
+func VectorWithin[T Number](tb testing.TB, expected, input []T, tolerance T) {
+	summary.recordAssertion()
+
+	vectorWithin(tb, expected, input, func(int) float64 { return float64(tolerance) })
+}
+
+// VectorWithinEach is VectorWithin with a tolerance for each component, for vectors whose components
+// have different scales such as a position and an angle
+func VectorWithinEach[T Number](tb testing.TB, expected, input, tolerances []T) {
+	const toleranceFormat = "Tolerances do not match the vector length\n > expected: %v\n < tolerances: %v\n"
+
+	summary.recordAssertion()
+
+	if len(tolerances) != len(expected) {
+		errorfNow(tb, toleranceFormat, len(expected), len(tolerances))
+		return
+	}
+	vectorWithin(tb, expected, input, func(i int) float64 { return float64(tolerances[i]) })
+}
+
+// PointWithin asserts that the Euclidean distance between the points expected and input is at most maxDistance
+func PointWithin[T Number](tb testing.TB, expected, input []T, maxDistance float64) {
+	const lengthFormat = "Points have different dimensions\n > expected: %v\n < input:    %v\n"
+	const failureFormat = "Points are too far apart\n ~ distance: %v, at most %v\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	if len(expected) != len(input) {
+		errorfNow(tb, lengthFormat, len(expected), len(input))
+		return
+	}
+
+	var sum float64
+	for i := range expected {
+		d := float64(input[i]) - float64(expected[i])
+		sum += d * d
+	}
+	if distance := math.Sqrt(sum); !(distance <= maxDistance) {
+		errorfNow(tb, failureFormat, distance, maxDistance, expected, input)
+		return
+	}
+}
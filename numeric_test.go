@@ -0,0 +1,126 @@
+package assertions
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWithinPercent(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected float64
+		input    float64
+		percent  float64
+		mustFail bool
+	}{
+		{name: "equal", expected: 100, input: 100, percent: 0, mustFail: false},
+		{name: "within above", expected: 100, input: 104, percent: 5, mustFail: false},
+		{name: "within below", expected: 100, input: 95, percent: 5, mustFail: false},
+		{name: "outside", expected: 100, input: 94, percent: 5, mustFail: true},
+		{name: "negative expected", expected: -200, input: -190, percent: 5, mustFail: false},
+		{name: "zero expected", expected: 0, input: 0, percent: 5, mustFail: false},
+		{name: "zero expected nonzero input", expected: 0, input: 0.001, percent: 5, mustFail: true},
+		{name: "nan input", expected: 1, input: math.NaN(), percent: 5, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			WithinPercent(tb, tc.expected, tc.input, tc.percent)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestWithinPercentIntegers(t *testing.T) {
+	tb := NewTester(t, false)
+	WithinPercent(tb, uint64(1000), uint64(990), 1)
+	tb.AssertExpectation()
+
+	tb = NewTester(t, true)
+	WithinPercent(tb, 1000, 1011, 1)
+	tb.AssertExpectation()
+}
+
+func TestVectorWithin(t *testing.T) {
+	cases := []struct {
+		name      string
+		expected  []float64
+		input     []float64
+		tolerance float64
+		mustFail  bool
+	}{
+		{name: "equal", expected: []float64{1, 2, 3}, input: []float64{1, 2, 3}, tolerance: 0, mustFail: false},
+		{name: "within", expected: []float64{1, 2, 3}, input: []float64{1.05, 1.95, 3}, tolerance: 0.1, mustFail: false},
+		{name: "one outside", expected: []float64{1, 2, 3}, input: []float64{1, 2.5, 3}, tolerance: 0.1, mustFail: true},
+		{name: "different lengths", expected: []float64{1, 2, 3}, input: []float64{1, 2}, tolerance: 0.1, mustFail: true},
+		{name: "nan", expected: []float64{1}, input: []float64{math.NaN()}, tolerance: 1, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			VectorWithin(tb, tc.expected, tc.input, tc.tolerance)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestVectorWithinMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	expected := [3]int{1, 2, 3}
+	input := [3]int{1, 5, 0}
+	VectorWithin(tb, expected[:], input[:], 1)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Vector components are out of tolerance\n" +
+		" ~ [1]:\n   > expected: 2 ± 1\n   < input:    5\n" +
+		" ~ [2]:\n   > expected: 3 ± 1\n   < input:    0\n"}, tb.logs)
+}
+
+func TestVectorWithinEach(t *testing.T) {
+	cases := []struct {
+		name       string
+		input      []float64
+		tolerances []float64
+		mustFail   bool
+	}{
+		{name: "within", input: []float64{10.5, 0.01}, tolerances: []float64{1, 0.1}, mustFail: false},
+		{name: "outside", input: []float64{10.5, 0.5}, tolerances: []float64{1, 0.1}, mustFail: true},
+		{name: "missing tolerance", input: []float64{10, 0}, tolerances: []float64{1}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			VectorWithinEach(tb, []float64{10, 0}, tc.input, tc.tolerances)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestPointWithin(t *testing.T) {
+	cases := []struct {
+		name        string
+		input       []float64
+		maxDistance float64
+		mustFail    bool
+	}{
+		{name: "same point", input: []float64{0, 0}, maxDistance: 0, mustFail: false},
+		{name: "on the boundary", input: []float64{3, 4}, maxDistance: 5, mustFail: false},
+		{name: "components within but distance outside", input: []float64{3, 4}, maxDistance: 4.5, mustFail: true},
+		{name: "different dimensions", input: []float64{0, 0, 0}, maxDistance: 1, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			PointWithin(tb, []float64{0, 0}, tc.input, tc.maxDistance)
+			tb.AssertExpectation()
+		})
+	}
+}
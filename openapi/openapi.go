@@ -0,0 +1,146 @@
+// Package openapi asserts that HTTP responses match the operations of an OpenAPI 3 document, so drift between
+// handlers and their published contract is caught by unit tests.
+// It is a separate module so the assertions package stays free of dependencies
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// fail reports a failure in the format of the assertions package
+func fail(tb testing.TB, format string, args ...any) {
+	tb.Logf(format, args...)
+	tb.FailNow()
+}
+
+// matchesTemplate reports whether the request path matches the path template of the document,
+// e.g. /users/42 matches /users/{id}
+func matchesTemplate(path, template string) bool {
+	ps, ts := strings.Split(path, "/"), strings.Split(template, "/")
+	if len(ps) != len(ts) {
+		return false
+	}
+	for i := range ps {
+		if ps[i] != ts[i] && !(strings.HasPrefix(ts[i], "{") && strings.HasSuffix(ts[i], "}") && ps[i] != "") {
+			return false
+		}
+	}
+	return true
+}
+
+// findRoute returns the operation for method and path, which may be a path template of the document or a request path
+func findRoute(doc *openapi3.T, method, path string) (*routers.Route, error) {
+	template, item := path, doc.Paths.Find(path)
+	if item == nil {
+		for t, candidate := range doc.Paths.Map() {
+			if matchesTemplate(path, t) {
+				template, item = t, candidate
+				break
+			}
+		}
+	}
+	if item == nil {
+		return nil, fmt.Errorf("the document has no path %v", path)
+	}
+	op := item.GetOperation(strings.ToUpper(method))
+	if op == nil {
+		return nil, fmt.Errorf("the document has no %v operation for %v", strings.ToUpper(method), template)
+	}
+	return &routers.Route{Spec: doc, Path: template, PathItem: item, Method: strings.ToUpper(method), Operation: op}, nil
+}
+
+// violations lists the problems found by validating a response, each schema error with the JSON pointer
+// to the offending value in the body
+func violations(err error) []string {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var out []string
+		for _, e := range multi {
+			out = append(out, violations(e)...)
+		}
+		return out
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []string{fmt.Sprintf("/%v: %v", strings.Join(schemaErr.JSONPointer(), "/"), schemaErr.Reason)}
+	}
+	var responseErr *openapi3filter.ResponseError
+	if errors.As(err, &responseErr) && responseErr.Err != nil {
+		return violations(responseErr.Err)
+	}
+	return []string{err.Error()}
+}
+
+// ResponseMatches asserts that resp is a response the OpenAPI 3 document at specPath allows for the operation
+// method and path. path is the path template of the operation, such as /users/{id}, or a request path matching
+// it. The status code must be documented, and the headers and body must match their schemas. resp.Body is read
+// and replaced so it can still be read afterwards. Failing results list every violation in order, schema violations with
+// the JSON pointer to the offending value, such as /items/0/id
+func ResponseMatches(tb testing.TB, specPath, method, path string, resp *http.Response) {
+	const invalidFormat = "OpenAPI document could not be used\n ~ path:  %v\n < error: %v\n"
+	const routeFormat = "Operation is not in the OpenAPI document\n ~ document: %v\n < error:    %v\n"
+	const bodyFormat = "Response body could not be read\n < error: %v\n"
+	const failureFormat = "Response does not match the OpenAPI document\n ~ operation: %v %v\n ~ status:    %v\n%v"
+
+	ctx := context.Background()
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile(specPath)
+	if err == nil {
+		err = doc.Validate(ctx)
+	}
+	if err != nil {
+		fail(tb, invalidFormat, specPath, err)
+		return
+	}
+
+	route, err := findRoute(doc, method, path)
+	if err != nil {
+		fail(tb, routeFormat, specPath, err)
+		return
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			fail(tb, bodyFormat, err)
+			return
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	req := resp.Request
+	if req == nil {
+		req, _ = http.NewRequest(route.Method, path, nil)
+	}
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req, Route: route},
+		Status:                 resp.StatusCode,
+		Header:                 resp.Header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                &openapi3filter.Options{MultiError: true, IncludeResponseStatus: true},
+	}
+	if err := openapi3filter.ValidateResponse(ctx, input); err != nil {
+		found := violations(err)
+		slices.Sort(found)
+		var b strings.Builder
+		for _, v := range found {
+			fmt.Fprintf(&b, " < %v\n", v)
+		}
+		fail(tb, failureFormat, route.Method, route.Path, resp.StatusCode, b.String())
+		return
+	}
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+	logs   []string
+}
+
+func (t *tester) Logf(format string, args ...any) {
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+func response(status int, contentType, body string) *http.Response {
+	rec := httptest.NewRecorder()
+	if contentType != "" {
+		rec.Header().Set("Content-Type", contentType)
+	}
+	rec.WriteHeader(status)
+	rec.WriteString(body)
+	return rec.Result()
+}
+
+func TestResponseMatches(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		resp     *http.Response
+		mustFail bool
+	}{
+		{name: "valid", method: "GET", path: "/users/{id}", resp: response(200, "application/json", `{"id": 1, "name": "ada"}`), mustFail: false},
+		{name: "request path", method: "get", path: "/users/42", resp: response(200, "application/json", `{"id": 42, "name": "ada", "tags": ["admin"]}`), mustFail: false},
+		{name: "documented status without body", method: "GET", path: "/users/{id}", resp: response(404, "", ""), mustFail: false},
+		{name: "missing property", method: "GET", path: "/users/{id}", resp: response(200, "application/json", `{"id": 1}`), mustFail: true},
+		{name: "wrong type", method: "GET", path: "/users/{id}", resp: response(200, "application/json", `{"id": "1", "name": "ada"}`), mustFail: true},
+		{name: "undocumented status", method: "GET", path: "/users/{id}", resp: response(500, "", ""), mustFail: true},
+		{name: "wrong content type", method: "GET", path: "/users/{id}", resp: response(200, "text/plain", "ada"), mustFail: true},
+		{name: "unknown operation", method: "DELETE", path: "/users/{id}", resp: response(200, "", ""), mustFail: true},
+		{name: "unknown path", method: "GET", path: "/groups", resp: response(200, "", ""), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			ResponseMatches(tb, "testdata/users.yaml", tc.method, tc.path, tc.resp)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
+
+func TestResponseMatchesViolations(t *testing.T) {
+	tb := &tester{TB: t}
+	resp := response(200, "application/json", `{"id": 1, "tags": ["admin", 2]}`)
+	body := `{"id": 1, "tags": ["admin", 2]}`
+
+	ResponseMatches(tb, "testdata/users.yaml", "GET", "/users/1", resp)
+	expected := "Response does not match the OpenAPI document\n ~ operation: GET /users/{id}\n ~ status:    200\n" +
+		" < /name: property \"name\" is missing\n < /tags/1: value must be a string\n"
+	if !tb.failed || len(tb.logs) != 1 || tb.logs[0] != expected {
+		t.Fatalf("Violations were not as expected:\n > expected: %q\n < actual: %q\n", expected, tb.logs)
+	}
+
+	restored, err := io.ReadAll(resp.Body)
+	if err != nil || string(restored) != body {
+		t.Fatalf("Body was not restored:\n > expected: %v\n < actual: %v\n", body, string(restored))
+	}
+}
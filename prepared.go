@@ -0,0 +1,260 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// canonicalEncoder writes a string for a value such that two values have the same string if and only if
+// they are equal by reflect.DeepEqual. Values that are never equal, NaN and non-nil functions, are given
+// strings that are unique to the encoder, distinguished from those of other encoders by prefix
+type canonicalEncoder struct {
+	prefix  string
+	b       strings.Builder
+	unique  int
+	visited map[unsafe.Pointer]bool
+}
+
+func (c *canonicalEncoder) key(v reflect.Value) string {
+	c.b.Reset()
+	c.encode(v)
+	return c.b.String()
+}
+
+func (c *canonicalEncoder) uniqueToken(kind string) {
+	c.unique++
+	fmt.Fprintf(&c.b, "%v#%v%v", kind, c.prefix, c.unique)
+}
+
+func (c *canonicalEncoder) encode(v reflect.Value) {
+	if !v.IsValid() {
+		c.b.WriteString("nil")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		c.b.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.b.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c.b.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		c.encodeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c.encodeFloat(real(v.Complex()))
+		c.b.WriteString("+")
+		c.encodeFloat(imag(v.Complex()))
+		c.b.WriteString("i")
+	case reflect.String:
+		c.b.WriteString(strconv.Quote(v.String()))
+	case reflect.Chan, reflect.UnsafePointer:
+		fmt.Fprintf(&c.b, "%v(%x)", v.Kind(), v.Pointer())
+
+	case reflect.Func:
+		if v.IsNil() {
+			c.b.WriteString("nil")
+			return
+		}
+		c.uniqueToken("func")
+
+	case reflect.Array:
+		c.encodeElements(v)
+
+	case reflect.Slice:
+		if v.IsNil() {
+			c.b.WriteString("nil")
+			return
+		}
+		if !c.enter(v.UnsafePointer()) {
+			return
+		}
+		defer delete(c.visited, v.UnsafePointer())
+		c.encodeElements(v)
+
+	case reflect.Map:
+		if v.IsNil() {
+			c.b.WriteString("nil")
+			return
+		}
+		if !c.enter(v.UnsafePointer()) {
+			return
+		}
+		defer delete(c.visited, v.UnsafePointer())
+
+		// Entries are ordered by their encoded keys, this needs a separate encoder for each entry
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := canonicalEncoder{prefix: c.prefix, unique: c.unique, visited: c.visited}
+			entry.encode(iter.Key())
+			entry.b.WriteString(":")
+			entry.encode(iter.Value())
+			c.unique = entry.unique
+			entries = append(entries, entry.b.String())
+		}
+		slices.Sort(entries)
+		c.b.WriteString("map{")
+		c.b.WriteString(strings.Join(entries, ","))
+		c.b.WriteString("}")
+
+	case reflect.Struct:
+		c.b.WriteString("{")
+		for i := range v.NumField() {
+			if i > 0 {
+				c.b.WriteString(",")
+			}
+			c.encode(v.Field(i))
+		}
+		c.b.WriteString("}")
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			c.b.WriteString("nil")
+			return
+		}
+		if !c.enter(v.UnsafePointer()) {
+			return
+		}
+		defer delete(c.visited, v.UnsafePointer())
+		c.b.WriteString("&")
+		c.encode(v.Elem())
+
+	case reflect.Interface:
+		if v.IsNil() {
+			c.b.WriteString("nil")
+			return
+		}
+		// The dynamic type distinguishes int(1) from int64(1)
+		fmt.Fprintf(&c.b, "(%v)", v.Elem().Type())
+		c.encode(v.Elem())
+	}
+}
+
+// enter marks the reference p as being encoded, writing a marker and returning false if it already is
+func (c *canonicalEncoder) enter(p unsafe.Pointer) bool {
+	if c.visited == nil {
+		c.visited = make(map[unsafe.Pointer]bool)
+	}
+	if c.visited[p] {
+		c.b.WriteString("<cycle>")
+		return false
+	}
+	c.visited[p] = true
+	return true
+}
+
+func (c *canonicalEncoder) encodeFloat(f float64) {
+	switch {
+	case math.IsNaN(f):
+		c.uniqueToken("NaN")
+	case f == 0:
+		// 0 and -0 are equal
+		c.b.WriteString("0")
+	default:
+		c.b.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+}
+
+func (c *canonicalEncoder) encodeElements(v reflect.Value) {
+	c.b.WriteString("[")
+	for i := range v.Len() {
+		if i > 0 {
+			c.b.WriteString(",")
+		}
+		c.encode(v.Index(i))
+	}
+	c.b.WriteString("]")
+}
+
+// PreparedExpectation is an expected slice prepared for comparing against many inputs, as when a large
+// fixture is asserted in every case of a table test. The canonical form of each expected element is
+// computed once, so each comparison takes linear time rather than the quadratic time of SlicesMatch
+type PreparedExpectation[E any] struct {
+	expected []E
+	keys     []string
+	counts   map[string]int
+}
+
+// Prepare returns a PreparedExpectation for expected. Elements are compared as by reflect.DeepEqual and
+// expected must not be modified while it is in use. The assertions may be called from parallel subtests
+func Prepare[E any](expected []E) *PreparedExpectation[E] {
+	p := &PreparedExpectation[E]{expected: expected, keys: make([]string, len(expected)), counts: make(map[string]int)}
+	encoder := canonicalEncoder{prefix: "e"}
+	for i := range expected {
+		p.keys[i] = encoder.key(reflect.ValueOf(&expected[i]).Elem())
+		p.counts[p.keys[i]]++
+	}
+	return p
+}
+
+// inputKeys returns the canonical forms of the elements of input
+func (p *PreparedExpectation[E]) inputKeys(input []E) []string {
+	keys := make([]string, len(input))
+	encoder := canonicalEncoder{prefix: "i"}
+	for i := range input {
+		keys[i] = encoder.key(reflect.ValueOf(&input[i]).Elem())
+	}
+	return keys
+}
+
+// SlicesMatch asserts that input has the same members as the prepared expectation regardless of order,
+// as by SlicesMatch. Failing results print how many times each differing element occurs in expected and input
+func (p *PreparedExpectation[E]) SlicesMatch(tb testing.TB, input []E) {
+	const failureFormat = "Elements do not match\n%v"
+
+	summary.recordAssertion()
+
+	order := slices.Clone(p.keys)
+	elements := make(map[string]E, len(p.counts))
+	for i, k := range p.keys {
+		elements[k] = p.expected[i]
+	}
+	inputCounts := make(map[string]int)
+	for i, k := range p.inputKeys(input) {
+		if _, ok := elements[k]; !ok {
+			elements[k] = input[i]
+			order = append(order, k)
+		}
+		inputCounts[k]++
+	}
+
+	var differing []multiplicity[E]
+	seen := make(map[string]bool)
+	for _, k := range order {
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if p.counts[k] != inputCounts[k] {
+			differing = append(differing, multiplicity[E]{element: elements[k], expected: p.counts[k], input: inputCounts[k]})
+		}
+	}
+	if len(differing) > 0 {
+		errorfNow(tb, failureFormat, formatMultiplicities(differing))
+		return
+	}
+}
+
+// Equal asserts that input is equal to the prepared expectation element by element, as by Equal. The elements
+// are compared by their canonical forms first, only inputs that differ are walked to find the path to each
+// difference. That walk uses the comparers registered with RegisterComparer, which may find them equal
+func (p *PreparedExpectation[E]) Equal(tb testing.TB, input []E) {
+	const failureFormat = "Values are not equal\n%v"
+
+	summary.recordAssertion()
+
+	if slices.Equal(p.keys, p.inputKeys(input)) {
+		return
+	}
+	if diffs := diffValues("", p.expected, input); len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(diffs))
+		return
+	}
+}
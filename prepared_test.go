@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalKey(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	cyclic := &node{Value: 1}
+	cyclic.Next = cyclic
+
+	cases := []struct {
+		name  string
+		a, b  any
+		equal bool
+	}{
+		{name: "ints", a: 1, b: 1, equal: true},
+		{name: "different dynamic types", a: []any{1}, b: []any{int64(1)}, equal: false},
+		{name: "nil and empty slice", a: []int(nil), b: []int{}, equal: false},
+		{name: "maps in any order", a: map[string]int{"a": 1, "b": 2}, b: map[string]int{"b": 2, "a": 1}, equal: true},
+		{name: "pointers to equal values", a: &node{Value: 1}, b: &node{Value: 1}, equal: true},
+		{name: "negative zero", a: math.Copysign(0, -1), b: 0.0, equal: true},
+		{name: "NaN", a: math.NaN(), b: math.NaN(), equal: false},
+		{name: "strings that look like markers", a: []string{"<cycle>"}, b: []string{"<cycle>"}, equal: true},
+		{name: "cycles", a: cyclic, b: cyclic, equal: true},
+		{name: "funcs", a: func() {}, b: func() {}, equal: false},
+		{name: "unexported fields", a: struct{ n int }{1}, b: struct{ n int }{2}, equal: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoder := canonicalEncoder{prefix: "t"}
+			a := encoder.key(reflect.ValueOf(tc.a))
+			b := encoder.key(reflect.ValueOf(tc.b))
+			Equal(t, tc.equal, a == b)
+			Equal(t, tc.equal, reflect.DeepEqual(tc.a, tc.b))
+		})
+	}
+}
+
+func TestPreparedSlicesMatch(t *testing.T) {
+	type item struct {
+		Name string
+		Tags []string
+	}
+	prepared := Prepare([]item{{"a", []string{"x"}}, {"b", nil}, {"b", nil}})
+
+	cases := []struct {
+		name     string
+		input    []item
+		mustFail bool
+	}{
+		{name: "same order", input: []item{{"a", []string{"x"}}, {"b", nil}, {"b", nil}}, mustFail: false},
+		{name: "any order", input: []item{{"b", nil}, {"a", []string{"x"}}, {"b", nil}}, mustFail: false},
+		{name: "different multiplicity", input: []item{{"a", []string{"x"}}, {"a", []string{"x"}}, {"b", nil}}, mustFail: true},
+		{name: "different element", input: []item{{"a", []string{"y"}}, {"b", nil}, {"b", nil}}, mustFail: true},
+		{name: "shorter", input: []item{{"b", nil}, {"b", nil}}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			prepared.SlicesMatch(tb, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestPreparedSlicesMatchMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+
+	Prepare([]string{"foo", "foo", "foo", "bar"}).SlicesMatch(tb, []string{"foo", "bar", "bar", "baz"})
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Elements do not match\n ~ \"foo\": expected 3×, got 1×\n ~ \"bar\": expected 1×, got 2×\n ~ \"baz\": expected 0×, got 1×\n"}, tb.logs)
+}
+
+func TestPreparedEqual(t *testing.T) {
+	prepared := Prepare([]map[string]int{{"a": 1}, {"b": 2}})
+
+	cases := []struct {
+		name     string
+		input    []map[string]int
+		mustFail bool
+	}{
+		{name: "equal", input: []map[string]int{{"a": 1}, {"b": 2}}, mustFail: false},
+		{name: "order", input: []map[string]int{{"b": 2}, {"a": 1}}, mustFail: true},
+		{name: "different value", input: []map[string]int{{"a": 1}, {"b": 3}}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			prepared.Equal(tb, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package assertions
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer that may be written by a process while a failing assertion reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// Process is a command started by StartProcess, for asserting on whether and how it exits
+type Process struct {
+	cmd    *exec.Cmd
+	stdout syncBuffer
+	stderr syncBuffer
+	done   chan struct{}
+	err    error
+}
+
+// processWaitDelay bounds how long output is read after a process exits, as children it started may hold
+// its output open
+const processWaitDelay = time.Second
+
+// StartProcess starts cmd, capturing its standard output and error for failure messages unless cmd
+// already writes them elsewhere. The process is killed at cleanup if it is still running.
+// cmd.WaitDelay is set to one second unless it is already set
+func StartProcess(tb testing.TB, cmd *exec.Cmd) *Process {
+	const failureFormat = "Process did not start\n > command: %v\n < error:   %v\n"
+
+	summary.recordAssertion()
+
+	p := &Process{cmd: cmd, done: make(chan struct{})}
+	if cmd.Stdout == nil {
+		cmd.Stdout = &p.stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = &p.stderr
+	}
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = processWaitDelay
+	}
+	if err := cmd.Start(); err != nil {
+		p.err = err
+		close(p.done)
+		errorfNow(tb, failureFormat, cmd, err)
+		return p
+	}
+
+	go func() {
+		p.err = cmd.Wait()
+		close(p.done)
+	}()
+	tb.Cleanup(func() {
+		select {
+		case <-p.done:
+		default:
+			cmd.Process.Kill()
+			<-p.done
+		}
+	})
+	return p
+}
+
+// exitCode returns the exit code of the exited process, -1 if it was terminated by a signal
+func (p *Process) exitCode() int {
+	if p.cmd.ProcessState == nil {
+		return -1
+	}
+	return p.cmd.ProcessState.ExitCode()
+}
+
+// wait waits for the process to exit within timeout, returning whether it did, the time elapsed
+// and the context's error if waiting was stopped by it
+func (p *Process) wait(cfg waitConfig, timeout time.Duration) (bool, time.Duration, error) {
+	start := cfg.clock.Now()
+	select {
+	case <-p.done:
+		return true, cfg.clock.Now().Sub(start), nil
+	case <-cfg.clock.After(timeout):
+		return false, cfg.clock.Now().Sub(start), nil
+	case <-cfg.ctx.Done():
+		return false, cfg.clock.Now().Sub(start), cfg.ctx.Err()
+	}
+}
+
+// exits asserts that the process exits with wantCode within timeout
+func (p *Process) exits(tb testing.TB, header string, wantCode int, timeout time.Duration, opts []WaitOption) {
+	const runningFormat = "%v\n ~ still running after %v\n ~ command: %v\n ~ stdout:  %v\n ~ stderr:  %v\n"
+	const canceledFormat = "%v\n ~ waiting was stopped after %v\n > timeout: %v\n < error:   %v\n ~ command: %v\n ~ stdout:  %v\n ~ stderr:  %v\n"
+	const codeFormat = "%v\n > exit code: %v\n < exit code: %v\n ~ command: %v\n ~ error:   %v\n ~ stdout:  %v\n ~ stderr:  %v\n"
+
+	cfg := newWaitConfig(tb, opts)
+	ok, elapsed, err := p.wait(cfg, timeout)
+	summary.recordWait(tb, elapsed)
+	if err != nil {
+		errorfNow(tb, canceledFormat, header, elapsed, timeout, err, p.cmd, formatTail(p.stdout.String()), formatTail(p.stderr.String()))
+		return
+	}
+	if !ok {
+		errorfNow(tb, runningFormat, header, timeout, p.cmd, formatTail(p.stdout.String()), formatTail(p.stderr.String()))
+		return
+	}
+
+	var exitErr *exec.ExitError
+	if code := p.exitCode(); code != wantCode || (p.err != nil && !errors.As(p.err, &exitErr)) {
+		errorfNow(tb, codeFormat, header, wantCode, code, p.cmd, p.err, formatTail(p.stdout.String()), formatTail(p.stderr.String()))
+		return
+	}
+}
+
+// ExitsWithin asserts that the process exits with wantCode within timeout.
+// Failing results print the end of its standard output and error
+func (p *Process) ExitsWithin(tb testing.TB, wantCode int, timeout time.Duration, opts ...WaitOption) {
+	summary.recordAssertion()
+
+	p.exits(tb, "Process did not exit as expected", wantCode, timeout, opts)
+}
+
+// StillRunning asserts that the process does not exit during duration.
+// Failing results print its exit code and the end of its standard output and error
+func (p *Process) StillRunning(tb testing.TB, duration time.Duration, opts ...WaitOption) {
+	const exitedFormat = "Process exited after %v\n > expected it to keep running for %v\n < exit code: %v\n ~ command: %v\n ~ stdout:  %v\n ~ stderr:  %v\n"
+	const canceledFormat = "waiting was stopped after %v\n > expected the process to keep running for %v\n < error: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := newWaitConfig(tb, opts)
+	exited, elapsed, err := p.wait(cfg, duration)
+	if err != nil {
+		errorfNow(tb, canceledFormat, elapsed, duration, err)
+		return
+	}
+	if exited {
+		errorfNow(tb, exitedFormat, elapsed, duration, p.exitCode(), p.cmd, formatTail(p.stdout.String()), formatTail(p.stderr.String()))
+		return
+	}
+}
+
+// SignalCausesExit sends sig to the process and asserts that it exits with wantCode within timeout.
+// A process terminated by the signal rather than exiting has the exit code -1.
+// Failing results print the end of its standard output and error
+func (p *Process) SignalCausesExit(tb testing.TB, sig os.Signal, wantCode int, timeout time.Duration, opts ...WaitOption) {
+	const signalFormat = "Process could not be signaled\n > signal:  %v\n < error:   %v\n ~ command: %v\n"
+
+	summary.recordAssertion()
+
+	if p.cmd.Process == nil {
+		errorfNow(tb, signalFormat, sig, p.err, p.cmd)
+		return
+	}
+	if err := p.cmd.Process.Signal(sig); err != nil {
+		errorfNow(tb, signalFormat, sig, err, p.cmd)
+		return
+	}
+	p.exits(tb, "Process did not exit as expected after "+sig.String(), wantCode, timeout, opts)
+}
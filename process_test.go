@@ -0,0 +1,98 @@
+package assertions
+
+import (
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// shell returns a command running script with sh, skipping the test where there is no sh
+func shell(t *testing.T, script string) *exec.Cmd {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	return exec.Command("sh", "-c", script)
+}
+
+func TestProcessExitsWithin(t *testing.T) {
+	cases := []struct {
+		name     string
+		script   string
+		wantCode int
+		mustFail bool
+	}{
+		{name: "exits", script: "exit 0", wantCode: 0, mustFail: false},
+		{name: "exits with code", script: "exit 3", wantCode: 3, mustFail: false},
+		{name: "wrong code", script: "exit 1", wantCode: 0, mustFail: true},
+		{name: "keeps running", script: "exec sleep 10", wantCode: 0, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := StartProcess(t, shell(t, tc.script))
+
+			tb := NewTester(t, tc.mustFail)
+			p.ExitsWithin(tb, tc.wantCode, 200*time.Millisecond)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestProcessStillRunning(t *testing.T) {
+	cases := []struct {
+		name     string
+		script   string
+		mustFail bool
+	}{
+		{name: "running", script: "exec sleep 10", mustFail: false},
+		{name: "exits", script: "exit 0", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := StartProcess(t, shell(t, tc.script))
+
+			tb := NewTester(t, tc.mustFail)
+			p.StillRunning(tb, 200*time.Millisecond)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestProcessSignalCausesExit(t *testing.T) {
+	cases := []struct {
+		name     string
+		script   string
+		wantCode int
+		mustFail bool
+	}{
+		{name: "handled", script: "trap 'exit 3' TERM; echo ready; while :; do sleep 0.01; done", wantCode: 3, mustFail: false},
+		{name: "terminated", script: "echo ready; exec sleep 10", wantCode: -1, mustFail: false},
+		{name: "ignored", script: "trap '' TERM; echo ready; while :; do sleep 0.01; done", wantCode: 3, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := StartProcess(t, shell(t, tc.script))
+			Eventually(t, func() bool { return strings.Contains(p.stdout.String(), "ready") }, time.Second, time.Millisecond)
+
+			tb := NewTester(t, tc.mustFail)
+			p.SignalCausesExit(tb, syscall.SIGTERM, tc.wantCode, 200*time.Millisecond)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestProcessMessage(t *testing.T) {
+	p := StartProcess(t, shell(t, "echo out; echo err >&2; exit 2"))
+
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	p.ExitsWithin(tb, 0, time.Second)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"Process did not exit as expected\n > exit code: 0\n < exit code: 2\n ~ command: " + p.cmd.String() +
+		"\n ~ error:   exit status 2\n ~ stdout:  \"out\\n\"\n ~ stderr:  \"err\\n\"\n"}, tb.logs)
+}
@@ -0,0 +1,37 @@
+package assertions
+
+import "testing"
+
+// KnownFailure runs fn, which contains assertions that are known to fail because of the issue at issueURL.
+// Failures inside fn are logged but do not fail the test. If fn stops failing the test fails, so the
+// quarantine is removed once the issue is fixed instead of the test silently staying skipped.
+// Cleanup functions registered inside fn run when the test ends, and a skip inside fn skips the test
+func KnownFailure(tb testing.TB, issueURL string, fn func(tb testing.TB)) {
+	const stillFailingFormat = "known failure, see %v\n"
+	const passingFormat = "known failure now passes, remove the quarantine\n > issue: %v\n"
+
+	g := Go(tb)
+	g.Go(fn)
+	g.wg.Wait()
+
+	g.mu.Lock()
+	logs, cleanups, failed, skipped := g.logs, g.cleanups, g.failed, g.skipped
+	g.mu.Unlock()
+
+	for _, fn := range cleanups {
+		tb.Cleanup(fn)
+	}
+
+	if failed {
+		tb.Logf(stillFailingFormat, issueURL)
+		replayLogs(tb, logs)
+		return
+	}
+	if skipped {
+		replayLogs(tb, logs)
+		tb.SkipNow()
+		return
+	}
+
+	errorfNow(tb, passingFormat, issueURL)
+}
@@ -0,0 +1,75 @@
+package assertions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKnownFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       func(tb testing.TB)
+		mustFail bool
+	}{
+		{
+			name:     "still failing",
+			fn:       func(tb testing.TB) { NoError(tb, errors.New("bug")) },
+			mustFail: false,
+		},
+		{
+			name: "fails part way",
+			fn: func(tb testing.TB) {
+				Equal(tb, 1, 1)
+				Equal(tb, 1, 2)
+				panic("unreachable")
+			},
+			mustFail: false,
+		},
+		{
+			name:     "now passes",
+			fn:       func(tb testing.TB) { NoError(tb, nil) },
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			KnownFailure(tb, "https://example.test/issues/1", tc.fn)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestKnownFailureCleanup(t *testing.T) {
+	cleaned := false
+	t.Run("cleanup", func(t *testing.T) {
+		tb := NewTester(t, false)
+
+		KnownFailure(tb, "https://example.test/issues/1", func(tb testing.TB) {
+			tb.Cleanup(func() { cleaned = true })
+			NoError(tb, errors.New("bug"))
+		})
+		tb.AssertExpectation()
+		Equal(t, false, cleaned)
+	})
+
+	Equal(t, true, cleaned)
+}
+
+func TestKnownFailureSkip(t *testing.T) {
+	var sub *testing.T
+	reached := false
+	t.Run("skipped", func(t *testing.T) {
+		sub = t
+		KnownFailure(t, "https://example.test/issues/1", func(tb testing.TB) {
+			tb.Skip("not supported")
+		})
+		reached = true
+	})
+
+	Equal(t, true, sub.Skipped())
+	Equal(t, false, sub.Failed())
+	Equal(t, false, reached)
+}
@@ -0,0 +1,45 @@
+package assertions
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// SeedEnv names the environment variable that overrides the seed used by Rand
+const SeedEnv = "ASSERTIONS_SEED"
+
+// seedFor returns the seed from SeedEnv when set, otherwise a hash of the test name
+func seedFor(tb testing.TB) (uint64, error) {
+	if env := os.Getenv(SeedEnv); env != "" {
+		return strconv.ParseUint(env, 10, 64)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(tb.Name()))
+	return h.Sum64(), nil
+}
+
+// Rand returns a random source for the current test. The seed is read from the ASSERTIONS_SEED
+// environment variable, or derived from the test name so that each test sees a stable sequence.
+// If the test fails the seed is logged so the failure can be reproduced
+func Rand(tb testing.TB) *rand.Rand {
+	const invalidFormat = "invalid %v\n > error: %v\n"
+	const seedFormat = "random seed: %v=%v\n"
+
+	seed, err := seedFor(tb)
+	if err != nil {
+		errorfNow(tb, invalidFormat, SeedEnv, err)
+		return nil
+	}
+
+	tb.Cleanup(func() {
+		if tb.Failed() {
+			tb.Logf(seedFormat, SeedEnv, seed)
+		}
+	})
+
+	return rand.New(rand.NewPCG(seed, seed))
+}
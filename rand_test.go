@@ -0,0 +1,52 @@
+package assertions
+
+import (
+	"testing"
+)
+
+func TestRandStablePerTest(t *testing.T) {
+	t.Setenv(SeedEnv, "")
+
+	a, b := Rand(t), Rand(t)
+	for range 8 {
+		Equal(t, a.Uint64(), b.Uint64())
+	}
+}
+
+func TestRandDiffersBetweenTests(t *testing.T) {
+	t.Setenv(SeedEnv, "")
+
+	var first, second uint64
+	t.Run("first", func(t *testing.T) { first = Rand(t).Uint64() })
+	t.Run("second", func(t *testing.T) { second = Rand(t).Uint64() })
+
+	Equal(t, false, first == second)
+}
+
+func TestRandSeedEnv(t *testing.T) {
+	cases := []struct {
+		name     string
+		seed     string
+		mustFail bool
+	}{
+		{name: "valid", seed: "42", mustFail: false},
+		{name: "invalid", seed: "forty two", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(SeedEnv, tc.seed)
+
+			tb := NewTester(t, tc.mustFail)
+			r := Rand(tb)
+			tb.AssertExpectation()
+
+			if !tc.mustFail {
+				seed, err := seedFor(t)
+				NoError(t, err)
+				Equal(t, uint64(42), seed)
+				NotPanics(t, func() { r.IntN(10) })
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package assertions
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// ratePerSecond formats n events over elapsed as a rate per second
+func ratePerSecond(n int, elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.4g/s", float64(n)/elapsed.Seconds())
+}
+
+// RateAtLeast asserts that fn completes at least events calls within window, calling it repeatedly and
+// stopping as soon as it has. It is a coarse regression gate for throughput sensitive code, measured in
+// real time, so events should leave headroom for slow and busy machines. Failing results print the
+// measured number of calls and rate
+func RateAtLeast(tb testing.TB, events int, window time.Duration, fn func()) {
+	const failureFormat = "Rate is too low\n > expected: %v calls in %v (%v)\n < measured: %v calls in %v (%v)\n"
+
+	summary.recordAssertion()
+
+	n := 0
+	start := time.Now()
+	elapsed := time.Duration(0)
+	for n < events && elapsed < window {
+		fn()
+		n++
+		elapsed = time.Since(start)
+	}
+
+	if n < events {
+		errorfNow(tb, failureFormat, events, window, ratePerSecond(events, window), n, elapsed, ratePerSecond(n, elapsed))
+		return
+	}
+}
+
+// RateAtLeast asserts that the count increases by at least events within window, for callbacks invoked
+// by the code under test at their own pace. The count is checked every window/100, stopping as soon as it
+// has grown enough. Failing results print the measured increase and rate
+func (c *Counter) RateAtLeast(tb testing.TB, events int, window time.Duration) {
+	const failureFormat = "Count rate is too low\n > expected: %v in %v (%v)\n < measured: %v in %v (%v)\n"
+
+	summary.recordAssertion()
+
+	start, before := time.Now(), c.Count()
+	deadline := start.Add(window)
+	ticker := time.NewTicker(max(window/100, time.Millisecond))
+	defer ticker.Stop()
+
+	n := 0
+	for {
+		n = c.Count() - before
+		if n >= events || !time.Now().Before(deadline) {
+			break
+		}
+		<-ticker.C
+	}
+
+	if n < events {
+		elapsed := time.Since(start)
+		errorfNow(tb, failureFormat, events, window, ratePerSecond(events, window), n, elapsed, ratePerSecond(n, elapsed))
+		return
+	}
+}
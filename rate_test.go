@@ -0,0 +1,79 @@
+package assertions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateAtLeast(t *testing.T) {
+	cases := []struct {
+		name     string
+		events   int
+		fn       func()
+		mustFail bool
+	}{
+		{name: "fast", events: 1000, fn: func() {}, mustFail: false},
+		{name: "slow", events: 1000, fn: func() { time.Sleep(5 * time.Millisecond) }, mustFail: true},
+		{name: "no events", events: 0, fn: func() { t.Error("fn should not be called") }, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			RateAtLeast(tb, tc.events, 50*time.Millisecond, tc.fn)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestRateAtLeastStopsEarly(t *testing.T) {
+	calls := 0
+	start := time.Now()
+	RateAtLeast(t, 10, time.Minute, func() { calls++ })
+
+	Equal(t, 10, calls)
+	Equal(t, true, time.Since(start) < time.Second)
+}
+
+func TestRatePerSecond(t *testing.T) {
+	Equal(t, "2000/s", ratePerSecond(100, 50*time.Millisecond))
+	Equal(t, "0.5/s", ratePerSecond(1, 2*time.Second))
+	Equal(t, "-", ratePerSecond(1, 0))
+}
+
+func TestCounterRateAtLeast(t *testing.T) {
+	cases := []struct {
+		name     string
+		events   int
+		mustFail bool
+	}{
+		{name: "enough", events: 5, mustFail: false},
+		{name: "too few", events: 1000, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var c Counter
+			c.Add(100)
+			done := make(chan struct{})
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				for {
+					select {
+					case <-done:
+						return
+					case <-time.After(time.Millisecond):
+						c.Inc()
+					}
+				}
+			}()
+			defer func() { close(done); <-stopped }()
+
+			tb := NewTester(t, tc.mustFail)
+			c.RateAtLeast(tb, tc.events, 100*time.Millisecond)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package assertions
+
+import (
+	"iter"
+	"testing"
+)
+
+// defaultSeqLimit is the number of elements collected from a sequence when no SeqLimit is given
+const defaultSeqLimit = 100_000
+
+type seqConfig struct {
+	limit int
+}
+
+// SeqOption configures the assertions on iterators
+type SeqOption func(*seqConfig)
+
+// SeqLimit sets the number of elements collected from a sequence, a sequence yielding more fails the
+// assertion. The default is 100000, which keeps infinite sequences from running forever
+func SeqLimit(n int) SeqOption {
+	return func(c *seqConfig) {
+		c.limit = n
+	}
+}
+
+func newSeqConfig(opts []SeqOption) seqConfig {
+	cfg := seqConfig{limit: defaultSeqLimit}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// collectSeq collects at most limit elements of seq, reporting whether seq ended within the limit
+func collectSeq[E any](seq iter.Seq[E], limit int) ([]E, bool) {
+	var out []E
+	for e := range seq {
+		if len(out) == limit {
+			return out, false
+		}
+		out = append(out, e)
+	}
+	return out, true
+}
+
+const seqLimitFormat = "Sequence yielded more than %v elements\n ~ see SeqLimit\n"
+
+// SeqEqual asserts that input yields exactly the elements of expected in order, compared as by Equal
+func SeqEqual[E any](tb testing.TB, expected []E, input iter.Seq[E], opts ...SeqOption) {
+	const failureFormat = "Sequences are not equal\n%v"
+
+	summary.recordAssertion()
+
+	cfg := newSeqConfig(opts)
+	collected, ok := collectSeq(input, cfg.limit)
+	if !ok {
+		errorfNow(tb, seqLimitFormat, cfg.limit)
+		return
+	}
+
+	// A sequence that yields nothing matches both a nil and an empty expected slice
+	if len(expected) == 0 && len(collected) == 0 {
+		return
+	}
+	if diffs := diffValues("", expected, collected); len(diffs) > 0 {
+		errorfNow(tb, failureFormat, formatDifferences(diffs))
+		return
+	}
+}
+
+// SeqMatch asserts that input yields the elements of expected in any order, compared as by SlicesMatch
+func SeqMatch[E any](tb testing.TB, expected []E, input iter.Seq[E], opts ...SeqOption) {
+	summary.recordAssertion()
+
+	cfg := newSeqConfig(opts)
+	collected, ok := collectSeq(input, cfg.limit)
+	if !ok {
+		errorfNow(tb, seqLimitFormat, cfg.limit)
+		return
+	}
+
+	slicesMatch(tb, expected, collected)
+}
+
+// Seq2Match asserts that input yields the key value pairs of expected in any order, compared as by MapsMatch.
+// A key yielded more than once fails the assertion
+func Seq2Match[K comparable, V any](tb testing.TB, expected map[K]V, input iter.Seq2[K, V], opts ...SeqOption) {
+	const duplicateFormat = "Sequence yielded a key more than once\n ~ key: %v\n > first:  %v\n < second: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := newSeqConfig(opts)
+	collected := make(map[K]V)
+	for k, v := range input {
+		if first, ok := collected[k]; ok {
+			errorfNow(tb, duplicateFormat, formatValue(k), formatValue(first), formatValue(v))
+			return
+		}
+		if len(collected) == cfg.limit {
+			errorfNow(tb, seqLimitFormat, cfg.limit)
+			return
+		}
+		collected[k] = v
+	}
+
+	mapsMatch(tb, expected, collected)
+}
+
+// SeqEvery asserts that every element yielded by input satisfies pred. Elements are consumed one at a time
+// and the sequence is stopped at the first violation. Only the first elements up to the limit set by
+// SeqLimit are checked, so infinite sequences may be asserted
+func SeqEvery[E any](tb testing.TB, input iter.Seq[E], pred func(E) bool, opts ...SeqOption) {
+	const failureFormat = "Sequence element does not satisfy the predicate\n ~ index: %v\n < input: %v\n"
+
+	summary.recordAssertion()
+
+	cfg := newSeqConfig(opts)
+	i := 0
+	for e := range input {
+		if i == cfg.limit {
+			return
+		}
+		if !pred(e) {
+			errorfNow(tb, failureFormat, i, formatValue(e))
+			return
+		}
+		i++
+	}
+}
+
+// SeqCountAtLeast asserts that at least n elements yielded by input satisfy pred. The sequence is stopped
+// as soon as n matching elements have been seen, or once the limit set by SeqLimit has been examined
+func SeqCountAtLeast[E any](tb testing.TB, input iter.Seq[E], pred func(E) bool, n int, opts ...SeqOption) {
+	const failureFormat = "Sequence has too few elements satisfying the predicate\n > expected at least: %v\n < input:             %v of %v elements\n"
+	const limitFormat = "Sequence has too few elements satisfying the predicate within the limit\n > expected at least: %v\n < input:             %v of the first %v elements\n ~ see SeqLimit\n"
+
+	summary.recordAssertion()
+
+	cfg := newSeqConfig(opts)
+	if n <= 0 {
+		return
+	}
+
+	seen, matched := 0, 0
+	for e := range input {
+		if seen == cfg.limit {
+			errorfNow(tb, limitFormat, n, matched, seen)
+			return
+		}
+		seen++
+		if pred(e) {
+			matched++
+			if matched == n {
+				return
+			}
+		}
+	}
+
+	errorfNow(tb, failureFormat, n, matched, seen)
+}
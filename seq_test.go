@@ -0,0 +1,170 @@
+package assertions
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// naturals yields 0, 1, 2, ... without end
+func naturals(yield func(int) bool) {
+	for i := 0; ; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+func TestSeqEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []int
+		input    iter.Seq[int]
+		opts     []SeqOption
+		mustFail bool
+	}{
+		{name: "equal", expected: []int{1, 2, 3}, input: slices.Values([]int{1, 2, 3}), mustFail: false},
+		{name: "empty", expected: nil, input: slices.Values([]int(nil)), mustFail: false},
+		{name: "empty slice", expected: []int{}, input: slices.Values([]int(nil)), mustFail: false},
+		{name: "order", expected: []int{1, 2, 3}, input: slices.Values([]int{3, 2, 1}), mustFail: true},
+		{name: "shorter", expected: []int{1, 2, 3}, input: slices.Values([]int{1, 2}), mustFail: true},
+		{name: "infinite", expected: []int{0, 1, 2}, input: naturals, opts: []SeqOption{SeqLimit(10)}, mustFail: true},
+		{name: "within limit", expected: []int{1, 2}, input: slices.Values([]int{1, 2}), opts: []SeqOption{SeqLimit(2)}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SeqEqual(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSeqMatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []string
+		input    iter.Seq[string]
+		mustFail bool
+	}{
+		{name: "same order", expected: []string{"a", "b"}, input: slices.Values([]string{"a", "b"}), mustFail: false},
+		{name: "any order", expected: []string{"a", "b", "b"}, input: slices.Values([]string{"b", "a", "b"}), mustFail: false},
+		{name: "different multiplicity", expected: []string{"a", "b", "b"}, input: slices.Values([]string{"a", "a", "b"}), mustFail: true},
+		{name: "extra element", expected: []string{"a"}, input: slices.Values([]string{"a", "b"}), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SeqMatch(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSeq2Match(t *testing.T) {
+	repeated := func(yield func(string, int) bool) {
+		_ = yield("a", 1) && yield("a", 2)
+	}
+
+	cases := []struct {
+		name     string
+		expected map[string]int
+		input    iter.Seq2[string, int]
+		opts     []SeqOption
+		mustFail bool
+	}{
+		{name: "equal", expected: map[string]int{"a": 1, "b": 2}, input: maps.All(map[string]int{"a": 1, "b": 2}), mustFail: false},
+		{name: "different value", expected: map[string]int{"a": 1, "b": 2}, input: maps.All(map[string]int{"a": 1, "b": 3}), mustFail: true},
+		{name: "missing key", expected: map[string]int{"a": 1, "b": 2}, input: maps.All(map[string]int{"a": 1}), mustFail: true},
+		{name: "repeated key", expected: map[string]int{"a": 1}, input: repeated, mustFail: true},
+		{name: "over limit", expected: map[string]int{"a": 1, "b": 2}, input: maps.All(map[string]int{"a": 1, "b": 2}), opts: []SeqOption{SeqLimit(1)}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			Seq2Match(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSeqEvery(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	evens := func(yield func(int) bool) {
+		for i := 0; yield(i); i += 2 {
+		}
+	}
+
+	cases := []struct {
+		name     string
+		input    iter.Seq[int]
+		opts     []SeqOption
+		mustFail bool
+	}{
+		{name: "all satisfy", input: slices.Values([]int{0, 2, 4}), mustFail: false},
+		{name: "empty", input: slices.Values([]int(nil)), mustFail: false},
+		{name: "violation", input: slices.Values([]int{0, 3, 4}), mustFail: true},
+		{name: "infinite with violation", input: naturals, mustFail: true},
+		{name: "infinite within limit", input: evens, opts: []SeqOption{SeqLimit(100)}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SeqEvery(tb, tc.input, even, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSeqEveryStopsAtViolation(t *testing.T) {
+	var consumed []int
+	input := func(yield func(int) bool) {
+		for _, n := range []int{2, 4, 5, 6, 8} {
+			consumed = append(consumed, n)
+			if !yield(n) {
+				return
+			}
+		}
+	}
+
+	tb := NewTester(t, true)
+	SeqEvery(tb, input, func(n int) bool { return n%2 == 0 })
+	tb.AssertExpectation()
+	Equal(t, []int{2, 4, 5}, consumed)
+}
+
+func TestSeqCountAtLeast(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+
+	cases := []struct {
+		name     string
+		input    iter.Seq[int]
+		n        int
+		opts     []SeqOption
+		mustFail bool
+	}{
+		{name: "enough", input: slices.Values([]int{1, 2, 3, 4}), n: 2, mustFail: false},
+		{name: "too few", input: slices.Values([]int{1, 2, 3}), n: 2, mustFail: true},
+		{name: "zero", input: slices.Values([]int(nil)), n: 0, mustFail: false},
+		{name: "infinite", input: naturals, n: 1000, mustFail: false},
+		{name: "infinite over limit", input: naturals, n: 1000, opts: []SeqOption{SeqLimit(100)}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SeqCountAtLeast(tb, tc.input, even, tc.n, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
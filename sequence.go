@@ -0,0 +1,108 @@
+package assertions
+
+import (
+	"reflect"
+	"testing"
+)
+
+// matchSubsequence returns the number of leading elements of sub that appear
+// in full in order, and the index in full just past the last matched element
+func matchSubsequence[E any, T ~[]E](sub, full T) (matched int, after int) {
+	for i := 0; i < len(full) && matched < len(sub); i++ {
+		if reflect.DeepEqual(sub[matched], full[i]) {
+			matched++
+			after = i + 1
+		}
+	}
+
+	return matched, after
+}
+
+// longestRun returns the offset in full where the longest prefix of sub
+// begins, and the length of that prefix
+func longestRun[E any, T ~[]E](sub, full T) (offset int, length int) {
+	for start := range full {
+		n := 0
+		for n < len(sub) && start+n < len(full) && reflect.DeepEqual(sub[n], full[start+n]) {
+			n++
+		}
+		if n > length {
+			offset, length = start, n
+		}
+		if length == len(sub) {
+			break
+		}
+	}
+
+	return offset, length
+}
+
+// ContainsSubsequence asserts that the elements of sub appear in full in the same relative order,
+// other elements may appear between them. Elements are compared using reflect.DeepEqual.
+// Failing results report the first element of sub that could not be matched
+func ContainsSubsequence[E any, T ~[]E](tb testing.TB, sub, full T) {
+	const failureFormat = "Subsequence not found\n > matched %v of %v elements\n > element %v (%#v) not found at or after index %v\n < input: %#v\n"
+
+	summary.recordAssertion()
+
+	matched, after := matchSubsequence(sub, full)
+	if matched < len(sub) {
+		errorfNow(tb, failureFormat, matched, len(sub), matched, sub[matched], after, full)
+		return
+	}
+}
+
+// ContainsRun asserts that the elements of sub appear contiguously and in order in full.
+// Elements are compared using reflect.DeepEqual.
+// Failing results report the longest partial run found in full
+func ContainsRun[E any, T ~[]E](tb testing.TB, sub, full T) {
+	const failureFormat = "Run not found\n > longest partial run matched %v of %v elements starting at index %v\n > expected: %#v\n < input:    %#v\n"
+
+	summary.recordAssertion()
+
+	if len(sub) == 0 {
+		return
+	}
+
+	offset, length := longestRun(sub, full)
+	if length < len(sub) {
+		errorfNow(tb, failureFormat, length, len(sub), offset, sub, full)
+		return
+	}
+}
+
+func firstIndex[E any, K comparable, T ~[]E](events T, key func(E) K, k K) int {
+	for i, event := range events {
+		if key(event) == k {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// HappensBefore asserts that the first event with key first occurs before the first event with key second.
+// The key of each event is computed by key. Both keys must occur in events
+func HappensBefore[E any, K comparable, T ~[]E](tb testing.TB, events T, key func(E) K, first, second K) {
+	const missingFormat = "Event not found\n > key: %#v\n < events: %#v\n"
+	const failureFormat = "Events are out of order\n > expected %#v (index %v) before %#v (index %v)\n"
+
+	summary.recordAssertion()
+
+	firstIdx := firstIndex(events, key, first)
+	if firstIdx < 0 {
+		errorfNow(tb, missingFormat, first, events)
+		return
+	}
+
+	secondIdx := firstIndex(events, key, second)
+	if secondIdx < 0 {
+		errorfNow(tb, missingFormat, second, events)
+		return
+	}
+
+	if firstIdx >= secondIdx {
+		errorfNow(tb, failureFormat, first, firstIdx, second, secondIdx)
+		return
+	}
+}
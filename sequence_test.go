@@ -0,0 +1,96 @@
+package assertions
+
+import "testing"
+
+func TestContainsSubsequence(t *testing.T) {
+	cases := []struct {
+		name     string
+		sub      []any
+		full     []any
+		mustFail bool
+	}{
+		{name: "empty sub", sub: nil, full: []any{1, 2, 3}, mustFail: false},
+		{name: "both empty", sub: []any{}, full: nil, mustFail: false},
+		{name: "contiguous", sub: []any{2, 3}, full: []any{1, 2, 3, 4}, mustFail: false},
+		{name: "interleaved", sub: []any{"start", "stop"}, full: []any{"start", "noise", "tick", "stop"}, mustFail: false},
+		{name: "out of order", sub: []any{3, 1}, full: []any{1, 2, 3}, mustFail: true},
+		{name: "missing", sub: []any{1, 5}, full: []any{1, 2, 3}, mustFail: true},
+		{name: "repeated needs repeats", sub: []any{1, 1}, full: []any{1, 2, 3}, mustFail: true},
+		{name: "longer than full", sub: []any{1, 2, 3, 4}, full: []any{1, 2, 3}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ContainsSubsequence(tb, tc.sub, tc.full)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestContainsRun(t *testing.T) {
+	cases := []struct {
+		name     string
+		sub      []any
+		full     []any
+		mustFail bool
+	}{
+		{name: "empty sub", sub: nil, full: []any{1, 2, 3}, mustFail: false},
+		{name: "prefix", sub: []any{1, 2}, full: []any{1, 2, 3}, mustFail: false},
+		{name: "suffix", sub: []any{2, 3}, full: []any{1, 2, 3}, mustFail: false},
+		{name: "after partial match", sub: []any{1, 2, 3}, full: []any{1, 2, 1, 2, 3}, mustFail: false},
+		{name: "interleaved", sub: []any{1, 3}, full: []any{1, 2, 3}, mustFail: true},
+		{name: "truncated", sub: []any{2, 3, 4}, full: []any{1, 2, 3}, mustFail: true},
+		{name: "empty full", sub: []any{1}, full: nil, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ContainsRun(tb, tc.sub, tc.full)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestHappensBefore(t *testing.T) {
+	type event struct {
+		name string
+		at   int
+	}
+
+	events := []event{
+		{name: "open", at: 1},
+		{name: "read", at: 2},
+		{name: "read", at: 3},
+		{name: "close", at: 4},
+		{name: "open", at: 5},
+	}
+	key := func(e event) string { return e.name }
+
+	cases := []struct {
+		name     string
+		first    string
+		second   string
+		mustFail bool
+	}{
+		{name: "in order", first: "open", second: "close", mustFail: false},
+		{name: "adjacent", first: "open", second: "read", mustFail: false},
+		{name: "out of order", first: "close", second: "read", mustFail: true},
+		{name: "first occurrence only", first: "close", second: "open", mustFail: true},
+		{name: "same key", first: "read", second: "read", mustFail: true},
+		{name: "missing first", first: "write", second: "close", mustFail: true},
+		{name: "missing second", first: "open", second: "write", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			HappensBefore(tb, events, key, tc.first, tc.second)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package assertions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Set is an unordered collection of unique comparable elements.
+// The assertion methods compare sets in linear time, making them preferable to SlicesMatch for large inputs
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a Set containing each of elements
+func NewSet[T comparable](elements ...T) Set[T] {
+	s := make(Set[T], len(elements))
+	for _, e := range elements {
+		s[e] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts each of elements into the set
+func (s Set[T]) Add(elements ...T) {
+	for _, e := range elements {
+		s[e] = struct{}{}
+	}
+}
+
+// Contains reports whether element is a member of the set
+func (s Set[T]) Contains(element T) bool {
+	_, ok := s[element]
+	return ok
+}
+
+// difference returns the elements of s that are not in other, sorted by their printed form
+func (s Set[T]) difference(other Set[T]) []T {
+	out := make([]T, 0)
+	for e := range s {
+		if !other.Contains(e) {
+			out = append(out, e)
+		}
+	}
+	sortPrinted(out)
+	return out
+}
+
+// intersection returns the elements in both s and other, sorted by their printed form
+func (s Set[T]) intersection(other Set[T]) []T {
+	out := make([]T, 0)
+	for e := range s {
+		if other.Contains(e) {
+			out = append(out, e)
+		}
+	}
+	sortPrinted(out)
+	return out
+}
+
+// sortPrinted sorts elements by their %#v representation so failure output is stable.
+// Each element is printed once rather than at every comparison
+func sortPrinted[T any](elements []T) {
+	type printed struct {
+		s string
+		e T
+	}
+
+	sorted := make([]printed, len(elements))
+	for i, e := range elements {
+		sorted[i] = printed{s: fmt.Sprintf("%#v", e), e: e}
+	}
+	slices.SortFunc(sorted, func(a, b printed) int {
+		return strings.Compare(a.s, b.s)
+	})
+	for i, p := range sorted {
+		elements[i] = p.e
+	}
+}
+
+// ElementsEqual asserts that s, the expected set, and input have exactly the same members.
+// Failing results will only print the non-matching elements
+func (s Set[T]) ElementsEqual(tb testing.TB, input Set[T]) {
+	const failureFormat = "Sets are not equal\n > only in expected: %#v\n < only in input:    %#v\n"
+
+	summary.recordAssertion()
+
+	expectedOnly, inputOnly := s.difference(input), input.difference(s)
+	if len(expectedOnly) > 0 || len(inputOnly) > 0 {
+		errorfNow(tb, failureFormat, expectedOnly, inputOnly)
+		return
+	}
+}
+
+// IsSubsetOf asserts that every member of s is also a member of superset
+func (s Set[T]) IsSubsetOf(tb testing.TB, superset Set[T]) {
+	const failureFormat = "Set is not a subset\n > missing from superset: %#v\n"
+
+	summary.recordAssertion()
+
+	missing := s.difference(superset)
+	if len(missing) > 0 {
+		errorfNow(tb, failureFormat, missing)
+		return
+	}
+}
+
+// IntersectionEmpty asserts that s and other have no members in common
+func (s Set[T]) IntersectionEmpty(tb testing.TB, other Set[T]) {
+	const failureFormat = "Sets intersect\n > common elements: %#v\n"
+
+	summary.recordAssertion()
+
+	common := s.intersection(other)
+	if len(common) > 0 {
+		errorfNow(tb, failureFormat, common)
+		return
+	}
+}
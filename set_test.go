@@ -0,0 +1,74 @@
+package assertions
+
+import "testing"
+
+func TestSetElementsEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected Set[string]
+		input    Set[string]
+		mustFail bool
+	}{
+		{name: "nil and empty", expected: nil, input: NewSet[string](), mustFail: false},
+		{name: "equal", expected: NewSet("a", "b", "c"), input: NewSet("c", "b", "a"), mustFail: false},
+		{name: "duplicates collapse", expected: NewSet("a", "a", "b"), input: NewSet("b", "a"), mustFail: false},
+		{name: "missing element", expected: NewSet("a", "b", "c"), input: NewSet("a", "b"), mustFail: true},
+		{name: "extra element", expected: NewSet("a"), input: NewSet("a", "b"), mustFail: true},
+		{name: "disjoint", expected: NewSet("a"), input: NewSet("b"), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.expected.ElementsEqual(tb, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSetIsSubsetOf(t *testing.T) {
+	cases := []struct {
+		name     string
+		subset   Set[int]
+		superset Set[int]
+		mustFail bool
+	}{
+		{name: "empty subset", subset: NewSet[int](), superset: NewSet(1, 2), mustFail: false},
+		{name: "proper subset", subset: NewSet(1), superset: NewSet(1, 2), mustFail: false},
+		{name: "equal sets", subset: NewSet(1, 2), superset: NewSet(2, 1), mustFail: false},
+		{name: "superset", subset: NewSet(1, 2, 3), superset: NewSet(1, 2), mustFail: true},
+		{name: "empty superset", subset: NewSet(1), superset: nil, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.subset.IsSubsetOf(tb, tc.superset)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSetIntersectionEmpty(t *testing.T) {
+	cases := []struct {
+		name     string
+		a        Set[int]
+		b        Set[int]
+		mustFail bool
+	}{
+		{name: "both empty", a: nil, b: nil, mustFail: false},
+		{name: "disjoint", a: NewSet(1, 2), b: NewSet(3, 4), mustFail: false},
+		{name: "overlap", a: NewSet(1, 2), b: NewSet(2, 3), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.a.IntersectionEmpty(tb, tc.b)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Spy wraps a function value of type F, recording the arguments of every call.
+// Calls are forwarded to the wrapped function unless canned results are set with Returns
+type Spy[F any] struct {
+	fn      reflect.Value
+	typ     reflect.Type
+	results []reflect.Value
+
+	mu    sync.Mutex
+	calls [][]any
+}
+
+// NewSpy returns a Spy wrapping fn, which may be nil to return zero values.
+// NewSpy panics if F is not a function type
+func NewSpy[F any](fn F) *Spy[F] {
+	typ := reflect.TypeFor[F]()
+	if typ.Kind() != reflect.Func {
+		panic(fmt.Sprintf("assertions: NewSpy requires a function type, got %v", typ))
+	}
+
+	return &Spy[F]{fn: reflect.ValueOf(fn), typ: typ}
+}
+
+// Returns sets canned results returned by every call instead of calling the wrapped function.
+// Returns panics if the results do not match the function's result types
+func (s *Spy[F]) Returns(results ...any) *Spy[F] {
+	if len(results) != s.typ.NumOut() {
+		panic(fmt.Sprintf("assertions: %v returns %v values, got %v", s.typ, s.typ.NumOut(), len(results)))
+	}
+
+	values := make([]reflect.Value, len(results))
+	for i, r := range results {
+		out := s.typ.Out(i)
+		if r == nil {
+			values[i] = reflect.Zero(out)
+			continue
+		}
+		v := reflect.ValueOf(r)
+		if !v.Type().AssignableTo(out) {
+			panic(fmt.Sprintf("assertions: result %v of %v has type %v, got %v", i, s.typ, out, v.Type()))
+		}
+		values[i] = reflect.New(out).Elem()
+		values[i].Set(v)
+	}
+
+	s.mu.Lock()
+	s.results = values
+	s.mu.Unlock()
+	return s
+}
+
+// Func returns a function of type F that records its arguments and returns the spy's results
+func (s *Spy[F]) Func() F {
+	return reflect.MakeFunc(s.typ, s.call).Interface().(F)
+}
+
+func (s *Spy[F]) call(args []reflect.Value) []reflect.Value {
+	recorded := make([]any, len(args))
+	for i, a := range args {
+		recorded[i] = a.Interface()
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, recorded)
+	results := s.results
+	s.mu.Unlock()
+
+	if results != nil {
+		return results
+	}
+
+	if s.fn.IsValid() && !s.fn.IsNil() {
+		if s.typ.IsVariadic() {
+			return s.fn.CallSlice(args)
+		}
+		return s.fn.Call(args)
+	}
+
+	zeros := make([]reflect.Value, s.typ.NumOut())
+	for i := range zeros {
+		zeros[i] = reflect.Zero(s.typ.Out(i))
+	}
+	return zeros
+}
+
+// Calls returns the arguments of every call made so far, in order.
+// The arguments of a variadic function's final parameter are recorded as a single slice
+func (s *Spy[F]) Calls() [][]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([][]any(nil), s.calls...)
+}
+
+// CalledTimes asserts that the spy was called exactly expected times
+func (s *Spy[F]) CalledTimes(tb testing.TB, expected int) {
+	const failureFormat = "Unexpected number of calls\n > expected: %v\n < calls:    %v\n"
+
+	summary.recordAssertion()
+
+	if n := len(s.Calls()); n != expected {
+		errorfNow(tb, failureFormat, expected, n)
+		return
+	}
+}
+
+// NeverCalled asserts that the spy was not called
+func (s *Spy[F]) NeverCalled(tb testing.TB) {
+	const failureFormat = "Unexpected calls\n%v"
+
+	summary.recordAssertion()
+
+	if calls := s.Calls(); len(calls) > 0 {
+		errorfNow(tb, failureFormat, formatCalls(calls))
+		return
+	}
+}
+
+// CalledWith asserts that at least one call received arguments equal to args with the semantics of Equal,
+// each compared at the type of its parameter so that nil matches a nil pointer or error.
+// Anything may be used to accept any value for an argument
+func (s *Spy[F]) CalledWith(tb testing.TB, args ...any) {
+	const failureFormat = "No call matched the expected arguments\n > expected: %v\n < calls:\n%v"
+
+	summary.recordAssertion()
+
+	calls := s.Calls()
+	for _, call := range calls {
+		if s.matches(args, call) {
+			return
+		}
+	}
+
+	errorfNow(tb, failureFormat, formatArgs(args), formatCalls(calls))
+}
+
+// matches reports whether call received args
+func (s *Spy[F]) matches(args, call []any) bool {
+	if len(args) != len(call) {
+		return false
+	}
+
+	d := differ{cfg: compareConfig{comparers: snapshotComparers()}}
+	for i := range args {
+		in := s.typ.In(i)
+		d.walk(&diffPath{}, asType(args[i], in), asType(call[i], in))
+		if len(d.diffs) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func formatArgs(args []any) string {
+	formatted := make([]string, len(args))
+	for i, a := range args {
+		formatted[i] = formatValue(a)
+	}
+	return "(" + strings.Join(formatted, ", ") + ")"
+}
+
+func formatCalls(calls [][]any) string {
+	if len(calls) == 0 {
+		return "   (none)\n"
+	}
+
+	var b strings.Builder
+	for i, call := range calls {
+		fmt.Fprintf(&b, "   %v: %v\n", i, formatArgs(call))
+	}
+	return b.String()
+}
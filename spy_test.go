@@ -0,0 +1,102 @@
+package assertions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSpy(t *testing.T) {
+	spy := NewSpy(strings.Repeat)
+	repeat := spy.Func()
+
+	Equal(t, "abab", repeat("ab", 2))
+	Equal(t, "x", repeat("x", 1))
+
+	cases := []struct {
+		name     string
+		assert   func(tb testing.TB)
+		mustFail bool
+	}{
+		{name: "called times", assert: func(tb testing.TB) { spy.CalledTimes(tb, 2) }, mustFail: false},
+		{name: "wrong called times", assert: func(tb testing.TB) { spy.CalledTimes(tb, 3) }, mustFail: true},
+		{name: "called with", assert: func(tb testing.TB) { spy.CalledWith(tb, "x", 1) }, mustFail: false},
+		{name: "called with wildcard", assert: func(tb testing.TB) { spy.CalledWith(tb, "ab", Anything) }, mustFail: false},
+		{name: "not called with", assert: func(tb testing.TB) { spy.CalledWith(tb, "x", 2) }, mustFail: true},
+		{name: "wrong arity", assert: func(tb testing.TB) { spy.CalledWith(tb, "x") }, mustFail: true},
+		{name: "never called", assert: func(tb testing.TB) { spy.NeverCalled(tb) }, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			tc.assert(tb)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSpyReturns(t *testing.T) {
+	spy := NewSpy[func(id int) (string, error)](nil)
+	lookup := spy.Func()
+
+	name, err := lookup(1)
+	NoError(t, err)
+	Equal(t, "", name)
+
+	spy.Returns("", errors.New("not found"))
+	_, err = lookup(2)
+	ErrorsMatch(t, errors.New("not found"), err)
+
+	spy.CalledTimes(t, 2)
+	Equal(t, [][]any{{1}, {2}}, spy.Calls())
+}
+
+func TestSpyNeverCalled(t *testing.T) {
+	spy := NewSpy(func() {})
+
+	tb := NewTester(t, false)
+	spy.NeverCalled(tb)
+	tb.AssertExpectation()
+}
+
+func TestSpyVariadic(t *testing.T) {
+	spy := NewSpy(func(prefix string, parts ...string) string { return prefix + strings.Join(parts, "") })
+
+	Equal(t, "abc", spy.Func()("a", "b", "c"))
+	spy.CalledWith(t, "a", []string{"b", "c"})
+}
+
+func TestSpyCalledWithNil(t *testing.T) {
+	type node struct{ next *node }
+	spy := NewSpy(func(n *node, err error, v any) {})
+	spy.Func()(nil, nil, nil)
+
+	cases := []struct {
+		name     string
+		args     []any
+		mustFail bool
+	}{
+		{name: "nil", args: []any{nil, nil, nil}, mustFail: false},
+		{name: "typed nil", args: []any{(*node)(nil), error(nil), nil}, mustFail: false},
+		{name: "non-nil pointer", args: []any{&node{}, nil, nil}, mustFail: true},
+		{name: "non-nil error", args: []any{nil, errors.New("x"), nil}, mustFail: true},
+		{name: "typed nil in interface", args: []any{nil, nil, (*node)(nil)}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			spy.CalledWith(tb, tc.args...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestSpyInvalid(t *testing.T) {
+	Panics(t, func() { NewSpy(42) })
+	Panics(t, func() { NewSpy(func() int { return 0 }).Returns("x") })
+	Panics(t, func() { NewSpy(func() int { return 0 }).Returns(1, 2) })
+}
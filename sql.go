@@ -0,0 +1,176 @@
+package assertions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+type sqlConfig struct {
+	anyPlaceholder bool
+}
+
+// SQLOption configures SQLEqual
+type SQLOption func(*sqlConfig)
+
+// SQLAnyPlaceholder treats the placeholder styles ?, $1, :name and @name as equal, so a query built for
+// one driver may be compared against one written for another. Placeholder order is still compared
+func SQLAnyPlaceholder() SQLOption {
+	return func(c *sqlConfig) {
+		c.anyPlaceholder = true
+	}
+}
+
+// sqlOperators are the multi-character operators kept as single tokens, longest first
+var sqlOperators = []string{"<=>", "->>", "<>", "!=", "<=", ">=", "||", "::", "->", "=>"}
+
+// tokenizeSQL splits query into tokens, dropping whitespace and comments.
+// Unquoted words are upper cased, quoted strings and identifiers are kept as written
+func tokenizeSQL(query string, cfg sqlConfig) ([]string, error) {
+	var tokens []string
+
+	// scan returns the offset just past the run of word runes starting at i
+	scan := func(i int) int {
+		for i < len(query) {
+			r, size := utf8.DecodeRuneInString(query[i:])
+			if !isSQLWordRune(r) {
+				break
+			}
+			i += size
+		}
+		return i
+	}
+
+	for i := 0; i < len(query); {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		rest := query[i:]
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case strings.HasPrefix(rest, "--"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			i += end
+
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %v", i)
+			}
+			i += end + 4
+
+		case r == '\'' || r == '"' || r == '`':
+			// A quote is escaped by doubling it
+			j := i + 1
+			for {
+				end := strings.IndexRune(query[j:], r)
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quote at offset %v", i)
+				}
+				j += end + 1
+				if j < len(query) && rune(query[j]) == r {
+					j++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, query[i:j])
+			i = j
+
+		case r == '?' || (r == '$' || r == ':' || r == '@') && scan(i+1) > i+1:
+			j := scan(i + 1)
+			token := query[i:j]
+			if cfg.anyPlaceholder {
+				token = "?"
+			}
+			tokens = append(tokens, token)
+			i = j
+
+		case isSQLWordRune(r):
+			j := scan(i)
+			// Keep the fractional part of numbers such as 1.5 in the same token
+			if unicode.IsDigit(r) && j+1 < len(query) && query[j] == '.' {
+				j = scan(j + 1)
+			}
+			tokens = append(tokens, strings.ToUpper(query[i:j]))
+			i = j
+
+		default:
+			token := rest[:size]
+			for _, op := range sqlOperators {
+				if strings.HasPrefix(rest, op) {
+					token = op
+					break
+				}
+			}
+			tokens = append(tokens, token)
+			i += len(token)
+		}
+	}
+
+	if len(tokens) > 0 && tokens[len(tokens)-1] == ";" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return tokens, nil
+}
+
+func isSQLWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// formatTokenDiff prints the tokens of input separated by spaces, marking tokens only in expected with [-token-]
+// and tokens only in input with {+token+}
+func formatTokenDiff(expected, input []string) string {
+	ops := alignLines(expected, input)
+
+	parts := make([]string, len(ops))
+	for i, op := range ops {
+		switch op.kind {
+		case '-':
+			parts[i] = "[-" + op.line + "-]"
+		case '+':
+			parts[i] = "{+" + op.line + "+}"
+		default:
+			parts[i] = op.line
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// SQLEqual asserts that expected and input are the same SQL query ignoring whitespace, comments, a trailing
+// semicolon and the case of keywords and other unquoted words. Quoted strings and identifiers must match exactly.
+// Failing results print the tokens of the query with removed tokens as [-token-] and added tokens as {+token+}
+func SQLEqual(tb testing.TB, expected, input string, opts ...SQLOption) {
+	const invalidFormat = "SQL could not be tokenized\n ~ %v\n > error: %v\n"
+	const failureFormat = "Queries are not equal\n ~ %v\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	var cfg sqlConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	et, err := tokenizeSQL(expected, cfg)
+	if err != nil {
+		errorfNow(tb, invalidFormat, "expected", err)
+		return
+	}
+	it, err := tokenizeSQL(input, cfg)
+	if err != nil {
+		errorfNow(tb, invalidFormat, "input", err)
+		return
+	}
+
+	if !slices.Equal(et, it) {
+		errorfNow(tb, failureFormat, formatTokenDiff(et, it), strings.Join(et, " "), strings.Join(it, " "))
+		return
+	}
+}
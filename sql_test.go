@@ -0,0 +1,104 @@
+package assertions
+
+import "testing"
+
+func TestSQLEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		opts     []SQLOption
+		mustFail bool
+	}{
+		{
+			name:     "identical",
+			expected: "SELECT id FROM users WHERE id = ?",
+			input:    "SELECT id FROM users WHERE id = ?",
+			mustFail: false,
+		},
+		{
+			name:     "whitespace case and semicolon",
+			expected: "SELECT id, name FROM users WHERE id = ?",
+			input:    "select id,name\n  from users\n  where id=? ;",
+			mustFail: false,
+		},
+		{
+			name:     "comments",
+			expected: "SELECT id FROM users",
+			input:    "SELECT id -- primary key\nFROM /* all */ users",
+			mustFail: false,
+		},
+		{
+			name:     "string literal case",
+			expected: "SELECT id FROM users WHERE name = 'Alice'",
+			input:    "SELECT id FROM users WHERE name = 'alice'",
+			mustFail: true,
+		},
+		{
+			name:     "escaped quote",
+			expected: "SELECT 'it''s'",
+			input:    "select 'it''s'",
+			mustFail: false,
+		},
+		{
+			name:     "different column",
+			expected: "SELECT id FROM users",
+			input:    "SELECT name FROM users",
+			mustFail: true,
+		},
+		{
+			name:     "placeholder styles differ",
+			expected: "SELECT id FROM users WHERE id = ?",
+			input:    "SELECT id FROM users WHERE id = $1",
+			mustFail: true,
+		},
+		{
+			name:     "any placeholder",
+			expected: "SELECT id FROM users WHERE id = ? AND org = ?",
+			input:    "SELECT id FROM users WHERE id = $1 AND org = :org",
+			opts:     []SQLOption{SQLAnyPlaceholder()},
+			mustFail: false,
+		},
+		{
+			name:     "casts are not placeholders",
+			expected: "SELECT id::text FROM users",
+			input:    "SELECT id :: TEXT FROM users",
+			opts:     []SQLOption{SQLAnyPlaceholder()},
+			mustFail: false,
+		},
+		{
+			name:     "operators",
+			expected: "SELECT id FROM users WHERE age >= 18",
+			input:    "SELECT id FROM users WHERE age > = 18",
+			mustFail: true,
+		},
+		{
+			name:     "unterminated quote",
+			expected: "SELECT 'a",
+			input:    "SELECT 'a'",
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			SQLEqual(tb, tc.expected, tc.input, tc.opts...)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestFormatTokenDiff(t *testing.T) {
+	Equal(t, "SELECT [-ID-] {+NAME+} FROM USERS", formatTokenDiff(
+		[]string{"SELECT", "ID", "FROM", "USERS"},
+		[]string{"SELECT", "NAME", "FROM", "USERS"},
+	))
+}
+
+func TestTokenizeSQL(t *testing.T) {
+	tokens, err := tokenizeSQL("select u.name, 1.5 from \"Users\" u where u.id = $1 and u.tag::text <> 'a''b';", sqlConfig{})
+	NoError(t, err)
+	Equal(t, []string{"SELECT", "U", ".", "NAME", ",", "1.5", "FROM", `"Users"`, "U", "WHERE", "U", ".", "ID", "=", "$1", "AND", "U", ".", "TAG", "::", "TEXT", "<>", "'a''b'"}, tokens)
+}
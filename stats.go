@@ -0,0 +1,220 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// sampleStats summarizes a set of samples
+type sampleStats struct {
+	sorted []float64
+	mean   float64
+	stdDev float64
+}
+
+func newSampleStats[T Number](samples []T) sampleStats {
+	s := sampleStats{sorted: make([]float64, len(samples))}
+	for i, v := range samples {
+		s.sorted[i] = float64(v)
+		s.mean += float64(v)
+	}
+	slices.Sort(s.sorted)
+	s.mean /= float64(len(samples))
+
+	// The sample standard deviation, with Bessel's correction
+	if len(samples) > 1 {
+		var squares float64
+		for _, v := range s.sorted {
+			squares += (v - s.mean) * (v - s.mean)
+		}
+		s.stdDev = math.Sqrt(squares / float64(len(samples)-1))
+	}
+	return s
+}
+
+// percentile returns the p-th percentile, p in [0, 100], interpolating linearly between the closest ranks
+func (s sampleStats) percentile(p float64) float64 {
+	rank := p / 100 * float64(len(s.sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := min(lower+1, len(s.sorted)-1)
+	return s.sorted[lower] + (rank-float64(lower))*(s.sorted[upper]-s.sorted[lower])
+}
+
+func (s sampleStats) String() string {
+	return fmt.Sprintf(" ~ samples: n=%v min=%.4g mean=%.4g stddev=%.4g p50=%.4g p90=%.4g p99=%.4g max=%.4g\n",
+		len(s.sorted), s.sorted[0], s.mean, s.stdDev, s.percentile(50), s.percentile(90), s.percentile(99), s.sorted[len(s.sorted)-1])
+}
+
+func noSamples(tb testing.TB) {
+	const failureFormat = "no samples\n"
+	errorfNow(tb, failureFormat)
+}
+
+// MeanWithin asserts that the mean of samples is within the range [minMean, maxMean].
+// Failing results print summary statistics of the samples
+func MeanWithin[T Number](tb testing.TB, samples []T, minMean, maxMean float64) {
+	const failureFormat = "mean is outside the expected range\n > expected: [%v, %v]\n < mean:     %v\n%v"
+
+	summary.recordAssertion()
+
+	if len(samples) == 0 {
+		noSamples(tb)
+		return
+	}
+
+	if s := newSampleStats(samples); !(s.mean >= minMean && s.mean <= maxMean) {
+		errorfNow(tb, failureFormat, minMean, maxMean, s.mean, s)
+		return
+	}
+}
+
+// PercentileWithin asserts that the p-th percentile of samples, p in [0, 100], is within the range [minV, maxV].
+// Percentiles interpolate linearly between the closest ranks. Failing results print summary statistics of the samples
+func PercentileWithin[T Number](tb testing.TB, samples []T, p, minV, maxV float64) {
+	const invalidFormat = "percentile must be in [0, 100]\n < input: %v\n"
+	const failureFormat = "p%v is outside the expected range\n > expected: [%v, %v]\n < input:    %v\n%v"
+
+	summary.recordAssertion()
+
+	if !(p >= 0 && p <= 100) {
+		errorfNow(tb, invalidFormat, p)
+		return
+	}
+	if len(samples) == 0 {
+		noSamples(tb)
+		return
+	}
+
+	s := newSampleStats(samples)
+	if v := s.percentile(p); !(v >= minV && v <= maxV) {
+		errorfNow(tb, failureFormat, p, minV, maxV, v, s)
+		return
+	}
+}
+
+// StdDevBelow asserts that the sample standard deviation of samples is at most maxStdDev.
+// Failing results print summary statistics of the samples
+func StdDevBelow[T Number](tb testing.TB, samples []T, maxStdDev float64) {
+	const failureFormat = "standard deviation is too high\n > expected: <= %v\n < stddev:   %v\n%v"
+
+	summary.recordAssertion()
+
+	if len(samples) == 0 {
+		noSamples(tb)
+		return
+	}
+
+	if s := newSampleStats(samples); !(s.stdDev <= maxStdDev) {
+		errorfNow(tb, failureFormat, maxStdDev, s.stdDev, s)
+		return
+	}
+}
+
+// chiSquaredSurvival returns the probability that a chi-squared statistic with df degrees of freedom is at
+// least x, the regularized upper incomplete gamma function Q(df/2, x/2)
+func chiSquaredSurvival(x float64, df int) float64 {
+	a, x := float64(df)/2, x/2
+	if x <= 0 {
+		return 1
+	}
+	lnPrefix := a*math.Log(x) - x
+	lgamma, _ := math.Lgamma(a)
+	lnPrefix -= lgamma
+
+	if x < a+1 {
+		// The series for the lower function P converges quickly below a+1
+		term, sum := 1/a, 1/a
+		for n := 1.0; n < 1000 && math.Abs(term) > math.Abs(sum)*1e-15; n++ {
+			term *= x / (a + n)
+			sum += term
+		}
+		return 1 - sum*math.Exp(lnPrefix)
+	}
+
+	// Above it, the continued fraction for Q by the modified Lentz method
+	const tiny = 1e-300
+	b := x + 1 - a
+	c, d := 1/tiny, 1/b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return h * math.Exp(lnPrefix)
+}
+
+// formatBuckets lists the count of each bucket against the expected count, with a bar scaled to the largest
+func formatBuckets(counts []int, expected float64) string {
+	most := 0
+	for _, n := range counts {
+		most = max(most, n)
+	}
+
+	var b strings.Builder
+	for i, n := range counts {
+		bar := 0
+		if most > 0 {
+			bar = n * matrixBarWidth / most
+		}
+		fmt.Fprintf(&b, "   [%v] %-*v %v (%+.1f%%)\n", i, matrixBarWidth, strings.Repeat("#", bar), n, (float64(n)-expected)/expected*100)
+	}
+	return b.String()
+}
+
+// UniformlyDistributed asserts that samples, each a bucket in [0, buckets), are spread evenly over the buckets,
+// as for the positions chosen by a shuffler, the backends picked by a load balancer or hashes modulo a table size.
+// A chi-squared test fails when the chance of counts at least this uneven from a uniform source is below
+// tolerance, e.g. 0.001 to fail one run in a thousand by chance. Failing results print the count of every bucket.
+// Each bucket should expect at least 5 samples for the test to be accurate
+func UniformlyDistributed(tb testing.TB, samples []int, buckets int, tolerance float64) {
+	const bucketsFormat = "at least 2 buckets are needed\n < buckets: %v\n"
+	const rangeFormat = "sample is outside the buckets\n > expected: [0, %v)\n < sample[%v]: %v\n"
+	const failureFormat = "samples are not uniformly distributed\n ~ chi-squared: %.4g with %v degrees of freedom\n > expected: p >= %v\n < p:        %.4g\n ~ buckets, %.4g samples expected in each:\n%v"
+
+	summary.recordAssertion()
+
+	if buckets < 2 {
+		errorfNow(tb, bucketsFormat, buckets)
+		return
+	}
+	if len(samples) == 0 {
+		noSamples(tb)
+		return
+	}
+
+	counts := make([]int, buckets)
+	for i, s := range samples {
+		if s < 0 || s >= buckets {
+			errorfNow(tb, rangeFormat, buckets, i, s)
+			return
+		}
+		counts[s]++
+	}
+
+	expected := float64(len(samples)) / float64(buckets)
+	var chi2 float64
+	for _, n := range counts {
+		chi2 += (float64(n) - expected) * (float64(n) - expected) / expected
+	}
+	if p := chiSquaredSurvival(chi2, buckets-1); !(p >= tolerance) {
+		errorfNow(tb, failureFormat, chi2, buckets-1, tolerance, p, expected, formatBuckets(counts, expected))
+		return
+	}
+}
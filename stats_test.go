@@ -0,0 +1,170 @@
+package assertions
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSampleStats(t *testing.T) {
+	s := newSampleStats([]int{4, 2, 8, 6})
+
+	Equal(t, 5.0, s.mean)
+	Equal(t, true, math.Abs(s.stdDev-math.Sqrt(20.0/3)) < 1e-12)
+	Equal(t, 2.0, s.percentile(0))
+	Equal(t, 5.0, s.percentile(50))
+	Equal(t, 8.0, s.percentile(100))
+
+	single := newSampleStats([]float64{3})
+	Equal(t, 0.0, single.stdDev)
+	Equal(t, 3.0, single.percentile(99))
+}
+
+func TestMeanWithin(t *testing.T) {
+	cases := []struct {
+		name     string
+		samples  []float64
+		minMean  float64
+		maxMean  float64
+		mustFail bool
+	}{
+		{name: "within", samples: []float64{9, 10, 11}, minMean: 9.5, maxMean: 10.5, mustFail: false},
+		{name: "too low", samples: []float64{1, 2, 3}, minMean: 9.5, maxMean: 10.5, mustFail: true},
+		{name: "too high", samples: []float64{20}, minMean: 9.5, maxMean: 10.5, mustFail: true},
+		{name: "nan", samples: []float64{math.NaN()}, minMean: 0, maxMean: 1, mustFail: true},
+		{name: "no samples", samples: nil, minMean: 0, maxMean: 1, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			MeanWithin(tb, tc.samples, tc.minMean, tc.maxMean)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestPercentileWithin(t *testing.T) {
+	latencies := []int{10, 12, 11, 13, 10, 12, 11, 250, 12, 11}
+
+	cases := []struct {
+		name     string
+		p        float64
+		minV     float64
+		maxV     float64
+		mustFail bool
+	}{
+		{name: "median", p: 50, minV: 10, maxV: 12, mustFail: false},
+		{name: "tail too slow", p: 99, minV: 0, maxV: 100, mustFail: true},
+		{name: "max", p: 100, minV: 250, maxV: 250, mustFail: false},
+		{name: "invalid percentile", p: 101, minV: 0, maxV: 1000, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			PercentileWithin(tb, latencies, tc.p, tc.minV, tc.maxV)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestStdDevBelow(t *testing.T) {
+	cases := []struct {
+		name      string
+		samples   []float64
+		maxStdDev float64
+		mustFail  bool
+	}{
+		{name: "constant", samples: []float64{5, 5, 5}, maxStdDev: 0, mustFail: false},
+		{name: "below", samples: []float64{9, 10, 11}, maxStdDev: 1, mustFail: false},
+		{name: "above", samples: []float64{0, 10, 20}, maxStdDev: 1, mustFail: true},
+		{name: "no samples", samples: []float64{}, maxStdDev: 1, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			StdDevBelow(tb, tc.samples, tc.maxStdDev)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestChiSquaredSurvival(t *testing.T) {
+	cases := []struct {
+		x        float64
+		df       int
+		expected float64
+	}{
+		{x: 0, df: 3, expected: 1},
+		{x: 3.841458820694124, df: 1, expected: 0.05},
+		{x: 2, df: 2, expected: math.Exp(-1)},
+		{x: 16.918977604620448, df: 9, expected: 0.05},
+		{x: 124.3421134, df: 100, expected: 0.05},
+	}
+
+	for _, tc := range cases {
+		Equal(t, true, math.Abs(chiSquaredSurvival(tc.x, tc.df)-tc.expected) < 1e-6)
+	}
+}
+
+func TestUniformlyDistributed(t *testing.T) {
+	even := make([]int, 0, 1000)
+	skewed := make([]int, 0, 1000)
+	for i := range 1000 {
+		even = append(even, i%10)
+		skewed = append(skewed, min(i%12, 9))
+	}
+
+	cases := []struct {
+		name     string
+		samples  []int
+		buckets  int
+		mustFail bool
+	}{
+		{name: "even", samples: even, buckets: 10, mustFail: false},
+		{name: "seeded shuffle", samples: shuffledPositions(10, 1000), buckets: 10, mustFail: false},
+		{name: "skewed", samples: skewed, buckets: 10, mustFail: true},
+		{name: "out of range", samples: []int{0, 1, 10}, buckets: 10, mustFail: true},
+		{name: "one bucket", samples: []int{0, 0}, buckets: 1, mustFail: true},
+		{name: "no samples", samples: nil, buckets: 10, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			UniformlyDistributed(tb, tc.samples, tc.buckets, 0.001)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+// shuffledPositions returns where element 0 lands in n shuffles of a slice of length size, from a fixed seed
+func shuffledPositions(size, n int) []int {
+	r := rand.New(rand.NewPCG(1, 2))
+	positions := make([]int, n)
+	for i := range positions {
+		s := make([]int, size)
+		for j := range s {
+			s[j] = j
+		}
+		r.Shuffle(size, func(a, b int) { s[a], s[b] = s[b], s[a] })
+		positions[i] = slices.Index(s, 0)
+	}
+	return positions
+}
+
+func TestUniformlyDistributedMessage(t *testing.T) {
+	tb := &recordingTB{TesterTB: NewTester(t, true)}
+	UniformlyDistributed(tb, []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, 2, 0.05)
+	tb.AssertExpectation()
+
+	Equal(t, []string{"samples are not uniformly distributed\n ~ chi-squared: 6.4 with 1 degrees of freedom\n > expected: p >= 0.05\n < p:        0.01141\n ~ buckets, 5 samples expected in each:\n" +
+		"   [0] #################### 9 (+80.0%)\n   [1] ##                   1 (-80.0%)\n"}, tb.logs)
+}
@@ -0,0 +1,176 @@
+package assertions
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// normalizeWhitespace trims every line, collapses runs of whitespace within lines to a single space
+// and drops lines left empty
+func normalizeWhitespace(s string) string {
+	lines := strings.Split(s, "\n")
+	out := lines[:0]
+	for _, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			out = append(out, strings.Join(fields, " "))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// ansiEscape matches CSI sequences such as colors and cursor movement, and OSC sequences such as hyperlinks
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// normalizedEqual fails when expected and input differ after both are passed through normalize
+func normalizedEqual(tb testing.TB, header string, expected, input string, normalize func(string) string) {
+	const failureFormat = "%v\n > %v\n > expected: %q\n < input:    %q\n"
+
+	ne, ni := normalize(expected), normalize(input)
+	if ne != ni {
+		errorfNow(tb, failureFormat, header, describeBytesDifference([]byte(ne), []byte(ni)), ne, ni)
+		return
+	}
+}
+
+// EqualIgnoringWhitespace asserts that expected and input are equal once every line is trimmed, runs of
+// whitespace are collapsed to a single space and blank lines are removed.
+// Failing results print the normalized strings
+func EqualIgnoringWhitespace(tb testing.TB, expected, input string) {
+	summary.recordAssertion()
+
+	normalizedEqual(tb, "Strings are not equal ignoring whitespace", expected, input, normalizeWhitespace)
+}
+
+// EqualIgnoringANSI asserts that expected and input are equal once ANSI escape sequences, such as terminal
+// colors, are removed. Failing results print the stripped strings
+func EqualIgnoringANSI(tb testing.TB, expected, input string) {
+	summary.recordAssertion()
+
+	normalizedEqual(tb, "Strings are not equal ignoring ANSI escapes", expected, input, stripANSI)
+}
+
+// normalizeLineEndings replaces \r\n line terminators with \n
+func normalizeLineEndings(s string) string {
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
+
+// EqualIgnoringLineEndings asserts that expected and input are equal once \r\n line endings are replaced
+// with \n, so text checked out with different line ending settings compares equal
+func EqualIgnoringLineEndings(tb testing.TB, expected, input string) {
+	summary.recordAssertion()
+
+	normalizedEqual(tb, "Strings are not equal ignoring line endings", expected, input, normalizeLineEndings)
+}
+
+// splitLines splits s into lines, ignoring a final line terminator and any carriage returns before newlines.
+// An empty string has no lines
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+func formatLines(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&b, "   %4d | %v\n", i+1, line)
+	}
+	return b.String()
+}
+
+// ContainsLine asserts that one of the lines of input is exactly line
+func ContainsLine(tb testing.TB, input, line string) {
+	const failureFormat = "Line not found\n > expected line: %q\n < input:\n%v"
+
+	summary.recordAssertion()
+
+	lines := splitLines(input)
+	if !slices.Contains(lines, line) {
+		errorfNow(tb, failureFormat, line, formatLines(lines))
+		return
+	}
+}
+
+// LineCount asserts that input has exactly expected lines
+func LineCount(tb testing.TB, input string, expected int) {
+	const failureFormat = "Unexpected number of lines\n > expected: %v\n < input:    %v\n%v"
+
+	summary.recordAssertion()
+
+	lines := splitLines(input)
+	if len(lines) != expected {
+		errorfNow(tb, failureFormat, expected, len(lines), formatLines(lines))
+		return
+	}
+}
+
+// LinesMatch asserts that the lines of input are exactly expected in any order.
+// Failing results print the missing and unexpected lines
+func LinesMatch(tb testing.TB, expected []string, input string) {
+	const failureFormat = "Lines do not match\n > missing:    %q\n < unexpected: %q\n"
+
+	summary.recordAssertion()
+
+	missing, unexpected := nonMatchingSlices(expected, splitLines(input))
+	if len(missing) > 0 || len(unexpected) > 0 {
+		errorfNow(tb, failureFormat, missing, unexpected)
+		return
+	}
+}
+
+// LinesMatchRegexp asserts that input has one line per pattern and that each line matches the pattern
+// at the same position. Patterns are not anchored, use ^ and $ to match whole lines
+func LinesMatchRegexp(tb testing.TB, patterns []string, input string) {
+	const invalidFormat = "Invalid pattern\n > pattern %v: %q\n > error: %v\n"
+	const failureFormat = "Lines do not match the patterns\n%v"
+
+	summary.recordAssertion()
+
+	lines := splitLines(input)
+
+	var b strings.Builder
+	for i := range max(len(patterns), len(lines)) {
+		switch {
+		case i >= len(patterns):
+			fmt.Fprintf(&b, " ~ line %v: unexpected line %q\n", i+1, lines[i])
+			continue
+		case i >= len(lines):
+			fmt.Fprintf(&b, " ~ line %v: missing line matching %q\n", i+1, patterns[i])
+			continue
+		}
+
+		re, err := regexp.Compile(patterns[i])
+		if err != nil {
+			errorfNow(tb, invalidFormat, i+1, patterns[i], err)
+			return
+		}
+		if !re.MatchString(lines[i]) {
+			fmt.Fprintf(&b, " ~ line %v: %q does not match %q\n", i+1, lines[i], patterns[i])
+		}
+	}
+
+	if b.Len() > 0 {
+		errorfNow(tb, failureFormat, b.String())
+		return
+	}
+}
+
+// EqualNormalized asserts that expected and input are equal after both are passed through normalize.
+// It is the building block for normalizing comparisons such as EqualIgnoringWhitespace and those in submodules
+func EqualNormalized(tb testing.TB, expected, input string, normalize func(string) string) {
+	summary.recordAssertion()
+
+	normalizedEqual(tb, "Strings are not equal after normalization", expected, input, normalize)
+}
@@ -0,0 +1,193 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEqualIgnoringWhitespace(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		mustFail bool
+	}{
+		{name: "equal", expected: "a b", input: "a b", mustFail: false},
+		{name: "runs of spaces", expected: "a b", input: "a  \t b", mustFail: false},
+		{name: "indentation", expected: "func() {\n\treturn\n}", input: "func() {\n    return\n}\n", mustFail: false},
+		{name: "blank lines", expected: "a\nb", input: "\na\n\n  \nb\n\n", mustFail: false},
+		{name: "different words", expected: "a b", input: "a c", mustFail: true},
+		{name: "joined words", expected: "a b", input: "ab", mustFail: true},
+		{name: "line break is significant", expected: "a b", input: "a\nb", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EqualIgnoringWhitespace(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualIgnoringANSI(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		mustFail bool
+	}{
+		{name: "plain", expected: "ok", input: "ok", mustFail: false},
+		{name: "colors", expected: "PASS: 3 tests", input: "\x1b[1;32mPASS\x1b[0m: 3 tests", mustFail: false},
+		{name: "cursor movement", expected: "done", input: "\x1b[2K\x1b[1Gdone", mustFail: false},
+		{name: "hyperlink", expected: "docs", input: "\x1b]8;;https://example.test\x1b\\docs\x1b]8;;\x1b\\", mustFail: false},
+		{name: "different text", expected: "PASS", input: "\x1b[31mFAIL\x1b[0m", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EqualIgnoringANSI(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualIgnoringLineEndings(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		mustFail bool
+	}{
+		{name: "unix", expected: "a\nb\n", input: "a\nb\n", mustFail: false},
+		{name: "windows", expected: "a\nb\n", input: "a\r\nb\r\n", mustFail: false},
+		{name: "mixed", expected: "a\r\nb\n", input: "a\nb\r\n", mustFail: false},
+		{name: "lone carriage return", expected: "a\nb", input: "a\rb", mustFail: true},
+		{name: "different text", expected: "a\r\nb", input: "a\r\nc", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EqualIgnoringLineEndings(tb, tc.expected, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+const cliOutput = "building...\r\nok  pkg/a 0.01s\nok  pkg/b 0.20s\nFAIL pkg/c\n"
+
+func TestContainsLine(t *testing.T) {
+	cases := []struct {
+		name     string
+		line     string
+		mustFail bool
+	}{
+		{name: "present", line: "FAIL pkg/c", mustFail: false},
+		{name: "carriage return stripped", line: "building...", mustFail: false},
+		{name: "partial line", line: "FAIL", mustFail: true},
+		{name: "absent", line: "ok  pkg/c 0.01s", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			ContainsLine(tb, cliOutput, tc.line)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestLineCount(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected int
+		mustFail bool
+	}{
+		{name: "empty", input: "", expected: 0, mustFail: false},
+		{name: "no trailing newline", input: "a\nb", expected: 2, mustFail: false},
+		{name: "trailing newline", input: cliOutput, expected: 4, mustFail: false},
+		{name: "blank line counts", input: "a\n\nb\n", expected: 3, mustFail: false},
+		{name: "wrong count", input: cliOutput, expected: 3, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			LineCount(tb, tc.input, tc.expected)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestLinesMatch(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected []string
+		mustFail bool
+	}{
+		{name: "reordered", expected: []string{"FAIL pkg/c", "ok  pkg/b 0.20s", "building...", "ok  pkg/a 0.01s"}, mustFail: false},
+		{name: "missing", expected: []string{"FAIL pkg/c", "ok  pkg/b 0.20s", "building...", "ok  pkg/a 0.01s", "ok  pkg/d 0.01s"}, mustFail: true},
+		{name: "unexpected", expected: []string{"FAIL pkg/c", "ok  pkg/b 0.20s", "building..."}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			LinesMatch(tb, tc.expected, cliOutput)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestLinesMatchRegexp(t *testing.T) {
+	cases := []struct {
+		name     string
+		patterns []string
+		mustFail bool
+	}{
+		{name: "all match", patterns: []string{`^building`, `^ok\s+pkg/a \d+\.\d+s$`, `^ok\s+pkg/b`, `^FAIL`}, mustFail: false},
+		{name: "line does not match", patterns: []string{`^building`, `^ok`, `^ok`, `^ok`}, mustFail: true},
+		{name: "too few patterns", patterns: []string{`^building`}, mustFail: true},
+		{name: "too many patterns", patterns: []string{``, ``, ``, ``, ``}, mustFail: true},
+		{name: "invalid pattern", patterns: []string{`(`, ``, ``, ``}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			LinesMatchRegexp(tb, tc.patterns, cliOutput)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestEqualNormalized(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		mustFail bool
+	}{
+		{name: "equal after normalizing", expected: "Hello", input: "HELLO", mustFail: false},
+		{name: "different", expected: "Hello", input: "Help", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			EqualNormalized(tb, tc.expected, tc.input, strings.ToLower)
+			tb.AssertExpectation()
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package assertions
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// StubResponse is the scripted response of a StubRoute
+type StubResponse struct {
+	Status int
+	Header http.Header
+	Body   string
+}
+
+// StubRoute is a scripted route of a StubServer, configured with its builder methods
+type StubRoute struct {
+	method   string
+	path     string
+	response StubResponse
+	times    int
+	checks   []func(req *http.Request, body []byte) string
+
+	hits       int
+	mismatches []string
+}
+
+// Times sets the exact number of times the route must be hit, by default a route must be hit at least once
+func (r *StubRoute) Times(n int) *StubRoute {
+	r.times = n
+	return r
+}
+
+// ExpectHeader requires requests to the route to carry a header key with the value expected among its values
+func (r *StubRoute) ExpectHeader(key, expected string) *StubRoute {
+	r.checks = append(r.checks, func(req *http.Request, _ []byte) string {
+		for _, v := range req.Header.Values(key) {
+			if v == expected {
+				return ""
+			}
+		}
+		return fmt.Sprintf("header %v: expected %q, got %q", key, expected, req.Header.Values(key))
+	})
+	return r
+}
+
+// ExpectJSONBody requires the bodies of requests to the route to be JSON equivalent to expected.
+// Options relax the comparison as for JSONEq
+func (r *StubRoute) ExpectJSONBody(expected string, opts ...JSONOption) *StubRoute {
+	r.checks = append(r.checks, func(_ *http.Request, body []byte) string {
+		diffs, err := jsonDifferences([]byte(expected), body, opts...)
+		if err != nil {
+			return err.Error()
+		}
+		if len(diffs) > 0 {
+			return "body does not match\n" + formatDifferences(diffs)
+		}
+		return ""
+	})
+	return r
+}
+
+// StubServer is an HTTP test server answering scripted routes. When the test ends the server is
+// closed and the test fails if any route was not hit the expected number of times, was hit with a
+// request that did not match its expectations, or if an unscripted route was requested
+type StubServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	routes     []*StubRoute
+	unexpected []string
+}
+
+// NewStubServer starts a StubServer which is verified and closed when tb's test ends
+func NewStubServer(tb testing.TB) *StubServer {
+	s := &StubServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	tb.Cleanup(func() {
+		s.Close()
+		s.Verify(tb)
+	})
+
+	return s
+}
+
+// Handle scripts the response for requests with the given method and path
+func (s *StubServer) Handle(method, path string, response StubResponse) *StubRoute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	route := &StubRoute{method: method, path: path, response: response, times: -1}
+	s.routes = append(s.routes, route)
+	return route
+}
+
+func (s *StubServer) serve(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var route *StubRoute
+	for _, r := range s.routes {
+		if r.method == req.Method && r.path == req.URL.Path {
+			route = r
+			break
+		}
+	}
+
+	if route == nil {
+		s.unexpected = append(s.unexpected, req.Method+" "+req.URL.Path)
+		http.NotFound(w, req)
+		return
+	}
+
+	route.hits++
+	for _, check := range route.checks {
+		if msg := check(req, body); msg != "" {
+			route.mismatches = append(route.mismatches, fmt.Sprintf("request %v: %v", route.hits, msg))
+		}
+	}
+
+	for k, values := range route.response.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	status := route.response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	io.WriteString(w, route.response.Body)
+}
+
+// Verify asserts that every route was hit the expected number of times with matching requests and
+// that no unscripted routes were requested. It is called automatically when the test ends
+func (s *StubServer) Verify(tb testing.TB) {
+	const failureFormat = "Stub server expectations were not met\n%v"
+
+	summary.recordAssertion()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b strings.Builder
+	for _, r := range s.routes {
+		switch {
+		case r.times < 0 && r.hits == 0:
+			fmt.Fprintf(&b, " ~ %v %v: expected at least 1 request, got 0\n", r.method, r.path)
+		case r.times >= 0 && r.hits != r.times:
+			fmt.Fprintf(&b, " ~ %v %v: expected %v requests, got %v\n", r.method, r.path, r.times, r.hits)
+		}
+		for _, m := range r.mismatches {
+			fmt.Fprintf(&b, " ~ %v %v: %v\n", r.method, r.path, strings.TrimSuffix(m, "\n"))
+		}
+	}
+	for _, u := range s.unexpected {
+		fmt.Fprintf(&b, " ~ %v: unscripted route requested\n", u)
+	}
+
+	if b.Len() > 0 {
+		errorfNow(tb, failureFormat, b.String())
+		return
+	}
+}
@@ -0,0 +1,91 @@
+package assertions
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStubServer(t *testing.T) {
+	post := func(t *testing.T, s *StubServer, path, body string) {
+		req, err := http.NewRequest(http.MethodPost, s.URL+path, strings.NewReader(body))
+		NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := s.Client().Do(req)
+		NoError(t, err)
+		resp.Body.Close()
+	}
+
+	cases := []struct {
+		name     string
+		script   func(s *StubServer)
+		requests func(t *testing.T, s *StubServer)
+		mustFail bool
+	}{
+		{
+			name: "all routes hit",
+			script: func(s *StubServer) {
+				s.Handle(http.MethodPost, "/users", StubResponse{Status: http.StatusCreated}).
+					ExpectHeader("Content-Type", "application/json").
+					ExpectJSONBody(`{"name": "x"}`)
+			},
+			requests: func(t *testing.T, s *StubServer) { post(t, s, "/users", `{"name":"x"}`) },
+			mustFail: false,
+		},
+		{
+			name: "route not hit",
+			script: func(s *StubServer) {
+				s.Handle(http.MethodPost, "/users", StubResponse{})
+			},
+			requests: func(t *testing.T, s *StubServer) {},
+			mustFail: true,
+		},
+		{
+			name: "wrong count",
+			script: func(s *StubServer) {
+				s.Handle(http.MethodPost, "/users", StubResponse{}).Times(2)
+			},
+			requests: func(t *testing.T, s *StubServer) { post(t, s, "/users", `{}`) },
+			mustFail: true,
+		},
+		{
+			name: "mismatched body",
+			script: func(s *StubServer) {
+				s.Handle(http.MethodPost, "/users", StubResponse{}).ExpectJSONBody(`{"name": "x"}`)
+			},
+			requests: func(t *testing.T, s *StubServer) { post(t, s, "/users", `{"name":"y"}`) },
+			mustFail: true,
+		},
+		{
+			name:     "unscripted route",
+			script:   func(s *StubServer) {},
+			requests: func(t *testing.T, s *StubServer) { post(t, s, "/users", `{}`) },
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			s := NewStubServer(tb)
+			tc.script(s)
+			tc.requests(t, s)
+
+			s.Verify(tb)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestStubServerResponse(t *testing.T) {
+	s := NewStubServer(t)
+	s.Handle(http.MethodGet, "/health", StubResponse{Status: http.StatusAccepted, Header: http.Header{"X-Stub": {"1"}}, Body: "ok"}).Times(1)
+
+	resp, err := s.Client().Get(s.URL + "/health")
+	NoError(t, err)
+	defer resp.Body.Close()
+
+	Equal(t, http.StatusAccepted, resp.StatusCode)
+	Equal(t, "1", resp.Header.Get("X-Stub"))
+}
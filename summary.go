@@ -0,0 +1,117 @@
+package assertions
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// summaryTop is the number of slowest waits and largest failures listed in the summary
+const summaryTop = 5
+
+type summaryEntry struct {
+	test string
+	size int64
+}
+
+// summaryStats accumulates the statistics reported by RunWithSummary. Nothing is recorded until it is enabled
+type summaryStats struct {
+	enabled  atomic.Bool
+	executed atomic.Int64
+	failed   atomic.Int64
+	warned   atomic.Int64
+
+	mu    sync.Mutex
+	waits []summaryEntry
+	diffs []summaryEntry
+}
+
+var summary summaryStats
+
+// recordAssertion counts an assertion, every exported assertion calls it once before checking its input
+func (s *summaryStats) recordAssertion() {
+	if s.enabled.Load() {
+		s.executed.Add(1)
+	}
+}
+
+// keepLargest inserts e into entries, which are ordered largest first, keeping at most summaryTop of them
+func keepLargest(entries []summaryEntry, e summaryEntry) []summaryEntry {
+	i, _ := slices.BinarySearchFunc(entries, e, func(a, b summaryEntry) int {
+		return int(b.size - a.size)
+	})
+	entries = slices.Insert(entries, i, e)
+	return entries[:min(len(entries), summaryTop)]
+}
+
+func (s *summaryStats) recordFailure(tb testing.TB, format string, args []any) {
+	if !s.enabled.Load() {
+		return
+	}
+	s.failed.Add(1)
+
+	message := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	lines := int64(strings.Count(message, "\n") + 1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.diffs = keepLargest(s.diffs, summaryEntry{test: tb.Name(), size: lines})
+}
+
+// recordWarning counts a failing assertion made through Warn, which does not fail the test
+func (s *summaryStats) recordWarning() {
+	if s.enabled.Load() {
+		s.warned.Add(1)
+	}
+}
+
+func (s *summaryStats) recordWait(tb testing.TB, elapsed time.Duration) {
+	if !s.enabled.Load() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.waits = keepLargest(s.waits, summaryEntry{test: tb.Name(), size: int64(elapsed)})
+}
+
+func (s *summaryStats) write(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "assertions summary\n ~ executed: %v\n ~ failed:   %v\n", s.executed.Load(), s.failed.Load())
+	if warned := s.warned.Load(); warned > 0 {
+		fmt.Fprintf(w, " ~ warned:   %v\n", warned)
+	}
+	if len(s.waits) > 0 {
+		fmt.Fprintf(w, " ~ slowest Eventually waits:\n")
+		for _, e := range s.waits {
+			fmt.Fprintf(w, "   %v %v\n", time.Duration(e.size), e.test)
+		}
+	}
+	if len(s.diffs) > 0 {
+		fmt.Fprintf(w, " ~ largest failures:\n")
+		for _, e := range s.diffs {
+			fmt.Fprintf(w, "   %v lines %v\n", e.size, e.test)
+		}
+	}
+}
+
+// RunWithSummary runs the tests of m and prints a summary of the assertions they made: how many were executed
+// and failed, how many only warned, the slowest Eventually waits and the failures with the longest messages.
+// It returns the exit code of m.Run and is meant to be called from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(assertions.RunWithSummary(m))
+//	}
+func RunWithSummary(m *testing.M) int {
+	summary.enabled.Store(true)
+	code := m.Run()
+	summary.write(os.Stdout)
+	return code
+}
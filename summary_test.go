@@ -0,0 +1,61 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummary(t *testing.T) {
+	var s summaryStats
+	s.recordAssertion()
+	s.recordFailure(t, "ignored while disabled\n", nil)
+
+	s.enabled.Store(true)
+	for range 3 {
+		s.recordAssertion()
+	}
+	s.recordFailure(t, "Values are not equal\n > expected: %v\n < input:    %v\n", []any{1, 2})
+	s.recordWarning()
+	s.recordWait(t, 2*time.Second)
+	s.recordWait(t, time.Second)
+	s.recordWait(t, 3*time.Second)
+
+	var b strings.Builder
+	s.write(&b)
+
+	expected := "assertions summary\n" +
+		" ~ executed: 3\n" +
+		" ~ failed:   1\n" +
+		" ~ warned:   1\n" +
+		" ~ slowest Eventually waits:\n" +
+		"   3s TestSummary\n" +
+		"   2s TestSummary\n" +
+		"   1s TestSummary\n" +
+		" ~ largest failures:\n" +
+		"   3 lines TestSummary\n"
+	Equal(t, expected, b.String())
+}
+
+func TestKeepLargest(t *testing.T) {
+	var entries []summaryEntry
+	for _, size := range []int64{4, 9, 1, 7, 3, 8, 2} {
+		entries = keepLargest(entries, summaryEntry{size: size})
+	}
+
+	var sizes []int64
+	for _, e := range entries {
+		sizes = append(sizes, e.size)
+	}
+	Equal(t, []int64{9, 8, 7, 4, 3}, sizes)
+}
+
+func TestSummaryDisabled(t *testing.T) {
+	var s summaryStats
+	s.recordAssertion()
+	s.recordWait(t, time.Second)
+
+	var b strings.Builder
+	s.write(&b)
+	Equal(t, "assertions summary\n ~ executed: 0\n ~ failed:   0\n", b.String())
+}
@@ -0,0 +1,15 @@
+module github.com/jcopi/assertions/terraform
+
+go 1.23.0
+
+require (
+	github.com/hashicorp/terraform-json v0.23.0
+	github.com/jcopi/assertions v0.1.0
+)
+
+require (
+	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
+	github.com/hashicorp/go-version v1.7.0 // indirect
+	github.com/zclconf/go-cty v1.15.0 // indirect
+	golang.org/x/text v0.11.0 // indirect
+)
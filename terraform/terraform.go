@@ -0,0 +1,141 @@
+// Package terraform asserts on Terraform plans in the JSON format written by terraform show -json,
+// so infrastructure modules can be tested by the changes they plan rather than by applying them.
+// It is a separate module so the assertions package stays free of dependencies
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/jcopi/assertions"
+)
+
+// LoadPlan reads the JSON plan at path, as written by terraform show -json plan.out > plan.json.
+// The test fails if the file cannot be read or is not a plan
+func LoadPlan(tb testing.TB, path string) *tfjson.Plan {
+	plan := assertions.LoadJSON[tfjson.Plan](tb, path)
+	return &plan
+}
+
+// Counts is the number of resources a plan changes for each kind of change. A replaced resource
+// is counted as a replacement only, not as a create and a destroy
+type Counts struct {
+	Creates      int
+	Updates      int
+	Destroys     int
+	Replacements int
+}
+
+// countChanges counts the managed resource changes of plan, listing the addresses for each kind of change
+func countChanges(plan *tfjson.Plan) (Counts, map[string][]string) {
+	var counts Counts
+	addresses := make(map[string][]string)
+	for _, rc := range plan.ResourceChanges {
+		if rc.Mode != tfjson.ManagedResourceMode || rc.Change == nil {
+			continue
+		}
+		switch a := rc.Change.Actions; {
+		case a.Replace():
+			counts.Replacements++
+			addresses["replace"] = append(addresses["replace"], rc.Address)
+		case a.Create():
+			counts.Creates++
+			addresses["create"] = append(addresses["create"], rc.Address)
+		case a.Update():
+			counts.Updates++
+			addresses["update"] = append(addresses["update"], rc.Address)
+		case a.Delete():
+			counts.Destroys++
+			addresses["destroy"] = append(addresses["destroy"], rc.Address)
+		}
+	}
+	return counts, addresses
+}
+
+// ChangeCounts asserts that plan creates, updates, destroys and replaces the expected numbers of managed
+// resources. Data sources and resources left unchanged are not counted. Failing results list the
+// addresses of the resources planned for each kind of change
+func ChangeCounts(tb testing.TB, plan *tfjson.Plan, expected Counts) {
+	counts, addresses := countChanges(plan)
+	if counts != expected {
+		for _, kind := range []string{"create", "update", "destroy", "replace"} {
+			if len(addresses[kind]) > 0 {
+				tb.Logf("planned to %v: %v", kind, strings.Join(addresses[kind], ", "))
+			}
+		}
+	}
+	assertions.Equal(tb, expected, counts)
+}
+
+// findChange returns the change planned for the resource at address
+func findChange(plan *tfjson.Plan, address string) (*tfjson.ResourceChange, error) {
+	var planned []string
+	for _, rc := range plan.ResourceChanges {
+		if rc.Address == address {
+			return rc, nil
+		}
+		planned = append(planned, rc.Address)
+	}
+	return nil, fmt.Errorf("plan has no change for %v, it has changes for %v", address, planned)
+}
+
+// attributeValue returns the value at the dotted path within value, indexing lists by number
+func attributeValue(value any, path string) (any, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				return nil, fmt.Errorf("attribute %v has no %q", path, key)
+			}
+			value = next
+		case []any:
+			var i int
+			if _, err := fmt.Sscan(key, &i); err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("attribute %v has no index %q in a list of %v", path, key, len(v))
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("attribute %v cannot be indexed by %q in %v", path, key, v)
+		}
+	}
+	return value, nil
+}
+
+// AttributeEqual asserts that the resource at address, such as "module.net.aws_subnet.private[0]", has the
+// expected value for attribute after the plan is applied. attribute is a dotted path with list elements
+// indexed by number, such as "tags.Name" or "ingress.0.from_port". expected is compared as by
+// assertions.Equal with the JSON representation of the value, so any Go value encoding to it is equal,
+// e.g. 443 for a number or map[string]string for a map. Values unknown until apply are absent from the plan
+func AttributeEqual(tb testing.TB, plan *tfjson.Plan, address, attribute string, expected any) {
+	rc, err := findChange(plan, address)
+	if err != nil {
+		assertions.NoError(tb, err)
+		return
+	}
+	var after any
+	if rc.Change != nil {
+		after = rc.Change.After
+	}
+	value, err := attributeValue(after, attribute)
+	if err != nil {
+		assertions.NoError(tb, fmt.Errorf("%v: %w", address, err))
+		return
+	}
+
+	// Encoding expected gives it the types value was decoded with
+	data, err := json.Marshal(expected)
+	if err != nil {
+		assertions.NoError(tb, fmt.Errorf("encoding expected: %w", err))
+		return
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		assertions.NoError(tb, fmt.Errorf("decoding expected: %w", err))
+		return
+	}
+	assertions.Equal(tb, normalized, value)
+}
@@ -0,0 +1,77 @@
+package terraform
+
+import (
+	"testing"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+func TestChangeCounts(t *testing.T) {
+	plan := LoadPlan(t, "testdata/plan.json")
+
+	cases := []struct {
+		name     string
+		expected Counts
+		mustFail bool
+	}{
+		{name: "matching", expected: Counts{Creates: 1, Updates: 1, Destroys: 1, Replacements: 1}, mustFail: false},
+		{name: "different", expected: Counts{Creates: 2, Updates: 1, Destroys: 1, Replacements: 1}, mustFail: true},
+		{name: "replacement counted as create and destroy", expected: Counts{Creates: 2, Updates: 1, Destroys: 2}, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			ChangeCounts(tb, plan, tc.expected)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
+
+func TestAttributeEqual(t *testing.T) {
+	plan := LoadPlan(t, "testdata/plan.json")
+
+	cases := []struct {
+		name      string
+		address   string
+		attribute string
+		expected  any
+		mustFail  bool
+	}{
+		{name: "string", address: "aws_s3_bucket.logs", attribute: "bucket", expected: "example-logs", mustFail: false},
+		{name: "bool", address: "aws_s3_bucket.logs", attribute: "force_destroy", expected: false, mustFail: false},
+		{name: "map", address: "aws_s3_bucket.logs", attribute: "tags", expected: map[string]string{"Team": "platform"}, mustFail: false},
+		{name: "nested", address: "aws_s3_bucket.logs", attribute: "tags.Team", expected: "platform", mustFail: false},
+		{name: "list element", address: "aws_security_group.web", attribute: "ingress.0.from_port", expected: 443, mustFail: false},
+		{name: "indexed address", address: "aws_instance.app[0]", attribute: "ami", expected: "ami-2", mustFail: false},
+		{name: "different value", address: "aws_security_group.web", attribute: "ingress.0.from_port", expected: 80, mustFail: true},
+		{name: "unknown until apply", address: "aws_s3_bucket.logs", attribute: "arn", expected: "", mustFail: true},
+		{name: "index out of range", address: "aws_security_group.web", attribute: "ingress.1.from_port", expected: 443, mustFail: true},
+		{name: "destroyed", address: "aws_instance.old", attribute: "ami", expected: "ami-1", mustFail: true},
+		{name: "missing resource", address: "aws_instance.missing", attribute: "ami", expected: "ami-1", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			AttributeEqual(tb, plan, tc.address, tc.attribute, tc.expected)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/jcopi/assertions/textnorm
+
+go 1.23.0
+
+require (
+	github.com/jcopi/assertions v0.1.0
+	golang.org/x/text v0.21.0
+)
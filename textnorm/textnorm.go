@@ -0,0 +1,44 @@
+// Package textnorm provides Unicode normalization aware string assertions.
+// It is a separate module so the assertions package stays free of dependencies
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/jcopi/assertions"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+type config struct {
+	fold bool
+}
+
+// Option configures EqualNFC
+type Option func(*config)
+
+// CaseFold additionally compares strings with Unicode case folding, so "Straße" equals "STRASSE"
+func CaseFold() Option {
+	return func(c *config) {
+		c.fold = true
+	}
+}
+
+// EqualNFC asserts that expected and input are equal after both are converted to Unicode
+// normalization form C, so precomposed and decomposed characters compare equal
+func EqualNFC(tb testing.TB, expected, input string, opts ...Option) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	normalize := norm.NFC.String
+	if cfg.fold {
+		fold := cases.Fold()
+		normalize = func(s string) string {
+			return norm.NFC.String(fold.String(norm.NFD.String(s)))
+		}
+	}
+
+	assertions.EqualNormalized(tb, expected, input, normalize)
+}
@@ -0,0 +1,45 @@
+package textnorm
+
+import (
+	"testing"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+func TestEqualNFC(t *testing.T) {
+	cases := []struct {
+		name     string
+		expected string
+		input    string
+		opts     []Option
+		mustFail bool
+	}{
+		{name: "identical", expected: "café", input: "café", mustFail: false},
+		{name: "decomposed", expected: "caf\u00e9", input: "cafe\u0301", mustFail: false},
+		{name: "different letters", expected: "cafe", input: "café", mustFail: true},
+		{name: "case differs", expected: "Café", input: "CAFÉ", mustFail: true},
+		{name: "case folded", expected: "Café", input: "CAFÉ", opts: []Option{CaseFold()}, mustFail: false},
+		{name: "case folded sharp s", expected: "Straße", input: "STRASSE", opts: []Option{CaseFold()}, mustFail: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			EqualNFC(tb, tc.expected, tc.input, tc.opts...)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package assertions
+
+import (
+	"testing"
+	"time"
+)
+
+// TimeWithinRange asserts that input is within the range [start, end]
+// Failing results report how far before start or after end input is
+func TimeWithinRange(tb testing.TB, start, end, input time.Time) {
+	const beforeFormat = "time is before the expected range by %v\n > expected: [%v, %v]\n < input:    %v\n"
+	const afterFormat = "time is after the expected range by %v\n > expected: [%v, %v]\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	switch {
+	case input.Before(start):
+		errorfNow(tb, beforeFormat, start.Sub(input), start, end, input)
+		return
+	case input.After(end):
+		errorfNow(tb, afterFormat, input.Sub(end), start, end, input)
+		return
+	}
+}
+
+// DurationWithin asserts that input is within the range [minD, maxD]
+// Failing results print durations in units and report how far outside the range input is
+func DurationWithin(tb testing.TB, minD, maxD, input time.Duration) {
+	const shortFormat = "duration is shorter than the expected range by %v\n > expected: [%v, %v]\n < input:    %v\n"
+	const longFormat = "duration is longer than the expected range by %v\n > expected: [%v, %v]\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	switch {
+	case input < minD:
+		errorfNow(tb, shortFormat, minD-input, minD, maxD, input)
+		return
+	case input > maxD:
+		errorfNow(tb, longFormat, input-maxD, minD, maxD, input)
+		return
+	}
+}
@@ -0,0 +1,55 @@
+package assertions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWithinRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	cases := []struct {
+		name     string
+		input    time.Time
+		mustFail bool
+	}{
+		{name: "start", input: start, mustFail: false},
+		{name: "end", input: end, mustFail: false},
+		{name: "middle", input: start.Add(30 * time.Minute), mustFail: false},
+		{name: "other zone", input: start.In(time.FixedZone("UTC+1", 3600)), mustFail: false},
+		{name: "before", input: start.Add(-time.Nanosecond), mustFail: true},
+		{name: "after", input: end.Add(time.Second), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			TimeWithinRange(tb, start, end, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestDurationWithin(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    time.Duration
+		mustFail bool
+	}{
+		{name: "min", input: time.Second, mustFail: false},
+		{name: "max", input: 2 * time.Second, mustFail: false},
+		{name: "short", input: 999 * time.Millisecond, mustFail: true},
+		{name: "long", input: 3 * time.Second, mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			DurationWithin(tb, time.Second, 2*time.Second, tc.input)
+			tb.AssertExpectation()
+		})
+	}
+}
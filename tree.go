@@ -0,0 +1,66 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// treeDifference is the first point at which two trees differ, path holds the keys of the nodes leading to it
+type treeDifference struct {
+	path     []string
+	expected string
+	input    string
+}
+
+func formatChildKeys[T any, K comparable](nodes []T, key func(T) K) string {
+	keys := make([]string, len(nodes))
+	for i, n := range nodes {
+		keys[i] = formatValue(key(n))
+	}
+	return fmt.Sprintf("%v children [%v]", len(nodes), strings.Join(keys, ", "))
+}
+
+// diffTrees compares expected and input depth first. path holds the labels of their ancestors,
+// position is the label of their index among their siblings, empty for the roots
+func diffTrees[T any, K comparable](path []string, position string, expected, input T, children func(T) []T, key func(T) K) (treeDifference, bool) {
+	ek, ik := key(expected), key(input)
+	if ek != ik {
+		if position != "" {
+			path = append(path, position)
+		}
+		return treeDifference{path: path, expected: formatValue(ek), input: formatValue(ik)}, true
+	}
+
+	path = append(path, strings.TrimSpace(position+" "+formatValue(ek)))
+	ec, ic := children(expected), children(input)
+	if len(ec) != len(ic) {
+		return treeDifference{path: path, expected: formatChildKeys(ec, key), input: formatChildKeys(ic, key)}, true
+	}
+
+	for i := range ec {
+		if diff, ok := diffTrees(path, fmt.Sprintf("[%v]", i), ec[i], ic[i], children, key); ok {
+			return diff, true
+		}
+	}
+
+	return treeDifference{}, false
+}
+
+// TreesEqual asserts that two recursive structures have the same shape, with nodes identified by key and children
+// compared by position. Failing results report the path of keys leading to the first difference,
+// which is far easier to read than the output of Equal for ASTs and file trees
+func TreesEqual[T any, K comparable](tb testing.TB, expected, input T, children func(T) []T, key func(T) K) {
+	const failureFormat = "Trees are not equal\n ~ at: %v\n > expected: %v\n < input:    %v\n"
+
+	summary.recordAssertion()
+
+	if diff, ok := diffTrees(nil, "", expected, input, children, key); ok {
+		at := "(root)"
+		if len(diff.path) > 0 {
+			at = strings.Join(diff.path, " > ")
+		}
+		errorfNow(tb, failureFormat, at, diff.expected, diff.input)
+		return
+	}
+}
@@ -0,0 +1,81 @@
+package assertions
+
+import "testing"
+
+type treeNode struct {
+	name     string
+	children []*treeNode
+}
+
+func node(name string, children ...*treeNode) *treeNode {
+	return &treeNode{name: name, children: children}
+}
+
+func TestTreesEqual(t *testing.T) {
+	children := func(n *treeNode) []*treeNode { return n.children }
+	key := func(n *treeNode) string { return n.name }
+
+	cases := []struct {
+		name     string
+		expected *treeNode
+		input    *treeNode
+		mustFail bool
+	}{
+		{
+			name:     "single node",
+			expected: node("root"),
+			input:    node("root"),
+			mustFail: false,
+		},
+		{
+			name:     "nested",
+			expected: node("root", node("a", node("a1")), node("b")),
+			input:    node("root", node("a", node("a1")), node("b")),
+			mustFail: false,
+		},
+		{
+			name:     "different root",
+			expected: node("root"),
+			input:    node("other"),
+			mustFail: true,
+		},
+		{
+			name:     "different leaf",
+			expected: node("root", node("a", node("a1")), node("b")),
+			input:    node("root", node("a", node("a2")), node("b")),
+			mustFail: true,
+		},
+		{
+			name:     "missing child",
+			expected: node("root", node("a"), node("b")),
+			input:    node("root", node("a")),
+			mustFail: true,
+		},
+		{
+			name:     "reordered children",
+			expected: node("root", node("a"), node("b")),
+			input:    node("root", node("b"), node("a")),
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			TreesEqual(tb, tc.expected, tc.input, children, key)
+			tb.AssertExpectation()
+		})
+	}
+}
+
+func TestDiffTreesPath(t *testing.T) {
+	children := func(n *treeNode) []*treeNode { return n.children }
+	key := func(n *treeNode) string { return n.name }
+
+	diff, ok := diffTrees(nil, "", node("root", node("a"), node("b", node("b1"))), node("root", node("a"), node("b", node("b2"))), children, key)
+	Equal(t, true, ok)
+	Equal(t, []string{`"root"`, `[1] "b"`, `[0]`}, diff.path)
+	Equal(t, `"b1"`, diff.expected)
+	Equal(t, `"b2"`, diff.input)
+}
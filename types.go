@@ -0,0 +1,47 @@
+package assertions
+
+import (
+	"reflect"
+	"testing"
+)
+
+// isTypedNil reports whether v holds a nil pointer, map, slice, channel, function or interface
+func isTypedNil(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// AsNonNil asserts that input is non-nil, does not hold a typed nil such as a nil pointer, and has type T.
+// The concrete value is returned, on failure the zero value of T is returned
+func AsNonNil[T any](tb testing.TB, input any) T {
+	const nilFormat = "value is nil\n > expected type: %v\n"
+	const typedNilFormat = "value is a typed nil\n > expected type: %v\n < input type:    %T\n"
+	const typeFormat = "value has the wrong type\n > expected type: %v\n < input type:    %T\n < input:         %#v\n"
+
+	summary.recordAssertion()
+
+	var zero T
+	expectedType := reflect.TypeFor[T]()
+
+	if input == nil {
+		errorfNow(tb, nilFormat, expectedType)
+		return zero
+	}
+
+	if isTypedNil(input) {
+		errorfNow(tb, typedNilFormat, expectedType, input)
+		return zero
+	}
+
+	value, ok := input.(T)
+	if !ok {
+		errorfNow(tb, typeFormat, expectedType, input, input)
+		return zero
+	}
+
+	return value
+}
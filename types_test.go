@@ -0,0 +1,50 @@
+package assertions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestAsNonNil(t *testing.T) {
+	var nilBuffer *bytes.Buffer
+	var nilReader io.Reader = nilBuffer
+
+	cases := []struct {
+		name     string
+		input    any
+		mustFail bool
+	}{
+		{name: "concrete pointer", input: &bytes.Buffer{}, mustFail: false},
+		{name: "through interface", input: io.Reader(&bytes.Buffer{}), mustFail: false},
+		{name: "nil", input: nil, mustFail: true},
+		{name: "typed nil", input: nilBuffer, mustFail: true},
+		{name: "typed nil in interface", input: nilReader, mustFail: true},
+		{name: "wrong type", input: &bytes.Reader{}, mustFail: true},
+		{name: "non pointer", input: fmt.Errorf("error"), mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := NewTester(t, tc.mustFail)
+
+			value := AsNonNil[*bytes.Buffer](tb, tc.input)
+			tb.AssertExpectation()
+			if tc.mustFail {
+				Equal(t, nil, value)
+			} else {
+				Equal(t, tc.input, any(value))
+			}
+		})
+	}
+}
+
+func TestAsNonNilInterface(t *testing.T) {
+	tb := NewTester(t, false)
+
+	err := AsNonNil[error](tb, errors.New("error"))
+	tb.AssertExpectation()
+	ErrorsMatch(t, errors.New("error"), err)
+}
@@ -0,0 +1,101 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// StrictWarningsEnv names the environment variable that, when set to a non-empty value,
+// turns warnings into failures
+const StrictWarningsEnv = "ASSERTIONS_STRICT_WARNINGS"
+
+const warningPrefix = "WARNING (set " + StrictWarningsEnv + "=1 to fail): "
+
+func strictWarnings() bool {
+	return os.Getenv(StrictWarningsEnv) != ""
+}
+
+// warnTB is a testing.TB that logs failures as warnings instead of failing the test. Failing assertions
+// recognize it and warn rather than counting a failure, other messages are logged as usual
+type warnTB struct {
+	testing.TB
+}
+
+var _ testing.TB = &warnTB{}
+
+// warner is implemented by warnTB and by the TBs that wrap another and pass its failures on,
+// so that a warning TB still warns when it is wrapped, e.g. by With
+type warner interface {
+	// warnFunc returns the function that logs a failure message as a warning, or nil when failures are not warnings
+	warnFunc() func(message string)
+}
+
+// warnerOf returns the function that logs failures on tb as warnings, or nil when tb does not warn
+func warnerOf(tb testing.TB) func(message string) {
+	if w, ok := tb.(warner); ok {
+		return w.warnFunc()
+	}
+	return nil
+}
+
+// Warn returns a testing.TB on which failing assertions log a warning and let the test continue,
+// for checks that are not enforced yet, e.g. Equal(Warn(tb), budget, used).
+// When the ASSERTIONS_STRICT_WARNINGS environment variable is set the warnings fail the test as usual
+func Warn(tb testing.TB) testing.TB {
+	if strictWarnings() {
+		return tb
+	}
+	return &warnTB{TB: tb}
+}
+
+// Warnf logs a warning, unless the ASSERTIONS_STRICT_WARNINGS environment variable is set in which case
+// the test fails with the message
+func Warnf(tb testing.TB, format string, args ...any) {
+	errorfNow(Warn(tb), format, args...)
+}
+
+// WarnEqual is Equal, logging a warning instead of failing, see Warn
+func WarnEqual[T any](tb testing.TB, expected, input T, opts ...CompareOption) {
+	Equal(Warn(tb), expected, input, opts...)
+}
+
+// warn logs the message of a failure as a warning
+func (w *warnTB) warn(message string) {
+	summary.recordWarning()
+	if colorOutput.Load() {
+		message = colorize(message)
+	}
+	w.TB.Log(warningPrefix + message)
+}
+
+// warnFunc implements warner.
+func (w *warnTB) warnFunc() func(message string) {
+	return w.warn
+}
+
+// Error implements testing.TB.
+func (w *warnTB) Error(args ...any) {
+	w.warn(fmt.Sprint(args...))
+}
+
+// Errorf implements testing.TB.
+func (w *warnTB) Errorf(format string, args ...any) {
+	w.warn(fmt.Sprintf(format, args...))
+}
+
+// Fail implements testing.TB.
+func (w *warnTB) Fail() {}
+
+// FailNow implements testing.TB. The test continues, the warning has already been logged
+func (w *warnTB) FailNow() {}
+
+// Fatal implements testing.TB.
+func (w *warnTB) Fatal(args ...any) {
+	w.warn(fmt.Sprint(args...))
+}
+
+// Fatalf implements testing.TB.
+func (w *warnTB) Fatalf(format string, args ...any) {
+	w.warn(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,110 @@
+package assertions
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWarn(t *testing.T) {
+	cases := []struct {
+		name     string
+		strict   string
+		mustFail bool
+	}{
+		{name: "warning", strict: "", mustFail: false},
+		{name: "strict", strict: "1", mustFail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(StrictWarningsEnv, tc.strict)
+
+			rec := &recordingTB{TesterTB: NewTester(t, tc.mustFail)}
+			WarnEqual(rec, 1, 2)
+			rec.AssertExpectation()
+
+			Equal(t, 1, len(rec.logs))
+			Equal(t, !tc.mustFail, strings.HasPrefix(rec.logs[0], "WARNING"))
+		})
+	}
+}
+
+func TestWarnf(t *testing.T) {
+	t.Setenv(StrictWarningsEnv, "")
+
+	rec := &recordingTB{TesterTB: NewTester(t, false)}
+	Warnf(rec, "deprecated option %v", "x")
+	rec.AssertExpectation()
+
+	Equal(t, []string{warningPrefix + "deprecated option x"}, rec.logs)
+}
+
+func TestWarnLogs(t *testing.T) {
+	t.Setenv(StrictWarningsEnv, "")
+
+	rec := &recordingTB{TesterTB: NewTester(t, false)}
+	Warn(rec).Logf("progress %v", 1)
+	rec.AssertExpectation()
+
+	Equal(t, []string{"progress 1"}, rec.logs)
+}
+
+func TestWarnSummary(t *testing.T) {
+	t.Setenv(StrictWarningsEnv, "")
+	defer summary.enabled.Store(summary.enabled.Load())
+	summary.enabled.Store(true)
+
+	failed, warned := summary.failed.Load(), summary.warned.Load()
+	rec := &recordingTB{TesterTB: NewTester(t, false)}
+	WarnEqual(rec, 1, 2)
+	Warnf(rec, "deprecated")
+	rec.AssertExpectation()
+
+	Equal(t, failed, summary.failed.Load())
+	Equal(t, warned+2, summary.warned.Load())
+}
+
+func TestWarnPassing(t *testing.T) {
+	t.Setenv(StrictWarningsEnv, "")
+
+	rec := &recordingTB{TesterTB: NewTester(t, false)}
+	NoError(Warn(rec), nil)
+	rec.AssertExpectation()
+
+	Equal(t, 0, len(rec.logs))
+}
+
+func TestWarnWrapped(t *testing.T) {
+	t.Setenv(StrictWarningsEnv, "")
+
+	rec := &recordingTB{TesterTB: NewTester(t, false)}
+	NoError(With(Warn(rec), "id", 7), errors.New("deprecated"))
+	rec.AssertExpectation()
+
+	Equal(t, []string{warningPrefix + "Unexpected error occurred\n > Error: deprecated\n @ id: 7\n"}, rec.logs)
+}
+
+func TestWarnGoroutine(t *testing.T) {
+	t.Setenv(StrictWarningsEnv, "")
+	defer summary.enabled.Store(summary.enabled.Load())
+	summary.enabled.Store(true)
+
+	failed, warned := summary.failed.Load(), summary.warned.Load()
+	rec := &recordingTB{TesterTB: NewTester(t, false)}
+	g := Go(Warn(rec))
+	g.Go(func(tb testing.TB) {
+		tb.Log("started")
+		Equal(tb, 1, 2)
+		tb.Log("finished")
+	})
+	g.Wait()
+	rec.AssertExpectation()
+
+	Equal(t, 3, len(rec.logs))
+	Equal(t, "started", rec.logs[0])
+	Equal(t, true, strings.HasPrefix(rec.logs[1], warningPrefix))
+	Equal(t, "finished", rec.logs[2])
+	Equal(t, failed, summary.failed.Load())
+	Equal(t, warned+1, summary.warned.Load())
+}
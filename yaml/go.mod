@@ -0,0 +1,8 @@
+module github.com/jcopi/assertions/yaml
+
+go 1.23.0
+
+require (
+	github.com/jcopi/assertions v0.1.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,23 @@
+// Package yaml loads YAML test fixtures with the assertions package.
+// It is a separate module so the assertions package stays free of dependencies
+package yaml
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/jcopi/assertions"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadYAML reads the YAML file at path, typically under testdata, and decodes it into a T.
+// The test fails if the file cannot be read or decoded, decoding errors report the line.
+// On failure the zero value of T is returned
+func LoadYAML[T any](tb testing.TB, path string) T {
+	return assertions.LoadWith[T](tb, path, yaml.Unmarshal)
+}
+
+// LoadYAMLFS is LoadYAML reading the file from fsys, such as an embed.FS of testdata
+func LoadYAMLFS[T any](tb testing.TB, fsys fs.FS, path string) T {
+	return assertions.LoadWithFS[T](tb, fsys, path, yaml.Unmarshal)
+}
@@ -0,0 +1,89 @@
+package yaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jcopi/assertions"
+)
+
+// tester records failures without stopping the test, mirroring the tester used by the assertions package
+type tester struct {
+	testing.TB
+	failed bool
+}
+
+func (t *tester) Logf(format string, args ...any) {}
+
+func (t *tester) FailNow() {
+	t.failed = true
+}
+
+type service struct {
+	Name     string   `yaml:"name"`
+	Replicas int      `yaml:"replicas"`
+	Ports    []int    `yaml:"ports"`
+	Labels   []string `yaml:"labels"`
+}
+
+func TestLoadYAML(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		assertions.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+		return path
+	}
+
+	cases := []struct {
+		name     string
+		path     string
+		expected service
+		mustFail bool
+	}{
+		{
+			name:     "valid",
+			path:     write("valid.yaml", "name: api\nreplicas: 2\nports: [80, 443]\n"),
+			expected: service{Name: "api", Replicas: 2, Ports: []int{80, 443}},
+			mustFail: false,
+		},
+		{
+			name:     "wrong type",
+			path:     write("type.yaml", "name: api\nreplicas: two\n"),
+			mustFail: true,
+		},
+		{
+			name:     "invalid",
+			path:     write("invalid.yaml", "name: [api\n"),
+			mustFail: true,
+		},
+		{
+			name:     "missing file",
+			path:     filepath.Join(dir, "missing.yaml"),
+			mustFail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tb := &tester{TB: t}
+
+			v := LoadYAML[service](tb, tc.path)
+			if tb.failed != tc.mustFail {
+				t.Fatalf("Failure was not as expected:\n > expected: %v\n < actual: %v\n", tc.mustFail, tb.failed)
+			}
+			assertions.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestLoadYAMLFS(t *testing.T) {
+	fsys := fstest.MapFS{"testdata/service.yaml": {Data: []byte("name: api\nreplicas: 3\n")}}
+
+	tb := &tester{TB: t}
+	assertions.Equal(t, service{Name: "api", Replicas: 3}, LoadYAMLFS[service](tb, fsys, "testdata/service.yaml"))
+	if tb.failed {
+		t.Fatalf("loading an existing fixture failed")
+	}
+}
@@ -0,0 +1,8 @@
+module github.com/jcopi/assertions/zstd
+
+go 1.23.0
+
+require (
+	github.com/jcopi/assertions v0.1.0
+	github.com/klauspost/compress v1.17.11
+)
@@ -0,0 +1,22 @@
+// Package zstd provides a zstd Decompressor for assertions.DecompressedEqual.
+// It is a separate module so the assertions package stays free of dependencies
+package zstd
+
+import (
+	"io"
+
+	"github.com/jcopi/assertions"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Zstd decodes data compressed with zstd
+var Zstd = assertions.Decompressor{
+	Name: "zstd",
+	NewReader: func(r io.Reader) (io.ReadCloser, error) {
+		d, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	},
+}
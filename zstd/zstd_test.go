@@ -0,0 +1,22 @@
+package zstd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jcopi/assertions"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestZstd(t *testing.T) {
+	payload := []byte("hello\ncompressed\nworld\n")
+
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	assertions.NoError(t, err)
+	_, err = w.Write(payload)
+	assertions.NoError(t, err)
+	assertions.NoError(t, w.Close())
+
+	assertions.DecompressedEqual(t, payload, bytes.NewReader(buf.Bytes()), Zstd)
+}